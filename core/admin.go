@@ -100,19 +100,49 @@ func getAllHandlers(hoverfly *Hoverfly) []handlers.AdminHandler {
 		&v2.HoverflyDestinationHandler{Hoverfly: hoverfly},
 		&v2.HoverflyModeHandler{Hoverfly: hoverfly},
 		&v2.HoverflyMiddlewareHandler{Hoverfly: hoverfly},
+		&v2.HoverflyMiddlewareChainHandler{Hoverfly: hoverfly},
 		&v2.HoverflyUsageHandler{Hoverfly: hoverfly},
+		&v2.HoverflyMetricsHandler{Hoverfly: hoverfly},
 		&v2.HoverflyVersionHandler{Hoverfly: hoverfly},
 		&v2.HoverflyUpstreamProxyHandler{Hoverfly: hoverfly},
 		&v2.HoverflyPACHandler{Hoverfly: hoverfly},
 		&v2.HoverflyCORSHandler{Hoverfly: hoverfly},
+		&v2.HoverflyTemplatingHandler{Hoverfly: hoverfly},
 		&v2.SimulationHandler{Hoverfly: hoverfly},
+		&v2.SimulationCompactHandler{Hoverfly: hoverfly},
 		&v2.CacheHandler{Hoverfly: hoverfly},
 		&v2.LogsHandler{Hoverfly: hoverfly.StoreLogsHook},
-		&v2.JournalHandler{Hoverfly: hoverfly.Journal},
+		&v2.JournalHandler{Hoverfly: hoverfly.Journal, Version: hoverfly.version},
 		&v2.ShutdownHandler{},
 		&v2.StateHandler{Hoverfly: hoverfly},
+		&v2.ScenarioHandler{Hoverfly: hoverfly},
 		&v2.DiffHandler{Hoverfly: hoverfly},
+		&v2.SessionHandler{Hoverfly: hoverfly},
 	}
 
 	return list
 }
+
+// getSessionHandlers returns the handlers which can be used under /api/v2/sessions/{name}
+func getSessionHandlers(session *Hoverfly) []handlers.AdminHandler {
+	return []handlers.AdminHandler{
+		&v2.SimulationHandler{Hoverfly: session},
+		&v2.StateHandler{Hoverfly: session},
+		&v2.ScenarioHandler{Hoverfly: session},
+		&v2.JournalHandler{Hoverfly: session.Journal, Version: session.version},
+	}
+}
+
+// newSessionMux routes the requests for a session, with /api/v2/sessions/{name} replaced
+// by /api/v2, to its handlers
+func newSessionMux(session *Hoverfly) *bone.Mux {
+	// Requests have already been authenticated by the route of the session
+	authHandler := &handlers.AuthHandler{}
+
+	mux := bone.New()
+	for _, handler := range getSessionHandlers(session) {
+		handler.RegisterRoutes(mux, authHandler)
+	}
+
+	return mux
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching"
+	"github.com/SpectoLabs/hoverfly/core/models"
 	. "github.com/onsi/gomega"
 	"io/ioutil"
 	"net/http"
@@ -192,3 +194,53 @@ func BenchmarkProcessRequest(b *testing.B) {
 
 	hoverfly.StopProxy()
 }
+
+func BenchmarkMatching(b *testing.B) {
+	RegisterTestingT(b)
+
+	for _, simulationSize := range []int{100, 1000, 10000, 40000} {
+		hoverfly := NewHoverflyWithConfiguration(&Configuration{
+			DisableCache: true,
+		})
+
+		pairViews := []v2.RequestMatcherResponsePairViewV5{}
+		for i := 0; i < simulationSize; i++ {
+			pairViews = append(pairViews, v2.RequestMatcherResponsePairViewV5{
+				RequestMatcher: v2.RequestMatcherViewV5{
+					Method:      []v2.MatcherViewV5{v2.NewMatcherView("exact", "GET")},
+					Destination: []v2.MatcherViewV5{v2.NewMatcherView("exact", "test.com")},
+					Path:        []v2.MatcherViewV5{v2.NewMatcherView("exact", fmt.Sprintf("/api/%v", i))},
+				},
+				Response: v2.ResponseDetailsViewV5{
+					Status: 200,
+					Body:   fmt.Sprintf("response %v", i),
+				},
+			})
+		}
+
+		hoverfly.Cfg.NoImportCheck = true
+		hoverfly.PutSimulation(v2.SimulationViewV5{
+			DataViewV5: v2.DataViewV5{RequestResponsePairs: pairViews},
+		})
+
+		// The last pair is the worst case for a linear scan
+		request := models.RequestDetails{
+			Method:      "GET",
+			Destination: "test.com",
+			Path:        fmt.Sprintf("/api/%v", simulationSize-1),
+		}
+
+		var result *matching.MatchingResult
+
+		for _, strategy := range []string{"first", "strongest"} {
+			b.Run(fmt.Sprintf("%s match with %v pairs", strategy, simulationSize), func(b *testing.B) {
+				for n := 0; n < b.N; n++ {
+					result = matching.Match(strategy, request, false, hoverfly.Simulation, hoverfly.state)
+				}
+			})
+
+			Expect(result.Pair).ToNot(BeNil())
+			Expect(result.Pair.Response.Body).To(Equal(fmt.Sprintf("response %v", simulationSize-1)))
+		}
+	}
+}
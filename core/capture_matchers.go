@@ -0,0 +1,153 @@
+package hoverfly
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/util"
+)
+
+// The matchers which can be chosen for captured paths and bodies. An empty body
+// matcher chooses one based on the content type, as Hoverfly always has done.
+var capturePathMatchers = []string{matchers.Exact, matchers.Glob}
+var captureBodyMatchers = []string{matchers.Exact, matchers.Json, matchers.JsonPartial, matchers.Xml}
+
+// Path segments which are numbers, UUIDs or long hexadecimal strings are taken to be IDs
+var idPathSegment = regexp.MustCompile(`^([0-9]+|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[0-9a-fA-F]{16,})$`)
+
+func validateCaptureArguments(pathMatcher, bodyMatcher string, ignoreJsonPaths []string) error {
+	if pathMatcher != "" && !containsIgnoreCase(capturePathMatchers, pathMatcher) {
+		return fmt.Errorf("Only path matchers of '%s' are permitted", strings.Join(capturePathMatchers, "', '"))
+	}
+
+	if bodyMatcher != "" && !containsIgnoreCase(captureBodyMatchers, bodyMatcher) {
+		return fmt.Errorf("Only body matchers of '%s' are permitted", strings.Join(captureBodyMatchers, "', '"))
+	}
+
+	for _, jsonPath := range ignoreJsonPaths {
+		if len(util.ParseJsonPath(jsonPath)) == 0 {
+			return fmt.Errorf("Cannot ignore JSON path '%s', it should be in the form $.field.list[0].field", jsonPath)
+		}
+	}
+
+	return nil
+}
+
+// getCapturedPathMatchers matches the path exactly, or with a glob in place of each segment which looks like an ID
+func getCapturedPathMatchers(path, pathMatcher string) []models.RequestFieldMatchers {
+	if strings.ToLower(pathMatcher) != matchers.Glob {
+		return []models.RequestFieldMatchers{
+			{
+				Matcher: matchers.Exact,
+				Value:   path,
+			},
+		}
+	}
+
+	globbedPath, globbed := globIdPathSegments(path)
+	if !globbed {
+		return getCapturedPathMatchers(path, matchers.Exact)
+	}
+
+	return []models.RequestFieldMatchers{
+		{
+			Matcher: matchers.Glob,
+			Value:   globbedPath,
+		},
+	}
+}
+
+func globIdPathSegments(path string) (string, bool) {
+	segments := strings.Split(path, "/")
+	globbed := false
+	for i, segment := range segments {
+		if idPathSegment.MatchString(segment) {
+			segments[i] = "*"
+			globbed = true
+		}
+	}
+
+	return strings.Join(segments, "/"), globbed
+}
+
+// getCapturedBodyMatchers matches the body with the given matcher, or one chosen by content type. Ignored JSON
+// paths are removed from a JSON body, which is then matched partially, so that requests match whatever their
+// values are for those paths.
+func getCapturedBodyMatchers(request *models.RequestDetails, bodyMatcher string, ignoreJsonPaths []string) []models.RequestFieldMatchers {
+	bodyMatcher = strings.ToLower(bodyMatcher)
+	body := request.Body
+
+	isJson := bodyMatcher == matchers.Json || bodyMatcher == matchers.JsonPartial ||
+		(bodyMatcher == "" && util.GetContentTypeFromHeaders(request.Headers) == "json")
+
+	if isJson && len(ignoreJsonPaths) > 0 {
+		var data interface{}
+		if err := json.Unmarshal([]byte(body), &data); err == nil {
+			for _, jsonPath := range ignoreJsonPaths {
+				removeJsonPath(data, util.ParseJsonPath(jsonPath))
+			}
+			if bytes, err := json.Marshal(data); err == nil {
+				body = string(bytes)
+				bodyMatcher = matchers.JsonPartial
+			}
+		}
+	}
+
+	if bodyMatcher == "" {
+		return models.NewRequestFieldMatchersFromView(v2.NewBodyMatcherViews(body, request.Headers))
+	}
+
+	return []models.RequestFieldMatchers{
+		{
+			Matcher: bodyMatcher,
+			Value:   body,
+		},
+	}
+}
+
+// removeJsonPath deletes the field at the end of the path, wherever the path exists in the data
+func removeJsonPath(data interface{}, tokens []string) {
+	if len(tokens) == 0 {
+		return
+	}
+
+	token := tokens[0]
+	if strings.HasPrefix(token, "[") {
+		list, ok := data.([]interface{})
+		if !ok {
+			return
+		}
+		index := strings.Trim(token, "[]")
+		for i, item := range list {
+			if index == "*" || index == strconv.Itoa(i) {
+				removeJsonPath(item, tokens[1:])
+			}
+		}
+		return
+	}
+
+	object, ok := data.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if len(tokens) == 1 {
+		delete(object, token)
+	} else {
+		removeJsonPath(object[token], tokens[1:])
+	}
+}
+
+func containsIgnoreCase(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
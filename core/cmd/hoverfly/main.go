@@ -35,6 +35,7 @@ import (
 	hvc "github.com/SpectoLabs/hoverfly/core/certs"
 	cs "github.com/SpectoLabs/hoverfly/core/cors"
 	"github.com/SpectoLabs/hoverfly/core/handlers"
+	"github.com/SpectoLabs/hoverfly/core/journal"
 	"github.com/SpectoLabs/hoverfly/core/matching"
 	mw "github.com/SpectoLabs/hoverfly/core/middleware"
 	"github.com/SpectoLabs/hoverfly/core/modes"
@@ -57,6 +58,8 @@ var destinationFlags arrayFlags
 
 const boltBackend = "boltdb"
 const inmemoryBackend = "memory"
+const fileJournalStore = "file"
+const journalBucketName = "journal"
 
 var (
 	version      = flag.Bool("version", false, "Get the version of hoverfly")
@@ -67,7 +70,7 @@ var (
 	modify       = flag.Bool("modify", false, "Start Hoverfly in modify mode - applies middleware (required) to both outgoing and incoming HTTP traffic")
 	spy          = flag.Bool("spy", false, "Start Hoverfly in spy mode, similar to simulate but calls real server when cache miss")
 	diff         = flag.Bool("diff", false, "Start Hoverfly in diff mode - calls real server and compares the actual response with the expected simulation config if present")
-	middleware   = flag.String("middleware", "", "Set middleware by passing the name of the binary and the path of the middleware script separated by space. (i.e. '-middleware \"python script.py\"')")
+	middleware   = flag.String("middleware", "", "Set middleware by passing the name of the binary and the path of the middleware script separated by space (i.e. '-middleware \"python script.py\"'), a URL, the address of gRPC middleware (i.e. '-middleware grpc://localhost:50051') or a JavaScript file run by Hoverfly itself (i.e. '-middleware javascript:script.js')")
 	proxyPort    = flag.String("pp", "", "Proxy port - run proxy on another port (i.e. '-pp 9999' to run proxy on port 9999)")
 	adminPort    = flag.String("ap", "", "Admin port - run admin interface on another port (i.e. '-ap 1234' to run admin UI on port 1234)")
 	listenOnHost = flag.String("listen-on-host", "", "Specify which network interface to bind to, eg. 0.0.0.0 will bind to all interfaces. By default hoverfly will only bind ports to loopback interface")
@@ -108,6 +111,19 @@ var (
 	cacheSize     = flag.Int("cache-size", 1000, "Set the size of request/response cache")
 	cors          = flag.Bool("cors", false, "Enable CORS support")
 	noImportCheck = flag.Bool("no-import-check", false, "Skip duplicate request check when importing simulations")
+	importWatch   = flag.String("import-watch", "", "Import every simulation in a directory and reload them when they change (i.e. '-import-watch simulations')")
+
+	sessionHeader     = flag.String("session-header", hv.DefaultSessionHeader, "The header used to choose the session of a request, an empty value stops requests choosing a session with a header")
+	proxyUserSessions = flag.Bool("proxy-user-sessions", false, "Use the session named after the proxy authentication user when a request does not choose a session")
+
+	middlewareWorkers = flag.Int("middleware-workers", 0, "Keep this many middleware processes running and send them one pair per line, 0 starts the middleware for every pair")
+	middlewareTimeout = flag.Duration("middleware-timeout", 0, "Kill middleware that takes longer than this to process a pair (i.e. '5s'), 0 to wait for as long as it takes")
+
+	journalStore      = flag.String("journal-store", inmemoryBackend, "Storage to use for the journal - 'memory', 'file' or 'boltdb'. Only the memory store is limited to the journal size, and the boltdb store grows until the journal is deleted")
+	journalPath       = flag.String("journal-path", "", "A path to the JSON Lines file or BoltDB file used by the 'file' and 'boltdb' journal stores")
+	journalMaxSize    = flag.Int64("journal-max-size", 0, "Rotate the journal file once it would grow beyond this many bytes, 0 to never rotate on size")
+	journalMaxAge     = flag.Duration("journal-max-age", 0, "Rotate the journal file once its oldest entry is this old (i.e. '24h'), 0 to never rotate on age")
+	journalMaxBackups = flag.Int("journal-max-backups", 5, "Number of rotated journal files to keep, 0 to keep all of them")
 
 	clientAuthenticationDestination = flag.String("client-authentication-destination", "", "Regular expression of destination with client authentication")
 	clientAuthenticationClientCert  = flag.String("client-authentication-client-cert", "", "Path to the client certification file used for authentication")
@@ -315,7 +331,19 @@ func main() {
 	// overriding default middleware setting
 	newMiddleware, err := mw.ConvertToNewMiddleware(*middleware)
 	if err != nil {
-		log.Error(err.Error())
+		log.Fatal(err.Error())
+	}
+	if *middlewareWorkers > 0 && (newMiddleware.Remote != "" || newMiddleware.Grpc != "" || newMiddleware.Javascript != "") {
+		log.Fatal("Middleware workers cannot be used with remote or JavaScript middleware")
+	}
+	if err := newMiddleware.SetWorkers(*middlewareWorkers); err != nil {
+		log.Fatal(err.Error())
+	}
+	if err := newMiddleware.SetTimeout(*middlewareTimeout); err != nil {
+		log.Fatal(err.Error())
+	}
+	if err := newMiddleware.CheckHealth(); err != nil {
+		log.Warn(err.Error())
 	}
 	cfg.Middleware = *newMiddleware
 
@@ -369,6 +397,38 @@ func main() {
 			"database": *database,
 		}).Fatal("Unknown database type")
 	}
+	if *journalStore == fileJournalStore || *journalStore == boltBackend {
+		if *journalPath == "" {
+			log.WithFields(log.Fields{
+				"journal-store": *journalStore,
+			}).Fatal("A journal path must be given for this journal store")
+		}
+	}
+
+	if *journalStore == fileJournalStore {
+		journalFile, err := journal.NewFileStore(*journalPath, *journalMaxSize, *journalMaxAge, *journalMaxBackups)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"error":        err.Error(),
+				"journal-path": *journalPath,
+			}).Fatal("Failed to open journal file")
+		}
+		defer journalFile.Close()
+		hoverfly.Journal.SetStore(journalFile)
+
+		log.Info("Using file journal store")
+	} else if *journalStore == boltBackend {
+		journalDB := cache.GetDB(*journalPath)
+		defer journalDB.Close()
+		hoverfly.Journal.SetStore(journal.NewBoltDBStore(journalDB, []byte(journalBucketName)))
+
+		log.Info("Using boltdb journal store")
+	} else if *journalStore != inmemoryBackend {
+		log.WithFields(log.Fields{
+			"journal-store": *journalStore,
+		}).Fatal("Unknown journal store")
+	}
+
 	cfg.DisableCache = *disableCache
 	cfg.CacheSize = *cacheSize
 	if cfg.DisableCache {
@@ -391,6 +451,9 @@ func main() {
 		cfg.HttpsOnly = true
 	}
 
+	cfg.SessionHeader = *sessionHeader
+	cfg.ProxyUserSessions = *proxyUserSessions
+
 	authBackend := backends.NewCacheBasedAuthBackend(tokenCache, userCache)
 
 	hoverfly.Cfg = cfg
@@ -471,6 +534,16 @@ func main() {
 		}
 	}
 
+	if *importWatch != "" {
+		err := hoverfly.WatchImportDirectory(*importWatch)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"error":        err.Error(),
+				"import-watch": *importWatch,
+			}).Fatal("Failed to import simulations from directory")
+		}
+	}
+
 	// start metrics registry flush
 	if *metrics {
 		hoverfly.Counter.Init()
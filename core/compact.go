@@ -0,0 +1,468 @@
+package hoverfly
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/templating"
+)
+
+// compactedPairs is a pair made by merging the pairs it replaces
+type compactedPairs struct {
+	pair     models.RequestMatcherResponsePair
+	replaces []models.RequestMatcherResponsePair
+}
+
+var templatableQueryKey = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// CompactSimulation merges captured pairs which only differ in their IDs, query and header values or
+// JSON body fields. When it is a dry run, the merges are returned without changing the simulation.
+func (this *Hoverfly) CompactSimulation(dryRun bool) v2.SimulationCompactView {
+	pairs := this.Simulation.GetMatchingPairs()
+	compacted, merges := compactPairs(pairs, this.templator)
+
+	if !dryRun && len(merges) > 0 {
+		this.Simulation.ReplaceMatchingPairs(compacted)
+		this.CacheMatcher.FlushCache()
+	}
+
+	view := v2.SimulationCompactView{
+		DryRun:      dryRun,
+		PairsBefore: len(pairs),
+		PairsAfter:  len(compacted),
+		Merges:      []v2.CompactedPairsView{},
+	}
+	for _, merge := range merges {
+		mergeView := v2.CompactedPairsView{
+			Pair: merge.pair.BuildView(),
+		}
+		for _, replaced := range merge.replaces {
+			mergeView.Replaces = append(mergeView.Replaces, replaced.BuildView())
+		}
+		view.Merges = append(view.Merges, mergeView)
+	}
+
+	return view
+}
+
+// compactPairs groups together pairs which were captured from similar requests and merges each group
+// into one pair. A group takes the place of its first pair, and pairs which were not captured, such
+// as those using other matchers, state or sequences of responses, are left as they are.
+func compactPairs(pairs []models.RequestMatcherResponsePair, templator *templating.Templator) ([]models.RequestMatcherResponsePair, []compactedPairs) {
+	groups := map[string][]models.RequestMatcherResponsePair{}
+	keys := []string{}
+
+	for i, pair := range pairs {
+		key, ok := getCompactKey(pair.RequestMatcher)
+		if !ok || pair.Responses != nil {
+			key = fmt.Sprintf("pair:%d", i)
+		}
+		if _, exists := groups[key]; !exists {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], pair)
+	}
+
+	compacted := []models.RequestMatcherResponsePair{}
+	merges := []compactedPairs{}
+	for _, key := range keys {
+		group := groups[key]
+		if len(group) == 1 {
+			compacted = append(compacted, group[0])
+			continue
+		}
+
+		merged := mergePairs(group, templator)
+		compacted = append(compacted, merged)
+		merges = append(merges, compactedPairs{pair: merged, replaces: group})
+	}
+
+	return compacted, merges
+}
+
+// getCompactKey describes the request matcher without the values which are allowed to differ
+// between the pairs in a group. It is not ok if the pair does not look like it was captured.
+func getCompactKey(requestMatcher models.RequestMatcher) (string, bool) {
+	if len(requestMatcher.RequiresState) > 0 || len(requestMatcher.DeprecatedQuery) > 0 {
+		return "", false
+	}
+
+	for _, field := range [][]models.RequestFieldMatchers{requestMatcher.Path, requestMatcher.Method,
+		requestMatcher.Destination, requestMatcher.Scheme, requestMatcher.Body} {
+		if !isCapturedField(field) {
+			return "", false
+		}
+	}
+
+	key := []string{
+		getFieldKey(requestMatcher.Method),
+		getFieldKey(requestMatcher.Scheme),
+		getFieldKey(requestMatcher.Destination),
+	}
+
+	if len(requestMatcher.Path) == 0 {
+		key = append(key, "*")
+	} else {
+		path, _ := globIdPathSegments(requestMatcher.Path[0].Value.(string))
+		key = append(key, path)
+	}
+
+	queryKeys := []string{}
+	if requestMatcher.Query != nil {
+		for queryKey, field := range *requestMatcher.Query {
+			if !isCapturedField(field) {
+				return "", false
+			}
+			queryKeys = append(queryKeys, queryKey)
+		}
+	}
+	sort.Strings(queryKeys)
+	key = append(key, "query:"+strings.Join(queryKeys, "&"))
+
+	headerKeys := []string{}
+	for headerKey, field := range requestMatcher.Headers {
+		if !isCapturedField(field) {
+			return "", false
+		}
+		headerKeys = append(headerKeys, headerKey)
+	}
+	sort.Strings(headerKeys)
+	key = append(key, "headers:"+strings.Join(headerKeys, "&"))
+
+	// JSON objects with the same fields can be merged, other bodies have to be the same
+	if object, ok := getJsonObjectBody(requestMatcher.Body); ok {
+		fields := []string{}
+		for field := range object {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		key = append(key, "json:"+strings.Join(fields, "&"))
+	} else {
+		key = append(key, getFieldKey(requestMatcher.Body))
+	}
+
+	return strings.Join(key, "\n"), true
+}
+
+// isCapturedField checks that the field is matched the way capture mode would have matched it
+func isCapturedField(field []models.RequestFieldMatchers) bool {
+	if len(field) > 1 {
+		return false
+	}
+
+	for _, fieldMatcher := range field {
+		if _, ok := fieldMatcher.Value.(string); !ok || len(fieldMatcher.Config) > 0 {
+			return false
+		}
+		if fieldMatcher.Matcher != matchers.Exact && fieldMatcher.Matcher != matchers.Json && fieldMatcher.Matcher != matchers.Xml {
+			return false
+		}
+	}
+
+	return true
+}
+
+func getFieldKey(field []models.RequestFieldMatchers) string {
+	if len(field) == 0 {
+		return "*"
+	}
+
+	return field[0].Matcher + ":" + field[0].Value.(string)
+}
+
+func getFieldValue(field []models.RequestFieldMatchers) string {
+	if len(field) == 0 {
+		return ""
+	}
+
+	return field[0].Value.(string)
+}
+
+func getJsonObjectBody(body []models.RequestFieldMatchers) (map[string]interface{}, bool) {
+	if len(body) == 0 || body[0].Matcher != matchers.Json {
+		return nil, false
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(body[0].Value.(string)), &object); err != nil || object == nil {
+		return nil, false
+	}
+
+	return object, true
+}
+
+// mergePairs makes one pair which matches the requests of every pair in the group. Path segments
+// and query values which differ are globbed, and JSON bodies are matched partially on the fields
+// they have in common.
+func mergePairs(pairs []models.RequestMatcherResponsePair, templator *templating.Templator) models.RequestMatcherResponsePair {
+	first := pairs[0].RequestMatcher
+
+	// The request values of each pair, along with the template which would render them
+	templates := make([]map[string]string, len(pairs))
+	for i := range templates {
+		templates[i] = map[string]string{}
+	}
+
+	requestMatcher := models.RequestMatcher{
+		Method:      first.Method,
+		Destination: first.Destination,
+		Scheme:      first.Scheme,
+		Body:        mergeBodies(pairs),
+	}
+
+	if len(first.Path) > 0 {
+		segments := make([][]string, len(pairs))
+		for i, pair := range pairs {
+			segments[i] = strings.Split(getFieldValue(pair.RequestMatcher.Path), "/")
+		}
+
+		merged := strings.Split(getFieldValue(first.Path), "/")
+		globbed := false
+		for i := range merged {
+			differs := false
+			for _, pathSegments := range segments[1:] {
+				differs = differs || pathSegments[i] != segments[0][i]
+			}
+			if differs {
+				merged[i] = "*"
+				globbed = true
+				for j := range pairs {
+					templates[j][segments[j][i]] = fmt.Sprintf("{{{ Request.Path.[%d] }}}", i-1)
+				}
+			}
+		}
+
+		requestMatcher.Path = first.Path
+		if globbed {
+			requestMatcher.Path = []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Glob,
+					Value:   strings.Join(merged, "/"),
+				},
+			}
+		}
+	}
+
+	if first.Query != nil {
+		requestMatcher.Query = &models.QueryRequestFieldMatchers{}
+		for key, field := range *first.Query {
+			values := make([]string, len(pairs))
+			same := true
+			for i, pair := range pairs {
+				values[i] = getFieldValue((*pair.RequestMatcher.Query)[key])
+				same = same && values[i] == values[0]
+			}
+
+			if same {
+				requestMatcher.Query.Add(key, field)
+				continue
+			}
+
+			requestMatcher.Query.Add(key, []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Glob,
+					Value:   "*",
+				},
+			})
+			if templatableQueryKey.MatchString(key) {
+				for i, value := range values {
+					templates[i][value] = fmt.Sprintf("{{{ Request.QueryParam.%s }}}", key)
+				}
+			}
+		}
+	}
+
+	if first.Headers != nil {
+		requestMatcher.Headers = map[string][]models.RequestFieldMatchers{}
+		for key, field := range first.Headers {
+			requestMatcher.Headers[key] = field
+			for _, pair := range pairs[1:] {
+				if getFieldValue(pair.RequestMatcher.Headers[key]) != getFieldValue(field) {
+					requestMatcher.Headers[key] = []models.RequestFieldMatchers{
+						{
+							Matcher: matchers.Glob,
+							Value:   "*",
+						},
+					}
+					break
+				}
+			}
+		}
+	}
+
+	return models.RequestMatcherResponsePair{
+		RequestMatcher: requestMatcher,
+		Response:       mergeResponses(pairs, templates, templator),
+	}
+}
+
+func mergeBodies(pairs []models.RequestMatcherResponsePair) []models.RequestFieldMatchers {
+	first := pairs[0].RequestMatcher.Body
+
+	values := []interface{}{}
+	for _, pair := range pairs {
+		object, ok := getJsonObjectBody(pair.RequestMatcher.Body)
+		if !ok {
+			return first
+		}
+		values = append(values, object)
+	}
+
+	common, _ := getCommonJson(values)
+	if reflect.DeepEqual(common, values[0]) {
+		return first
+	}
+
+	body, _ := json.Marshal(common)
+	return []models.RequestFieldMatchers{
+		{
+			Matcher: matchers.JsonPartial,
+			Value:   string(body),
+		},
+	}
+}
+
+// getCommonJson returns the JSON which every value has in common. Objects keep the
+// fields which they have in common, anything else has to be the same in every value.
+func getCommonJson(values []interface{}) (interface{}, bool) {
+	same := true
+	for _, value := range values[1:] {
+		if !reflect.DeepEqual(values[0], value) {
+			same = false
+			break
+		}
+	}
+	if same {
+		return values[0], true
+	}
+
+	objects := []map[string]interface{}{}
+	for _, value := range values {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		objects = append(objects, object)
+	}
+
+	common := map[string]interface{}{}
+	for field := range objects[0] {
+		children := []interface{}{}
+		for _, object := range objects {
+			if child, ok := object[field]; ok {
+				children = append(children, child)
+			}
+		}
+		if len(children) != len(objects) {
+			continue
+		}
+		if child, ok := getCommonJson(children); ok {
+			common[field] = child
+		}
+	}
+
+	return common, true
+}
+
+// mergeResponses keeps the response of the first pair, unless the response bodies only differ by the
+// request values they were captured from, in which case those values are templated. The template has
+// to render the body of every pair from its request. Response headers are always kept from the first
+// pair, as they often differ in their dates.
+func mergeResponses(pairs []models.RequestMatcherResponsePair, templates []map[string]string, templator *templating.Templator) models.ResponseDetails {
+	first := pairs[0].Response
+
+	same := true
+	for _, pair := range pairs[1:] {
+		if pair.Response.Status != first.Status || pair.Response.Body != first.Body {
+			same = false
+			break
+		}
+	}
+	if same {
+		return first
+	}
+
+	templatedBody := ""
+	for i, pair := range pairs {
+		response := pair.Response
+		if response.Status != first.Status || response.Templated || strings.Contains(response.Body, "{{") || len(templates[i]) == 0 {
+			return first
+		}
+
+		body := templateRequestValues(response.Body, templates[i])
+		if i > 0 && body != templatedBody {
+			return first
+		}
+		templatedBody = body
+	}
+
+	template, err := templator.ParseTemplate(templatedBody)
+	if err != nil {
+		return first
+	}
+	for _, pair := range pairs {
+		body, err := templator.RenderTemplate(template, getCapturedRequest(pair.RequestMatcher), map[string]string{}, map[string]string{}, nil)
+		if err != nil || body != pair.Response.Body {
+			return first
+		}
+	}
+
+	templated := first
+	templated.Body = templatedBody
+	templated.Templated = true
+
+	return templated
+}
+
+// getCapturedRequest returns the request which a captured pair was made from
+func getCapturedRequest(requestMatcher models.RequestMatcher) *models.RequestDetails {
+	request := &models.RequestDetails{
+		Method:      getFieldValue(requestMatcher.Method),
+		Scheme:      getFieldValue(requestMatcher.Scheme),
+		Destination: getFieldValue(requestMatcher.Destination),
+		Path:        getFieldValue(requestMatcher.Path),
+		Body:        getFieldValue(requestMatcher.Body),
+		Query:       map[string][]string{},
+	}
+	if requestMatcher.Query != nil {
+		for key, field := range *requestMatcher.Query {
+			request.Query[key] = strings.Split(getFieldValue(field), ";")
+		}
+	}
+
+	return request
+}
+
+// templateRequestValues replaces the request values in the body with templates, longest values first
+func templateRequestValues(body string, templates map[string]string) string {
+	values := []string{}
+	for value := range templates {
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+
+	replacements := []string{}
+	for _, value := range values {
+		replacements = append(replacements, value, templates[value])
+	}
+
+	body = strings.NewReplacer(replacements...).Replace(body)
+
+	// A brace straight after a template would close it, so they are kept apart by an empty comment
+	for _, value := range values {
+		body = strings.Replace(body, templates[value]+"}", templates[value]+"{{!}}}", -1)
+	}
+
+	return body
+}
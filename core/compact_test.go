@@ -0,0 +1,204 @@
+package hoverfly
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/modes"
+	. "github.com/onsi/gomega"
+)
+
+func captureForCompacting(unit *Hoverfly, request models.RequestDetails, body string) {
+	if request.Method == "" {
+		request.Method = "GET"
+	}
+	if request.Destination == "" {
+		request.Destination = "api.example.com"
+	}
+	if request.Scheme == "" {
+		request.Scheme = "http"
+	}
+
+	unit.Save(&request, &models.ResponseDetails{
+		Status:  200,
+		Body:    body,
+		Headers: map[string][]string{"Date": {request.Path}},
+	}, &modes.ModeArguments{})
+}
+
+func Test_Hoverfly_CompactSimulation_GlobsIdsAndTemplatesTheResponse(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	captureForCompacting(unit, models.RequestDetails{Path: "/users/123/orders"}, `{"user": 123}`)
+	captureForCompacting(unit, models.RequestDetails{Path: "/users/456/orders"}, `{"user": 456}`)
+	captureForCompacting(unit, models.RequestDetails{Path: "/users/789/orders"}, `{"user": 789}`)
+
+	view := unit.CompactSimulation(false)
+
+	Expect(view.DryRun).To(BeFalse())
+	Expect(view.PairsBefore).To(Equal(3))
+	Expect(view.PairsAfter).To(Equal(1))
+	Expect(view.Merges).To(HaveLen(1))
+	Expect(view.Merges[0].Replaces).To(HaveLen(3))
+
+	pairs := unit.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(1))
+	Expect(pairs[0].RequestMatcher.Path).To(Equal([]models.RequestFieldMatchers{
+		{
+			Matcher: matchers.Glob,
+			Value:   "/users/*/orders",
+		},
+	}))
+	Expect(pairs[0].Response.Templated).To(BeTrue())
+	Expect(pairs[0].Response.Body).To(Equal(`{"user": {{{ Request.Path.[1] }}}{{!}}}`))
+	Expect(pairs[0].Response.Headers).To(Equal(map[string][]string{"Date": {"/users/123/orders"}}))
+}
+
+func Test_Hoverfly_CompactSimulation_KeepsTheFirstResponseIfItCannotBeTemplated(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	captureForCompacting(unit, models.RequestDetails{Path: "/users/1"}, `{"name": "Ben"}`)
+	captureForCompacting(unit, models.RequestDetails{Path: "/users/2"}, `{"name": "Tommy"}`)
+
+	unit.CompactSimulation(false)
+
+	pairs := unit.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(1))
+	Expect(pairs[0].Response.Templated).To(BeFalse())
+	Expect(pairs[0].Response.Body).To(Equal(`{"name": "Ben"}`))
+}
+
+func Test_Hoverfly_CompactSimulation_GlobsQueryValuesWhichDiffer(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	captureForCompacting(unit, models.RequestDetails{
+		Path:  "/search",
+		Query: map[string][]string{"q": {"hoverfly"}, "page": {"1"}},
+	}, `results for hoverfly`)
+	captureForCompacting(unit, models.RequestDetails{
+		Path:  "/search",
+		Query: map[string][]string{"q": {"mocks"}, "page": {"1"}},
+	}, `results for mocks`)
+
+	unit.CompactSimulation(false)
+
+	pairs := unit.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(1))
+	Expect(pairs[0].RequestMatcher.Path[0].Matcher).To(Equal(matchers.Exact))
+	Expect(*pairs[0].RequestMatcher.Query).To(Equal(models.QueryRequestFieldMatchers{
+		"q": {
+			{
+				Matcher: matchers.Glob,
+				Value:   "*",
+			},
+		},
+		"page": {
+			{
+				Matcher: matchers.Exact,
+				Value:   "1",
+			},
+		},
+	}))
+	Expect(pairs[0].Response.Body).To(Equal(`results for {{{ Request.QueryParam.q }}}`))
+}
+
+func Test_Hoverfly_CompactSimulation_MatchesJsonBodiesOnTheFieldsTheyHaveInCommon(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	headers := map[string][]string{"Content-Type": {"application/json"}}
+	captureForCompacting(unit, models.RequestDetails{
+		Method:  "POST",
+		Path:    "/events",
+		Headers: headers,
+		Body:    `{"type": "login", "timestamp": 1, "user": {"id": 1, "role": "admin"}}`,
+	}, "created")
+	captureForCompacting(unit, models.RequestDetails{
+		Method:  "POST",
+		Path:    "/events",
+		Headers: headers,
+		Body:    `{"type": "login", "timestamp": 2, "user": {"id": 2, "role": "admin"}}`,
+	}, "created")
+
+	unit.CompactSimulation(false)
+
+	pairs := unit.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(1))
+	Expect(pairs[0].RequestMatcher.Body).To(Equal([]models.RequestFieldMatchers{
+		{
+			Matcher: matchers.JsonPartial,
+			Value:   `{"type":"login","user":{"role":"admin"}}`,
+		},
+	}))
+	Expect(pairs[0].Response.Body).To(Equal("created"))
+}
+
+func Test_Hoverfly_CompactSimulation_LeavesPairsWhichCannotBeMerged(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	captureForCompacting(unit, models.RequestDetails{Path: "/users/1"}, "one")
+	captureForCompacting(unit, models.RequestDetails{Path: "/users/me"}, "me")
+	captureForCompacting(unit, models.RequestDetails{Path: "/users/2", Method: "DELETE"}, "deleted")
+	captureForCompacting(unit, models.RequestDetails{Path: "/accounts/1", Body: "one"}, "one")
+	captureForCompacting(unit, models.RequestDetails{Path: "/accounts/2", Body: "two"}, "two")
+	unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Glob,
+					Value:   "/users/*",
+				},
+			},
+		},
+	})
+	unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "/users/3",
+				},
+			},
+			RequiresState: map[string]string{"logged-in": "true"},
+		},
+	})
+
+	view := unit.CompactSimulation(false)
+
+	Expect(view.PairsBefore).To(Equal(7))
+	Expect(view.PairsAfter).To(Equal(7))
+	Expect(view.Merges).To(BeEmpty())
+}
+
+func Test_Hoverfly_CompactSimulation_DoesNotChangeTheSimulationOnADryRun(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	captureForCompacting(unit, models.RequestDetails{Path: "/users/1"}, "user")
+	captureForCompacting(unit, models.RequestDetails{Path: "/users/2"}, "user")
+	captureForCompacting(unit, models.RequestDetails{Path: "/health"}, "ok")
+
+	view := unit.CompactSimulation(true)
+
+	Expect(view.DryRun).To(BeTrue())
+	Expect(view.PairsBefore).To(Equal(3))
+	Expect(view.PairsAfter).To(Equal(2))
+	Expect(view.Merges).To(HaveLen(1))
+	Expect(view.Merges[0].Pair.RequestMatcher.Path[0].Value).To(Equal("/users/*"))
+	Expect(view.Merges[0].Pair.Response.Body).To(Equal("user"))
+	Expect(view.Merges[0].Replaces[0].RequestMatcher.Path[0].Value).To(Equal("/users/1"))
+	Expect(view.Merges[0].Replaces[1].RequestMatcher.Path[0].Value).To(Equal("/users/2"))
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(3))
+}
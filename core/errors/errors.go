@@ -38,3 +38,9 @@ func MiddlewareNotSetError() *HoverflyError {
 		Message: "Cannot execute middleware as middleware has not been correctly set",
 	}
 }
+
+func ResponsesExhaustedError() *HoverflyError {
+	return &HoverflyError{
+		Message: "Every response for the matched request has already been returned",
+	}
+}
@@ -36,7 +36,11 @@ func ReadFromRequest(request *http.Request, v interface{}) error {
 }
 
 func WriteResponse(response http.ResponseWriter, bytes []byte) {
-	response.Header().Set("Content-Type", detectContentType(bytes))
+	WriteResponseWithContentType(response, bytes, detectContentType(bytes))
+}
+
+func WriteResponseWithContentType(response http.ResponseWriter, bytes []byte, contentType string) {
+	response.Header().Set("Content-Type", contentType)
 	writeCorsHeadersIfEnabled(response)
 
 	response.Write(bytes)
@@ -2,6 +2,7 @@ package v2
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"net/http"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers"
@@ -11,6 +12,7 @@ import (
 
 type HoverflyDiff interface {
 	GetDiff() map[SimpleRequestDefinitionView][]DiffReport
+	GetDiffComparisons() map[SimpleRequestDefinitionView]int
 	ClearDiff()
 }
 
@@ -30,9 +32,21 @@ func (this *DiffHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler)
 	mux.Options("/api/v2/diff", negroni.New(
 		negroni.HandlerFunc(this.Options),
 	))
+
+	mux.Get("/api/v2/diff/summary", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.GetSummary),
+	))
+	mux.Options("/api/v2/diff/summary", negroni.New(
+		negroni.HandlerFunc(this.OptionsSummary),
+	))
 }
 
 func (this *DiffHandler) Get(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	if req.URL.Query().Get("format") == "junit" {
+		this.getJUnit(w)
+		return
+	}
 
 	var diffsToReturn []ResponseDiffForRequestView
 	for request, value := range this.Hoverfly.GetDiff() {
@@ -54,6 +68,28 @@ func (this *DiffHandler) Get(w http.ResponseWriter, req *http.Request, next http
 	w.WriteHeader(http.StatusOK)
 }
 
+// getJUnit writes the diff as a JUnit XML report, so that CI can fail a build
+// when responses no longer match the simulation
+func (this *DiffHandler) getJUnit(w http.ResponseWriter) {
+	marshal, err := xml.MarshalIndent(NewJUnitDiffView(this.Hoverfly.GetDiff(), this.Hoverfly.GetDiffComparisons()), "", "  ")
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	handlers.WriteResponseWithContentType(w, append([]byte(xml.Header), marshal...), "application/xml")
+}
+
+func (this *DiffHandler) GetSummary(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	marshal, err := json.Marshal(NewDiffSummaryView(this.Hoverfly.GetDiff(), this.Hoverfly.GetDiffComparisons()))
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	handlers.WriteResponse(w, marshal)
+}
+
 func (this *DiffHandler) Delete(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
 	this.Hoverfly.ClearDiff()
 	w.WriteHeader(http.StatusOK)
@@ -63,3 +99,8 @@ func (this *DiffHandler) Options(w http.ResponseWriter, r *http.Request, next ht
 	w.Header().Add("Allow", "OPTIONS, GET, DELETE")
 	handlers.WriteResponse(w, []byte(""))
 }
+
+func (this *DiffHandler) OptionsSummary(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, GET")
+	handlers.WriteResponse(w, []byte(""))
+}
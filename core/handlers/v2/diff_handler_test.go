@@ -6,6 +6,7 @@ import (
 
 	"bytes"
 	"encoding/json"
+	"encoding/xml"
 	. "github.com/onsi/gomega"
 	"io/ioutil"
 	"time"
@@ -19,11 +20,17 @@ func (this *DiffHOverflyStub) GetDiff() map[SimpleRequestDefinitionView][]DiffRe
 	return diffView
 }
 
+func (this *DiffHOverflyStub) GetDiffComparisons() map[SimpleRequestDefinitionView]int {
+	return diffComparisonsView
+}
+
 func (this *DiffHOverflyStub) ClearDiff() {
 	diffView = make(map[SimpleRequestDefinitionView][]DiffReport)
+	diffComparisonsView = make(map[SimpleRequestDefinitionView]int)
 }
 
 var diffView map[SimpleRequestDefinitionView][]DiffReport
+var diffComparisonsView map[SimpleRequestDefinitionView]int
 
 func TestDiffHandlerGetReturnsTheCorrectDiff(t *testing.T) {
 	RegisterTestingT(t)
@@ -91,6 +98,96 @@ func TestDiffHandlerOptionsGetsOptions(t *testing.T) {
 	Expect(response.Header().Get("Allow")).To(Equal("OPTIONS, GET, DELETE"))
 }
 
+func TestDiffHandlerGetReturnsTheDiffAsJUnitXml(t *testing.T) {
+	RegisterTestingT(t)
+
+	// given
+	initializeDiff()
+	unit := DiffHandler{Hoverfly: &DiffHOverflyStub{}}
+	request, err := http.NewRequest("GET", "/api/v2/diff?format=junit", nil)
+
+	// when
+	response := makeRequestOnHandler(unit.Get, request)
+
+	// then
+	Expect(err).To(BeNil())
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Header().Get("Content-Type")).To(Equal("application/xml"))
+
+	var junitView JUnitTestSuitesView
+	Expect(xml.Unmarshal(response.Body.Bytes(), &junitView)).To(Succeed())
+
+	Expect(junitView.Tests).To(Equal(2))
+	Expect(junitView.Failures).To(Equal(1))
+	Expect(junitView.TestSuites).To(HaveLen(1))
+
+	testCases := junitView.TestSuites[0].TestCases
+	Expect(testCases).To(HaveLen(2))
+	Expect(testCases[0].Name).To(Equal("GET otherHost/otherPath"))
+	Expect(testCases[0].Failure).To(BeNil())
+	Expect(testCases[1].ClassName).To(Equal("testMethod testHost"))
+	Expect(testCases[1].Name).To(Equal("testMethod testHosttestPath?testQuery"))
+	Expect(testCases[1].Failure.Message).To(Equal("2 of 3 responses differed from the simulation"))
+	Expect(testCases[1].Failure.Contents).To(ContainSubstring("first: expected expected1 but was actual1"))
+	Expect(testCases[1].Failure.Contents).To(ContainSubstring("second: expected expected2 but was actual2"))
+}
+
+func TestDiffHandlerGetSummaryCountsTheComparedResponses(t *testing.T) {
+	RegisterTestingT(t)
+
+	// given
+	initializeDiff()
+	unit, request, err := createRequest("GET")
+
+	// when
+	response := makeRequestOnHandler(unit.GetSummary, request)
+
+	// then
+	Expect(err).To(BeNil())
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	var summaryView DiffSummaryView
+	Expect(json.Unmarshal(response.Body.Bytes(), &summaryView)).To(Succeed())
+	Expect(summaryView).To(Equal(DiffSummaryView{
+		Requests:        2,
+		FailedRequests:  1,
+		Responses:       4,
+		FailedResponses: 2,
+		Differences:     2,
+		Passed:          false,
+	}))
+}
+
+func TestDiffHandlerGetSummaryPassesWhenNoResponseDiffered(t *testing.T) {
+	RegisterTestingT(t)
+
+	// given
+	initializeDiff()
+	unit, request, err := createRequest("GET")
+	makeRequestOnHandler(unit.Delete, request)
+
+	// when
+	response := makeRequestOnHandler(unit.GetSummary, request)
+
+	// then
+	Expect(err).To(BeNil())
+	Expect(response.Body.String()).To(Equal(`{"requests":0,"failedRequests":0,"responses":0,"failedResponses":0,"differences":0,"passed":true}`))
+}
+
+func TestDiffHandlerOptionsSummaryGetsOptions(t *testing.T) {
+	RegisterTestingT(t)
+	// given
+	unit, request, err := createRequest("OPTIONS")
+
+	// when
+	response := makeRequestOnHandler(unit.OptionsSummary, request)
+
+	//then
+	Expect(err).To(BeNil())
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Header().Get("Allow")).To(Equal("OPTIONS, GET"))
+}
+
 func createRequest(method string) (DiffHandler, *http.Request, error) {
 	var stubHoverfly DiffHOverflyStub
 	unit := DiffHandler{Hoverfly: &stubHoverfly}
@@ -122,6 +219,20 @@ func initializeDiff() {
 			},
 		},
 	}
+
+	diffComparisonsView = map[SimpleRequestDefinitionView]int{
+		SimpleRequestDefinitionView{
+			Host:   "testHost",
+			Method: "testMethod",
+			Path:   "testPath",
+			Query:  "testQuery",
+		}: 3,
+		SimpleRequestDefinitionView{
+			Host:   "otherHost",
+			Method: "GET",
+			Path:   "/otherPath",
+		}: 1,
+	}
 }
 
 func unmarshalDiffView(buffer *bytes.Buffer) (DiffView, error) {
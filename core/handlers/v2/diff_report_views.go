@@ -0,0 +1,148 @@
+package v2
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DiffSummaryView counts the responses compared by diff mode since the diffs were
+// last deleted, and those which differed from the simulation
+type DiffSummaryView struct {
+	Requests        int  `json:"requests"`
+	FailedRequests  int  `json:"failedRequests"`
+	Responses       int  `json:"responses"`
+	FailedResponses int  `json:"failedResponses"`
+	Differences     int  `json:"differences"`
+	Passed          bool `json:"passed"`
+}
+
+// JUnitTestSuitesView is a JUnit XML report, which is read by most CI servers
+type JUnitTestSuitesView struct {
+	XMLName    xml.Name             `xml:"testsuites"`
+	Name       string               `xml:"name,attr"`
+	Tests      int                  `xml:"tests,attr"`
+	Failures   int                  `xml:"failures,attr"`
+	TestSuites []JUnitTestSuiteView `xml:"testsuite"`
+}
+
+type JUnitTestSuiteView struct {
+	Name      string              `xml:"name,attr"`
+	Tests     int                 `xml:"tests,attr"`
+	Failures  int                 `xml:"failures,attr"`
+	TestCases []JUnitTestCaseView `xml:"testcase"`
+}
+
+type JUnitTestCaseView struct {
+	ClassName string            `xml:"classname,attr"`
+	Name      string            `xml:"name,attr"`
+	Failure   *JUnitFailureView `xml:"failure,omitempty"`
+}
+
+type JUnitFailureView struct {
+	Message  string `xml:"message,attr"`
+	Type     string `xml:"type,attr"`
+	Contents string `xml:",chardata"`
+}
+
+// NewDiffSummaryView counts the requests and responses compared by diff mode. The
+// diff has passed when no response differed from the simulation.
+func NewDiffSummaryView(diffs map[SimpleRequestDefinitionView][]DiffReport, comparisons map[SimpleRequestDefinitionView]int) DiffSummaryView {
+	summary := DiffSummaryView{}
+
+	for _, request := range diffRequests(diffs, comparisons) {
+		reports := diffs[request]
+
+		summary.Requests++
+		summary.Responses += diffComparisons(request, diffs, comparisons)
+		summary.FailedResponses += len(reports)
+		if len(reports) > 0 {
+			summary.FailedRequests++
+		}
+		for _, report := range reports {
+			summary.Differences += len(report.DiffEntries)
+		}
+	}
+
+	summary.Passed = summary.FailedResponses == 0
+
+	return summary
+}
+
+// NewJUnitDiffView reports each request compared by diff mode as a test case, which
+// fails when any of its responses differed from the simulation
+func NewJUnitDiffView(diffs map[SimpleRequestDefinitionView][]DiffReport, comparisons map[SimpleRequestDefinitionView]int) JUnitTestSuitesView {
+	testSuite := JUnitTestSuiteView{
+		Name:      "diff",
+		TestCases: []JUnitTestCaseView{},
+	}
+
+	for _, request := range diffRequests(diffs, comparisons) {
+		testCase := JUnitTestCaseView{
+			ClassName: request.Method + " " + request.Host,
+			Name:      request.Method + " " + request.Host + request.Path,
+		}
+		if request.Query != "" {
+			testCase.Name += "?" + request.Query
+		}
+
+		if reports := diffs[request]; len(reports) > 0 {
+			testCase.Failure = &JUnitFailureView{
+				Message:  fmt.Sprintf("%d of %d responses differed from the simulation", len(reports), diffComparisons(request, diffs, comparisons)),
+				Type:     "diff",
+				Contents: junitDiffReports(reports),
+			}
+			testSuite.Failures++
+		}
+
+		testSuite.Tests++
+		testSuite.TestCases = append(testSuite.TestCases, testCase)
+	}
+
+	return JUnitTestSuitesView{
+		Name:       "hoverfly",
+		Tests:      testSuite.Tests,
+		Failures:   testSuite.Failures,
+		TestSuites: []JUnitTestSuiteView{testSuite},
+	}
+}
+
+// diffRequests returns every request which has been compared, sorted so that
+// reports are always in the same order
+func diffRequests(diffs map[SimpleRequestDefinitionView][]DiffReport, comparisons map[SimpleRequestDefinitionView]int) []SimpleRequestDefinitionView {
+	requests := []SimpleRequestDefinitionView{}
+	for request := range comparisons {
+		requests = append(requests, request)
+	}
+	for request := range diffs {
+		if _, ok := comparisons[request]; !ok {
+			requests = append(requests, request)
+		}
+	}
+
+	sort.Slice(requests, func(i, j int) bool {
+		return fmt.Sprint(requests[i]) < fmt.Sprint(requests[j])
+	})
+
+	return requests
+}
+
+func diffComparisons(request SimpleRequestDefinitionView, diffs map[SimpleRequestDefinitionView][]DiffReport, comparisons map[SimpleRequestDefinitionView]int) int {
+	if comparisons[request] < len(diffs[request]) {
+		return len(diffs[request])
+	}
+	return comparisons[request]
+}
+
+func junitDiffReports(reports []DiffReport) string {
+	lines := []string{}
+	for _, report := range reports {
+		lines = append(lines, "Response at "+report.Timestamp+":")
+		for _, entry := range report.DiffEntries {
+			lines = append(lines, fmt.Sprintf("  %s: expected %s but was %s", entry.Field, entry.Expected, entry.Actual))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
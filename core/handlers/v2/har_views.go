@@ -0,0 +1,351 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/core/util"
+)
+
+// HarView is an HTTP Archive, as described by the HAR 1.2 spec
+type HarView struct {
+	Log HarLogView `json:"log"`
+}
+
+type HarLogView struct {
+	Version string         `json:"version"`
+	Creator HarCreatorView `json:"creator"`
+	Entries []HarEntryView `json:"entries"`
+}
+
+type HarCreatorView struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type HarEntryView struct {
+	StartedDateTime string          `json:"startedDateTime"`
+	Time            float64         `json:"time"`
+	Request         HarRequestView  `json:"request"`
+	Response        HarResponseView `json:"response"`
+	Cache           struct{}        `json:"cache"`
+	Timings         HarTimingsView  `json:"timings"`
+}
+
+type HarRequestView struct {
+	Method      string             `json:"method"`
+	URL         string             `json:"url"`
+	HTTPVersion string             `json:"httpVersion"`
+	Cookies     []HarNameValueView `json:"cookies"`
+	Headers     []HarNameValueView `json:"headers"`
+	QueryString []HarNameValueView `json:"queryString"`
+	PostData    *HarPostDataView   `json:"postData,omitempty"`
+	HeadersSize int                `json:"headersSize"`
+	BodySize    int                `json:"bodySize"`
+}
+
+type HarResponseView struct {
+	Status      int                `json:"status"`
+	StatusText  string             `json:"statusText"`
+	HTTPVersion string             `json:"httpVersion"`
+	Cookies     []HarNameValueView `json:"cookies"`
+	Headers     []HarNameValueView `json:"headers"`
+	Content     HarContentView     `json:"content"`
+	RedirectURL string             `json:"redirectURL"`
+	HeadersSize int                `json:"headersSize"`
+	BodySize    int                `json:"bodySize"`
+}
+
+type HarNameValueView struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type HarPostDataView struct {
+	MimeType string             `json:"mimeType"`
+	Text     string             `json:"text"`
+	Params   []HarNameValueView `json:"params,omitempty"`
+}
+
+type HarContentView struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+type HarTimingsView struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// Headers which describe how the body was sent over the wire. HAR content is
+// already decoded, so these would not be true of a simulated response.
+var harTransportHeaders = []string{"Content-Encoding", "Content-Length", "Transfer-Encoding"}
+
+// IsHar returns true if the unmarshalled JSON is an HTTP Archive rather than a simulation
+func IsHar(jsonMap map[string]interface{}) bool {
+	_, hasLog := jsonMap["log"].(map[string]interface{})
+	return hasLog && jsonMap["meta"] == nil
+}
+
+// NewSimulationViewFromHar converts every entry of an HTTP Archive into a request
+// matcher and response pair. The request matchers are the same ones Hoverfly
+// would create when capturing the request.
+func NewSimulationViewFromHar(harView HarView) (SimulationViewV5, error) {
+	pairs := []RequestMatcherResponsePairViewV5{}
+
+	for i, entry := range harView.Log.Entries {
+		requestUrl, err := url.Parse(entry.Request.URL)
+		if err != nil || requestUrl.Host == "" {
+			return SimulationViewV5{}, fmt.Errorf("Invalid HAR: log.entries[%v] has an invalid request url", i)
+		}
+
+		pairs = append(pairs, RequestMatcherResponsePairViewV5{
+			RequestMatcher: newRequestMatcherViewFromHar(entry.Request, requestUrl),
+			Response:       newResponseDetailsViewFromHar(entry.Response),
+		})
+	}
+
+	return BuildSimulationView(pairs, v1.ResponseDelayPayloadView{}, v1.ResponseDelayLogNormalPayloadView{}, ""), nil
+}
+
+func newRequestMatcherViewFromHar(request HarRequestView, requestUrl *url.URL) RequestMatcherViewV5 {
+	headers := getHarHeaders(request.Headers)
+
+	body := ""
+	if request.PostData != nil {
+		body = request.PostData.Text
+		if body == "" && len(request.PostData.Params) > 0 {
+			form := url.Values{}
+			for _, param := range request.PostData.Params {
+				form.Add(param.Name, param.Value)
+			}
+			body = form.Encode()
+		}
+
+		if len(headers["Content-Type"]) == 0 && request.PostData.MimeType != "" {
+			headers["Content-Type"] = []string{request.PostData.MimeType}
+		}
+	}
+
+	var query *QueryMatcherViewV5
+	if queryValues := requestUrl.Query(); len(queryValues) > 0 {
+		query = &QueryMatcherViewV5{}
+		for key, values := range queryValues {
+			(*query)[key] = []MatcherViewV5{
+				NewMatcherView(matchers.Exact, strings.Join(values, ";")),
+			}
+		}
+	}
+
+	return RequestMatcherViewV5{
+		Path:        []MatcherViewV5{NewMatcherView(matchers.Exact, requestUrl.Path)},
+		Method:      []MatcherViewV5{NewMatcherView(matchers.Exact, request.Method)},
+		Destination: []MatcherViewV5{NewMatcherView(matchers.Exact, requestUrl.Host)},
+		Scheme:      []MatcherViewV5{NewMatcherView(matchers.Exact, requestUrl.Scheme)},
+		Query:       query,
+		Body:        NewBodyMatcherViews(body, headers),
+	}
+}
+
+func newResponseDetailsViewFromHar(response HarResponseView) ResponseDetailsViewV5 {
+	headers := getHarHeaders(response.Headers)
+	for _, header := range harTransportHeaders {
+		delete(headers, header)
+	}
+
+	if len(headers) == 0 {
+		headers = nil
+	}
+
+	return ResponseDetailsViewV5{
+		Status:      response.Status,
+		Body:        response.Content.Text,
+		EncodedBody: response.Content.Encoding == "base64",
+		Headers:     headers,
+	}
+}
+
+// getHarHeaders groups the headers by name, leaving out HTTP/2 pseudo-headers
+// such as :authority
+func getHarHeaders(harHeaders []HarNameValueView) map[string][]string {
+	headers := map[string][]string{}
+	for _, header := range harHeaders {
+		if strings.HasPrefix(header.Name, ":") {
+			continue
+		}
+		name := http.CanonicalHeaderKey(header.Name)
+		headers[name] = append(headers[name], header.Value)
+	}
+
+	return headers
+}
+
+// NewBodyMatcherViews chooses how a captured request body should be matched,
+// based on its content type
+func NewBodyMatcherViews(body string, headers map[string][]string) []MatcherViewV5 {
+	switch util.GetContentTypeFromHeaders(headers) {
+	case "json":
+		return []MatcherViewV5{NewMatcherView(matchers.Json, body)}
+	case "xml":
+		return []MatcherViewV5{NewMatcherView(matchers.Xml, body)}
+	case "form":
+		if form, err := url.ParseQuery(body); err == nil {
+			return []MatcherViewV5{NewMatcherView(matchers.Form, getFormFieldMatchers(form))}
+		}
+	case "multipart":
+		if form, err := util.ParseMultipartForm(body); err == nil {
+			return []MatcherViewV5{NewMatcherView(matchers.Multipart, getFormFieldMatchers(form))}
+		}
+	}
+
+	return []MatcherViewV5{NewMatcherView(matchers.Exact, body)}
+}
+
+// getFormFieldMatchers builds the value of a form or multipart matcher, which
+// matches each field exactly
+func getFormFieldMatchers(form map[string][]string) map[string][]MatcherViewV5 {
+	fieldMatchers := map[string][]MatcherViewV5{}
+	for key, values := range form {
+		fieldMatchers[key] = []MatcherViewV5{
+			NewMatcherView(matchers.Exact, strings.Join(values, ";")),
+		}
+	}
+
+	return fieldMatchers
+}
+
+// NewHarViewFromJournal converts journal entries into an HTTP Archive
+func NewHarViewFromJournal(entries []JournalEntryView, version string) HarView {
+	harEntries := []HarEntryView{}
+	for _, entry := range entries {
+		harEntries = append(harEntries, HarEntryView{
+			StartedDateTime: entry.TimeStarted,
+			Time:            entry.Latency,
+			Request:         newHarRequestView(entry.Request),
+			Response:        newHarResponseView(entry.Response),
+			Timings: HarTimingsView{
+				Wait: entry.Latency,
+			},
+		})
+	}
+
+	return HarView{
+		Log: HarLogView{
+			Version: "1.2",
+			Creator: HarCreatorView{
+				Name:    "Hoverfly",
+				Version: version,
+			},
+			Entries: harEntries,
+		},
+	}
+}
+
+func newHarRequestView(request RequestDetailsView) HarRequestView {
+	requestUrl := url.URL{
+		Scheme:   stringValue(request.Scheme),
+		Host:     stringValue(request.Destination),
+		Path:     stringValue(request.Path),
+		RawQuery: stringValue(request.Query),
+	}
+
+	queryString := []HarNameValueView{}
+	queryValues, _ := url.ParseQuery(requestUrl.RawQuery)
+	for _, key := range sortedKeys(queryValues) {
+		for _, value := range queryValues[key] {
+			queryString = append(queryString, HarNameValueView{Name: key, Value: value})
+		}
+	}
+
+	body := stringValue(request.Body)
+
+	harRequest := HarRequestView{
+		Method:      stringValue(request.Method),
+		URL:         requestUrl.String(),
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []HarNameValueView{},
+		Headers:     newHarHeaders(request.Headers),
+		QueryString: queryString,
+		HeadersSize: -1,
+		BodySize:    len(body),
+	}
+
+	if body != "" {
+		harRequest.PostData = &HarPostDataView{
+			MimeType: http.Header(request.Headers).Get("Content-Type"),
+			Text:     body,
+		}
+	}
+
+	return harRequest
+}
+
+func newHarResponseView(response ResponseDetailsView) HarResponseView {
+	content := HarContentView{
+		Size:     len(response.Body),
+		MimeType: http.Header(response.Headers).Get("Content-Type"),
+		Text:     response.Body,
+	}
+	if response.EncodedBody {
+		content.Encoding = "base64"
+	}
+
+	return HarResponseView{
+		Status:      response.Status,
+		StatusText:  http.StatusText(response.Status),
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []HarNameValueView{},
+		Headers:     newHarHeaders(response.Headers),
+		Content:     content,
+		RedirectURL: http.Header(response.Headers).Get("Location"),
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+}
+
+func newHarHeaders(headers map[string][]string) []HarNameValueView {
+	harHeaders := []HarNameValueView{}
+	for _, name := range sortedKeys(headers) {
+		for _, value := range headers[name] {
+			harHeaders = append(harHeaders, HarNameValueView{Name: name, Value: value})
+		}
+	}
+
+	return harHeaders
+}
+
+func sortedKeys(values map[string][]string) []string {
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// NewHarViewFromRequestBody unmarshals an HTTP Archive
+func NewHarViewFromRequestBody(body []byte) (HarView, error) {
+	var harView HarView
+	if err := json.Unmarshal(body, &harView); err != nil {
+		return HarView{}, fmt.Errorf("Invalid HAR: %s", err.Error())
+	}
+
+	return harView, nil
+}
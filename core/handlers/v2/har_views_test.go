@@ -0,0 +1,190 @@
+package v2_test
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func Test_NewSimulationViewFromRequestBody_CanCreateSimulationFromHar(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation, err := v2.NewSimulationViewFromRequestBody([]byte(`{
+		"log": {
+			"version": "1.2",
+			"creator": {"name": "test", "version": "1"},
+			"entries": [
+				{
+					"startedDateTime": "2018-01-02T03:04:05.000Z",
+					"time": 10,
+					"request": {
+						"method": "GET",
+						"url": "http://hoverfly.io/path?b=1&a=2&a=3",
+						"httpVersion": "HTTP/1.1",
+						"headers": [{"name": "Accept", "value": "*/*"}],
+						"queryString": [],
+						"cookies": []
+					},
+					"response": {
+						"status": 200,
+						"statusText": "OK",
+						"headers": [
+							{"name": "Set-Cookie", "value": "a=1"},
+							{"name": "set-cookie", "value": "b=2"},
+							{"name": "Content-Length", "value": "100"}
+						],
+						"content": {"size": 6, "mimeType": "image/png", "text": "aGVsbG8=", "encoding": "base64"}
+					}
+				},
+				{
+					"startedDateTime": "2018-01-02T03:04:06.000Z",
+					"time": 10,
+					"request": {
+						"method": "POST",
+						"url": "http://hoverfly.io/form",
+						"httpVersion": "HTTP/1.1",
+						"headers": [],
+						"postData": {
+							"mimeType": "application/x-www-form-urlencoded",
+							"params": [{"name": "name", "value": "hoverfly"}]
+						}
+					},
+					"response": {
+						"status": 204,
+						"headers": [],
+						"content": {"size": 0, "mimeType": ""}
+					}
+				},
+				{
+					"startedDateTime": "2018-01-02T03:04:07.000Z",
+					"time": 10,
+					"request": {
+						"method": "POST",
+						"url": "http://hoverfly.io/xml",
+						"headers": [{"name": "Content-Type", "value": "application/xml"}],
+						"postData": {"mimeType": "application/xml", "text": "<name>hoverfly</name>"}
+					},
+					"response": {
+						"status": 200,
+						"content": {"size": 0, "mimeType": ""}
+					}
+				}
+			]
+		}
+	}`))
+	Expect(err).To(BeNil())
+
+	Expect(simulation.MetaView.SchemaVersion).To(Equal("v5.1"))
+	Expect(simulation.RequestResponsePairs).To(HaveLen(3))
+
+	getPair := simulation.RequestResponsePairs[0]
+	Expect(getPair.RequestMatcher.Method).To(Equal([]v2.MatcherViewV5{v2.NewMatcherView(matchers.Exact, "GET")}))
+	Expect(getPair.RequestMatcher.Scheme).To(Equal([]v2.MatcherViewV5{v2.NewMatcherView(matchers.Exact, "http")}))
+	Expect(getPair.RequestMatcher.Destination).To(Equal([]v2.MatcherViewV5{v2.NewMatcherView(matchers.Exact, "hoverfly.io")}))
+	Expect(getPair.RequestMatcher.Path).To(Equal([]v2.MatcherViewV5{v2.NewMatcherView(matchers.Exact, "/path")}))
+	Expect(*getPair.RequestMatcher.Query).To(Equal(v2.QueryMatcherViewV5{
+		"a": {v2.NewMatcherView(matchers.Exact, "2;3")},
+		"b": {v2.NewMatcherView(matchers.Exact, "1")},
+	}))
+	Expect(getPair.RequestMatcher.Body).To(Equal([]v2.MatcherViewV5{v2.NewMatcherView(matchers.Exact, "")}))
+	Expect(getPair.RequestMatcher.Headers).To(BeNil())
+
+	Expect(getPair.Response.Status).To(Equal(200))
+	Expect(getPair.Response.Body).To(Equal("aGVsbG8="))
+	Expect(getPair.Response.EncodedBody).To(BeTrue())
+	Expect(getPair.Response.Headers).To(Equal(map[string][]string{"Set-Cookie": {"a=1", "b=2"}}))
+
+	formPair := simulation.RequestResponsePairs[1]
+	Expect(formPair.RequestMatcher.Query).To(BeNil())
+	Expect(formPair.RequestMatcher.Body).To(Equal([]v2.MatcherViewV5{
+		v2.NewMatcherView(matchers.Form, map[string][]v2.MatcherViewV5{
+			"name": {v2.NewMatcherView(matchers.Exact, "hoverfly")},
+		}),
+	}))
+	Expect(formPair.Response.Status).To(Equal(204))
+	Expect(formPair.Response.Headers).To(BeNil())
+
+	xmlPair := simulation.RequestResponsePairs[2]
+	Expect(xmlPair.RequestMatcher.Body).To(Equal([]v2.MatcherViewV5{v2.NewMatcherView(matchers.Xml, "<name>hoverfly</name>")}))
+}
+
+func Test_NewSimulationViewFromRequestBody_ReturnsErrorForHarEntryWithoutAbsoluteUrl(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := v2.NewSimulationViewFromRequestBody([]byte(`{
+		"log": {
+			"version": "1.2",
+			"entries": [{"request": {"method": "GET", "url": "/path"}, "response": {"status": 200}}]
+		}
+	}`))
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Invalid HAR: log.entries[0] has an invalid request url"))
+}
+
+func Test_NewHarViewFromJournal_ConvertsJournalEntries(t *testing.T) {
+	RegisterTestingT(t)
+
+	method := "POST"
+	scheme := "http"
+	destination := "hoverfly.io"
+	path := "/path"
+	query := "b=1&a=2"
+	body := `{"id": 1}`
+
+	harView := v2.NewHarViewFromJournal([]v2.JournalEntryView{
+		{
+			Request: v2.RequestDetailsView{
+				Method:      &method,
+				Scheme:      &scheme,
+				Destination: &destination,
+				Path:        &path,
+				Query:       &query,
+				Body:        &body,
+				Headers: map[string][]string{
+					"Content-Type": {"application/json"},
+					"Accept":       {"*/*"},
+				},
+			},
+			Response: v2.ResponseDetailsView{
+				Status:      302,
+				Body:        "aGVsbG8=",
+				EncodedBody: true,
+				Headers: map[string][]string{
+					"Location": {"http://hoverfly.io/other"},
+				},
+			},
+			Mode:        "simulate",
+			TimeStarted: "2018-01-02T03:04:05.000Z",
+			Latency:     2.5,
+		},
+	}, "v1.1.0")
+
+	Expect(harView.Log.Version).To(Equal("1.2"))
+	Expect(harView.Log.Creator).To(Equal(v2.HarCreatorView{Name: "Hoverfly", Version: "v1.1.0"}))
+	Expect(harView.Log.Entries).To(HaveLen(1))
+
+	entry := harView.Log.Entries[0]
+	Expect(entry.StartedDateTime).To(Equal("2018-01-02T03:04:05.000Z"))
+	Expect(entry.Time).To(Equal(2.5))
+	Expect(entry.Timings.Wait).To(Equal(2.5))
+
+	Expect(entry.Request.Method).To(Equal("POST"))
+	Expect(entry.Request.URL).To(Equal("http://hoverfly.io/path?b=1&a=2"))
+	Expect(entry.Request.Headers).To(Equal([]v2.HarNameValueView{
+		{Name: "Accept", Value: "*/*"},
+		{Name: "Content-Type", Value: "application/json"},
+	}))
+	Expect(entry.Request.QueryString).To(Equal([]v2.HarNameValueView{
+		{Name: "a", Value: "2"},
+		{Name: "b", Value: "1"},
+	}))
+	Expect(entry.Request.PostData).To(Equal(&v2.HarPostDataView{MimeType: "application/json", Text: body}))
+
+	Expect(entry.Response.Status).To(Equal(302))
+	Expect(entry.Response.StatusText).To(Equal("Found"))
+	Expect(entry.Response.RedirectURL).To(Equal("http://hoverfly.io/other"))
+	Expect(entry.Response.Content).To(Equal(v2.HarContentView{Size: 8, Text: "aGVsbG8=", Encoding: "base64"}))
+}
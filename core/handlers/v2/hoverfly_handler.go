@@ -13,7 +13,7 @@ import (
 type Hoverfly interface {
 	GetCORS() CORSView
 	GetDestination() string
-	GetMiddleware() (string, string, string)
+	GetMiddlewareView() MiddlewareView
 	GetMode() ModeView
 	GetStats() metrics.Stats
 	GetVersion() string
@@ -46,7 +46,7 @@ func (this *HoverflyHandler) Get(w http.ResponseWriter, req *http.Request, next
 	hoverflyView.Destination = this.Hoverfly.GetDestination()
 	hoverflyView.Mode = this.Hoverfly.GetMode().Mode
 	hoverflyView.Arguments = this.Hoverfly.GetMode().Arguments
-	hoverflyView.Binary, hoverflyView.Script, hoverflyView.Remote = this.Hoverfly.GetMiddleware()
+	hoverflyView.MiddlewareView = this.Hoverfly.GetMiddlewareView()
 	hoverflyView.Usage = this.Hoverfly.GetStats()
 	hoverflyView.Version = this.Hoverfly.GetVersion()
 	hoverflyView.UpstreamProxy = this.Hoverfly.GetUpstreamProxy()
@@ -33,8 +33,12 @@ func (this HoverflyStub) GetMode() ModeView {
 		}}
 }
 
-func (this HoverflyStub) GetMiddleware() (string, string, string) {
-	return "test-binary", "test-script", "test-remote"
+func (this HoverflyStub) GetMiddlewareView() MiddlewareView {
+	return MiddlewareView{
+		Binary: "test-binary",
+		Script: "test-script",
+		Remote: "test-remote",
+	}
 }
 
 func (this HoverflyStub) GetStats() metrics.Stats {
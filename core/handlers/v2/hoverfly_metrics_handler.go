@@ -0,0 +1,39 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers"
+	"github.com/codegangsta/negroni"
+	"github.com/go-zoo/bone"
+)
+
+// Content type of version 0.0.4 of the Prometheus text exposition format
+const PrometheusTextContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type HoverflyMetrics interface {
+	GetMetrics() string
+}
+
+type HoverflyMetricsHandler struct {
+	Hoverfly HoverflyMetrics
+}
+
+func (this *HoverflyMetricsHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler) {
+	mux.Get("/api/v2/metrics", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Get),
+	))
+	mux.Options("/api/v2/metrics", negroni.New(
+		negroni.HandlerFunc(this.Options),
+	))
+}
+
+func (this *HoverflyMetricsHandler) Get(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	handlers.WriteResponseWithContentType(w, []byte(this.Hoverfly.GetMetrics()), PrometheusTextContentType)
+}
+
+func (this *HoverflyMetricsHandler) Options(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, GET")
+	handlers.WriteResponse(w, []byte(""))
+}
@@ -0,0 +1,45 @@
+package v2
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+type HoverflyMetricsStub struct{}
+
+func (this HoverflyMetricsStub) GetMetrics() string {
+	return "# HELP hoverfly_cache_hits_total Lookups which found a response in the request cache.\n" +
+		"# TYPE hoverfly_cache_hits_total counter\n" +
+		"hoverfly_cache_hits_total 3\n"
+}
+
+func Test_HoverflyMetricsHandler_Get_ReturnsMetricsInPrometheusTextFormat(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := HoverflyMetricsHandler{Hoverfly: HoverflyMetricsStub{}}
+
+	request, err := http.NewRequest("GET", "/api/v2/metrics", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Header().Get("Content-Type")).To(Equal("text/plain; version=0.0.4; charset=utf-8"))
+	Expect(response.Body.String()).To(ContainSubstring("hoverfly_cache_hits_total 3\n"))
+}
+
+func Test_HoverflyMetricsHandler_Options_GetsOptions(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := HoverflyMetricsHandler{Hoverfly: HoverflyMetricsStub{}}
+
+	request, err := http.NewRequest("OPTIONS", "/api/v2/metrics", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Options, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Header().Get("Allow")).To(Equal("OPTIONS, GET"))
+}
@@ -0,0 +1,75 @@
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers"
+	"github.com/codegangsta/negroni"
+	"github.com/go-zoo/bone"
+)
+
+type HoverflyMiddlewareChain interface {
+	GetMiddlewareChain() MiddlewareChainView
+	SetMiddlewareChain(MiddlewareChainView) error
+	DeleteMiddlewareChain()
+}
+
+type HoverflyMiddlewareChainHandler struct {
+	Hoverfly HoverflyMiddlewareChain
+}
+
+func (this *HoverflyMiddlewareChainHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler) {
+	mux.Get("/api/v2/hoverfly/middleware/chain", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Get),
+	))
+
+	mux.Put("/api/v2/hoverfly/middleware/chain", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Put),
+	))
+
+	mux.Delete("/api/v2/hoverfly/middleware/chain", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Delete),
+	))
+
+	mux.Options("/api/v2/hoverfly/middleware/chain", negroni.New(
+		negroni.HandlerFunc(this.Options),
+	))
+}
+
+func (this *HoverflyMiddlewareChainHandler) Get(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	chainBytes, _ := json.Marshal(this.Hoverfly.GetMiddlewareChain())
+
+	handlers.WriteResponse(w, chainBytes)
+}
+
+func (this *HoverflyMiddlewareChainHandler) Put(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	var chainReq MiddlewareChainView
+	err := handlers.ReadFromRequest(req, &chainReq)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), 400)
+		return
+	}
+
+	err = this.Hoverfly.SetMiddlewareChain(chainReq)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), 422)
+		return
+	}
+
+	this.Get(w, req, next)
+}
+
+func (this *HoverflyMiddlewareChainHandler) Delete(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	this.Hoverfly.DeleteMiddlewareChain()
+
+	this.Get(w, req, next)
+}
+
+func (this *HoverflyMiddlewareChainHandler) Options(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, GET, PUT, DELETE")
+	handlers.WriteResponse(w, []byte(""))
+}
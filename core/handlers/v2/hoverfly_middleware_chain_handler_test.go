@@ -0,0 +1,139 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+type HoverflyMiddlewareChainStub struct {
+	Stages []MiddlewareStageView
+}
+
+func (this HoverflyMiddlewareChainStub) GetMiddlewareChain() MiddlewareChainView {
+	return MiddlewareChainView{Stages: this.Stages}
+}
+
+func (this *HoverflyMiddlewareChainStub) SetMiddlewareChain(chainView MiddlewareChainView) error {
+	for _, stage := range chainView.Stages {
+		if stage.Phase == "error" {
+			return fmt.Errorf("error")
+		}
+	}
+
+	this.Stages = chainView.Stages
+	return nil
+}
+
+func (this *HoverflyMiddlewareChainStub) DeleteMiddlewareChain() {
+	this.Stages = []MiddlewareStageView{}
+}
+
+func TestHoverflyMiddlewareChainHandlerGetReturnsTheStages(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyMiddlewareChainStub{
+		Stages: []MiddlewareStageView{
+			{Phase: "on-miss", Modes: []string{"spy"}, MiddlewareView: MiddlewareView{Remote: "http://test.com"}},
+		},
+	}
+
+	unit := HoverflyMiddlewareChainHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Body.String()).To(Equal(`{"stages":[{"phase":"on-miss","modes":["spy"],"binary":"","script":"","remote":"http://test.com"}]}`))
+}
+
+func TestHoverflyMiddlewareChainHandlerPutSetsTheStages(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyMiddlewareChainStub{}
+	unit := HoverflyMiddlewareChainHandler{Hoverfly: stubHoverfly}
+
+	bodyBytes := []byte(`{"stages": [{"phase": "pre-request", "destination": "test.com", "binary": "python", "script": "middleware"}, {"phase": "post-response", "javascript": "pair.response.status = 201"}]}`)
+
+	request, err := http.NewRequest("PUT", "", ioutil.NopCloser(bytes.NewBuffer(bodyBytes)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	Expect(stubHoverfly.Stages).To(Equal([]MiddlewareStageView{
+		{Phase: "pre-request", Destination: "test.com", MiddlewareView: MiddlewareView{Binary: "python", Script: "middleware"}},
+		{Phase: "post-response", MiddlewareView: MiddlewareView{Javascript: "pair.response.status = 201"}},
+	}))
+
+	var chainView MiddlewareChainView
+	Expect(json.Unmarshal(response.Body.Bytes(), &chainView)).To(BeNil())
+	Expect(chainView.Stages).To(HaveLen(2))
+}
+
+func TestHoverflyMiddlewareChainHandlerPutWill422ErrorIfHoverflyErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyMiddlewareChainStub{}
+	unit := HoverflyMiddlewareChainHandler{Hoverfly: stubHoverfly}
+
+	bodyBytes := []byte(`{"stages": [{"phase": "error"}]}`)
+
+	request, err := http.NewRequest("PUT", "", ioutil.NopCloser(bytes.NewBuffer(bodyBytes)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+	Expect(response.Code).To(Equal(http.StatusUnprocessableEntity))
+
+	errorViewResponse, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorViewResponse.Error).To(Equal("error"))
+}
+
+func TestHoverflyMiddlewareChainHandlerPutWill400ErrorIfJsonIsBad(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyMiddlewareChainStub{}
+	unit := HoverflyMiddlewareChainHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("PUT", "", ioutil.NopCloser(bytes.NewBuffer([]byte("{{}{}}"))))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+}
+
+func TestHoverflyMiddlewareChainHandlerDeleteRemovesTheStages(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyMiddlewareChainStub{
+		Stages: []MiddlewareStageView{{Phase: "on-miss"}},
+	}
+	unit := HoverflyMiddlewareChainHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("DELETE", "", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Delete, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Body.String()).To(Equal(`{"stages":[]}`))
+}
+
+func TestHoverflyMiddlewareChainHandlerOptionsGetsOptions(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := HoverflyMiddlewareChainHandler{Hoverfly: &HoverflyMiddlewareChainStub{}}
+
+	request, err := http.NewRequest("OPTIONS", "", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Options, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Header().Get("Allow")).To(Equal("OPTIONS, GET, PUT, DELETE"))
+}
@@ -10,8 +10,8 @@ import (
 )
 
 type HoverflyMiddleware interface {
-	GetMiddleware() (string, string, string)
-	SetMiddleware(string, string, string) error
+	GetMiddlewareView() MiddlewareView
+	SetMiddlewareView(MiddlewareView) error
 }
 
 type HoverflyMiddlewareHandler struct {
@@ -34,10 +34,7 @@ func (this *HoverflyMiddlewareHandler) RegisterRoutes(mux *bone.Mux, am *handler
 }
 
 func (this *HoverflyMiddlewareHandler) Get(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
-	var middlewareView MiddlewareView
-	middlewareView.Binary, middlewareView.Script, middlewareView.Remote = this.Hoverfly.GetMiddleware()
-
-	middlewareBytes, _ := json.Marshal(middlewareView)
+	middlewareBytes, _ := json.Marshal(this.Hoverfly.GetMiddlewareView())
 
 	handlers.WriteResponse(w, middlewareBytes)
 }
@@ -50,7 +47,7 @@ func (this *HoverflyMiddlewareHandler) Put(w http.ResponseWriter, req *http.Requ
 		return
 	}
 
-	err = this.Hoverfly.SetMiddleware(middlewareReq.Binary, middlewareReq.Script, middlewareReq.Remote)
+	err = this.Hoverfly.SetMiddlewareView(middlewareReq)
 	if err != nil {
 		handlers.WriteErrorResponse(w, err.Error(), 422)
 		return
@@ -12,20 +12,33 @@ import (
 )
 
 type HoverflyMiddlewareStub struct {
-	Binary string
-	Script string
-	Remote string
+	Binary  string
+	Script  string
+	Remote  string
+	Grpc    string
+	Workers int
+	Timeout int
 }
 
-func (this HoverflyMiddlewareStub) GetMiddleware() (string, string, string) {
-	return this.Binary, this.Script, this.Remote
+func (this HoverflyMiddlewareStub) GetMiddlewareView() MiddlewareView {
+	return MiddlewareView{
+		Binary:  this.Binary,
+		Script:  this.Script,
+		Remote:  this.Remote,
+		Grpc:    this.Grpc,
+		Workers: this.Workers,
+		Timeout: this.Timeout,
+	}
 }
 
-func (this *HoverflyMiddlewareStub) SetMiddleware(binary, script, remote string) error {
-	this.Binary = binary
-	this.Script = script
-	this.Remote = remote
-	if script == "error" {
+func (this *HoverflyMiddlewareStub) SetMiddlewareView(middlewareView MiddlewareView) error {
+	this.Binary = middlewareView.Binary
+	this.Script = middlewareView.Script
+	this.Remote = middlewareView.Remote
+	this.Grpc = middlewareView.Grpc
+	this.Workers = middlewareView.Workers
+	this.Timeout = middlewareView.Timeout
+	if middlewareView.Script == "error" {
 		return fmt.Errorf("error")
 	}
 
@@ -83,6 +96,73 @@ func TestHoverflyMiddlewareHandlerPutSetsTheNewMiddlewarendReplacesTheTestMiddle
 	Expect(middlewareViewResponse.Script).To(Equal("new-middleware"))
 }
 
+func TestHoverflyMiddlewareHandlerGetReturnsTheWorkersAndTimeout(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyMiddlewareStub{
+		Binary:  "python",
+		Script:  "middleware",
+		Workers: 4,
+		Timeout: 500,
+	}
+
+	unit := HoverflyMiddlewareHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	middlewareView, err := unmarshalMiddlewareView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(middlewareView.Workers).To(Equal(4))
+	Expect(middlewareView.Timeout).To(Equal(500))
+}
+
+func TestHoverflyMiddlewareHandlerPutSetsTheWorkersAndTimeout(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyMiddlewareStub{}
+	unit := HoverflyMiddlewareHandler{Hoverfly: stubHoverfly}
+
+	bodyBytes := []byte(`{"binary": "python", "script": "middleware", "workers": 2, "timeout": 1000}`)
+
+	request, err := http.NewRequest("PUT", "", ioutil.NopCloser(bytes.NewBuffer(bodyBytes)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.Workers).To(Equal(2))
+	Expect(stubHoverfly.Timeout).To(Equal(1000))
+
+	middlewareViewResponse, err := unmarshalMiddlewareView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(middlewareViewResponse.Workers).To(Equal(2))
+	Expect(middlewareViewResponse.Timeout).To(Equal(1000))
+}
+
+func TestHoverflyMiddlewareHandlerPutSetsGrpcMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyMiddlewareStub{Binary: "test"}
+	unit := HoverflyMiddlewareHandler{Hoverfly: stubHoverfly}
+
+	bodyBytes := []byte(`{"grpc": "grpc://localhost:50051"}`)
+
+	request, err := http.NewRequest("PUT", "", ioutil.NopCloser(bytes.NewBuffer(bodyBytes)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.Binary).To(Equal(""))
+	Expect(stubHoverfly.Grpc).To(Equal("grpc://localhost:50051"))
+
+	middlewareViewResponse, err := unmarshalMiddlewareView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(middlewareViewResponse.Grpc).To(Equal("grpc://localhost:50051"))
+}
+
 func TestHoverflyMiddlewareHandlerPutWill422ErrorIfHoverflyErrors(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -0,0 +1,57 @@
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers"
+	"github.com/codegangsta/negroni"
+	"github.com/go-zoo/bone"
+)
+
+type HoverflyTemplating interface {
+	GetTemplating() TemplatingView
+	SetTemplating(TemplatingView)
+}
+
+type HoverflyTemplatingHandler struct {
+	Hoverfly HoverflyTemplating
+}
+
+func (this *HoverflyTemplatingHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler) {
+	mux.Get("/api/v2/hoverfly/templating", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Get),
+	))
+	mux.Put("/api/v2/hoverfly/templating", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Put),
+	))
+	mux.Options("/api/v2/hoverfly/templating", negroni.New(
+		negroni.HandlerFunc(this.Options),
+	))
+}
+
+func (this *HoverflyTemplatingHandler) Get(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	bytes, _ := json.Marshal(this.Hoverfly.GetTemplating())
+
+	handlers.WriteResponse(w, bytes)
+}
+
+func (this *HoverflyTemplatingHandler) Put(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	var templatingView TemplatingView
+	err := handlers.ReadFromRequest(req, &templatingView)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	this.Hoverfly.SetTemplating(templatingView)
+
+	this.Get(w, req, next)
+}
+
+func (this *HoverflyTemplatingHandler) Options(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, GET, PUT")
+	handlers.WriteResponse(w, []byte(""))
+}
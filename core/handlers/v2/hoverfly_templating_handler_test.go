@@ -0,0 +1,74 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+type HoverflyTemplatingStub struct {
+	TemplatingView TemplatingView
+}
+
+func (this HoverflyTemplatingStub) GetTemplating() TemplatingView {
+	return this.TemplatingView
+}
+
+func (this *HoverflyTemplatingStub) SetTemplating(templatingView TemplatingView) {
+	this.TemplatingView = templatingView
+}
+
+func Test_HoverflyTemplatingHandler_Get_ReturnsTheSeed(t *testing.T) {
+	RegisterTestingT(t)
+
+	seed := int64(42)
+	unit := HoverflyTemplatingHandler{Hoverfly: &HoverflyTemplatingStub{TemplatingView{Seed: &seed}}}
+
+	request, err := http.NewRequest("GET", "/api/v2/hoverfly/templating", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Body.String()).To(Equal(`{"seed":42}`))
+}
+
+func Test_HoverflyTemplatingHandler_Put_SetsAndClearsTheSeed(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyTemplatingStub{}
+	unit := HoverflyTemplatingHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("PUT", "/api/v2/hoverfly/templating", ioutil.NopCloser(bytes.NewBufferString(`{"seed": 42}`)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(*stubHoverfly.TemplatingView.Seed).To(Equal(int64(42)))
+
+	var templatingView TemplatingView
+	Expect(json.Unmarshal(response.Body.Bytes(), &templatingView)).To(Succeed())
+	Expect(*templatingView.Seed).To(Equal(int64(42)))
+
+	request, err = http.NewRequest("PUT", "/api/v2/hoverfly/templating", ioutil.NopCloser(bytes.NewBufferString(`{"seed": null}`)))
+	Expect(err).To(BeNil())
+
+	response = makeRequestOnHandler(unit.Put, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.TemplatingView.Seed).To(BeNil())
+}
+
+func Test_HoverflyTemplatingHandler_Put_ReturnsErrorForInvalidJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := HoverflyTemplatingHandler{Hoverfly: &HoverflyTemplatingStub{}}
+
+	request, err := http.NewRequest("PUT", "/api/v2/hoverfly/templating", ioutil.NopCloser(bytes.NewBufferString(`{"seed": "abc"}`)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+}
@@ -2,6 +2,7 @@ package v2
 
 import (
 	"encoding/json"
+	"math"
 	"net/http"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers"
@@ -17,11 +18,14 @@ const DefaultJournalLimit = 25
 type HoverflyJournal interface {
 	GetEntries(offset int, limit int, from *time.Time, to *time.Time, sort string) (JournalView, error)
 	GetFilteredEntries(journalEntryFilterView JournalEntryFilterView) ([]JournalEntryView, error)
+	VerifyEntries(journalVerifyView JournalVerifyView) (JournalVerifyResultView, error)
 	DeleteEntries() error
 }
 
 type JournalHandler struct {
 	Hoverfly HoverflyJournal
+	// Version is the Hoverfly version given as the creator of HAR exports
+	Version string
 }
 
 func (this *JournalHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler) {
@@ -40,6 +44,14 @@ func (this *JournalHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandl
 	mux.Options("/api/v2/journal", negroni.New(
 		negroni.HandlerFunc(this.Options),
 	))
+
+	mux.Post("/api/v2/journal/verify", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.PostVerify),
+	))
+	mux.Options("/api/v2/journal/verify", negroni.New(
+		negroni.HandlerFunc(this.OptionsVerify),
+	))
 }
 
 func (this *JournalHandler) Get(response http.ResponseWriter, request *http.Request, next http.HandlerFunc) {
@@ -50,9 +62,20 @@ func (this *JournalHandler) Get(response http.ResponseWriter, request *http.Requ
 	fromTime := util.GetUnixTimeQueryParam(request, "from")
 	toTime := util.GetUnixTimeQueryParam(request, "to")
 	sort := queryParams.Get("sort")
+	format := queryParams.Get("format")
+
+	if format != "" && format != "json" && format != "har" {
+		handlers.WriteErrorResponse(response, "Unsupported format "+format+", should be json or har", http.StatusBadRequest)
+		return
+	}
 
 	if limit == 0 {
-		limit = DefaultJournalLimit
+		if format == "har" {
+			// An archive holds every entry unless a limit is given
+			limit = math.MaxInt32
+		} else {
+			limit = DefaultJournalLimit
+		}
 	}
 
 	journalView, err := this.Hoverfly.GetEntries(offset, limit, fromTime, toTime, sort)
@@ -61,7 +84,12 @@ func (this *JournalHandler) Get(response http.ResponseWriter, request *http.Requ
 		return
 	}
 
-	bytes, _ := json.Marshal(journalView)
+	var bytes []byte
+	if format == "har" {
+		bytes, _ = json.Marshal(NewHarViewFromJournal(journalView.Journal, this.Version))
+	} else {
+		bytes, _ = json.Marshal(journalView)
+	}
 	handlers.WriteResponse(response, bytes)
 }
 
@@ -91,6 +119,33 @@ func (this *JournalHandler) Post(response http.ResponseWriter, request *http.Req
 	handlers.WriteResponse(response, bytes)
 }
 
+func (this *JournalHandler) PostVerify(response http.ResponseWriter, request *http.Request, next http.HandlerFunc) {
+	var journalVerifyView JournalVerifyView
+
+	err := handlers.ReadFromRequest(request, &journalVerifyView)
+	if err != nil {
+		handlers.WriteErrorResponse(response, err.Error(), http.StatusBadRequest)
+		return
+	} else if journalVerifyView.Request == nil {
+		handlers.WriteErrorResponse(response, "No \"request\" object in verify parameters", http.StatusBadRequest)
+		return
+	}
+
+	if _, _, err := journalVerifyView.Count.GetRange(); err != nil {
+		handlers.WriteErrorResponse(response, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := this.Hoverfly.VerifyEntries(journalVerifyView)
+	if err != nil {
+		handlers.WriteErrorResponse(response, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	bytes, _ := json.Marshal(result)
+	handlers.WriteResponse(response, bytes)
+}
+
 func (this *JournalHandler) Delete(response http.ResponseWriter, request *http.Request, next http.HandlerFunc) {
 	err := this.Hoverfly.DeleteEntries()
 	if err != nil {
@@ -105,3 +160,8 @@ func (this *JournalHandler) Options(w http.ResponseWriter, r *http.Request, next
 	w.Header().Add("Allow", "OPTIONS, GET, DELETE, POST")
 	handlers.WriteResponse(w, []byte(""))
 }
+
+func (this *JournalHandler) OptionsVerify(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, POST")
+	handlers.WriteResponse(w, []byte(""))
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"io/ioutil"
+	"math"
 	"net/http"
 	"testing"
 
@@ -24,6 +25,7 @@ type HoverflyJournalStub struct {
 	from                   *time.Time
 	to                     *time.Time
 	journalEntryFilterView JournalEntryFilterView
+	journalVerifyView      JournalVerifyView
 }
 
 func (this *HoverflyJournalStub) GetEntries(offset int, limit int, from *time.Time, to *time.Time, sort string) (JournalView, error) {
@@ -66,6 +68,24 @@ func (this *HoverflyJournalStub) GetFilteredEntries(journalEntryFilterView Journ
 	}, nil
 }
 
+func (this *HoverflyJournalStub) VerifyEntries(journalVerifyView JournalVerifyView) (JournalVerifyResultView, error) {
+	if this.error {
+		return JournalVerifyResultView{}, fmt.Errorf("verify error")
+	}
+
+	this.journalVerifyView = journalVerifyView
+	return JournalVerifyResultView{
+		Passed:  false,
+		Message: "Expected request to be made exactly 2 times, but it was made once",
+		Count:   1,
+		Matches: []JournalEntryView{
+			{
+				Mode: "test",
+			},
+		},
+	}, nil
+}
+
 func (this *HoverflyJournalStub) DeleteEntries() error {
 	if this.error {
 		return fmt.Errorf("delete error")
@@ -113,6 +133,46 @@ func Test_JournalHandler_Get_SetDefaultPagingQueryIfNotSpecified(t *testing.T) {
 	Expect(stubHoverfly.offset).To(Equal(0))
 }
 
+func Test_JournalHandler_Get_WithHarFormatReturnsEveryEntryAsHar(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyJournalStub{}
+	unit := JournalHandler{Hoverfly: stubHoverfly, Version: "v1.1.0"}
+
+	request, err := http.NewRequest("GET", "/api/v2/journal?format=har", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.limit).To(Equal(math.MaxInt32))
+
+	var harView HarView
+	Expect(json.Unmarshal(response.Body.Bytes(), &harView)).To(Succeed())
+
+	Expect(harView.Log.Version).To(Equal("1.2"))
+	Expect(harView.Log.Creator.Version).To(Equal("v1.1.0"))
+	Expect(harView.Log.Entries).To(HaveLen(1))
+}
+
+func Test_JournalHandler_Get_WithUnknownFormatReturnsBadRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyJournalStub{}
+	unit := JournalHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "/api/v2/journal?format=xml", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(Equal("Unsupported format xml, should be json or har"))
+}
+
 func Test_JournalHandler_Get_WithPagingQuery(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -320,6 +380,139 @@ func Test_JournalHandler_Post_JournalError(t *testing.T) {
 	Expect(errorView.Error).To(Equal("journal error"))
 }
 
+func Test_JournalHandler_PostVerify_CallsVerify(t *testing.T) {
+	RegisterTestingT(t)
+
+	var stubHoverfly HoverflyJournalStub
+	unit := JournalHandler{Hoverfly: &stubHoverfly}
+
+	exactly := 2
+	body, _ := json.Marshal(JournalVerifyView{
+		Request: &RequestMatcherViewV5{
+			Path: []MatcherViewV5{
+				NewMatcherView(matchers.Exact, "/path"),
+			},
+		},
+		Count: &CountConstraintView{
+			Exactly: &exactly,
+		},
+	})
+
+	request, err := http.NewRequest("POST", "/api/v2/journal/verify", bytes.NewBuffer(body))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.PostVerify, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	var resultView JournalVerifyResultView
+	Expect(json.Unmarshal(response.Body.Bytes(), &resultView)).To(Succeed())
+
+	Expect(resultView.Passed).To(BeFalse())
+	Expect(resultView.Count).To(Equal(1))
+	Expect(resultView.Matches).To(HaveLen(1))
+
+	Expect(stubHoverfly.journalVerifyView.Request.Path[0].Value).To(Equal("/path"))
+	Expect(*stubHoverfly.journalVerifyView.Count.Exactly).To(Equal(2))
+}
+
+func Test_JournalHandler_PostVerify_MalformedJson_EmptyRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	var stubHoverfly HoverflyJournalStub
+	unit := JournalHandler{Hoverfly: &stubHoverfly}
+
+	request, err := http.NewRequest("POST", "/api/v2/journal/verify", bytes.NewBufferString(`{"count": {"never": true}}`))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.PostVerify, request)
+
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(errorView.Error).To(Equal("No \"request\" object in verify parameters"))
+}
+
+func Test_JournalHandler_PostVerify_InvalidCount(t *testing.T) {
+	RegisterTestingT(t)
+
+	var stubHoverfly HoverflyJournalStub
+	unit := JournalHandler{Hoverfly: &stubHoverfly}
+
+	request, err := http.NewRequest("POST", "/api/v2/journal/verify", bytes.NewBufferString(`{"request": {}, "count": {"atLeast": 3, "atMost": 1}}`))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.PostVerify, request)
+
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(errorView.Error).To(Equal("count atLeast cannot be greater than atMost"))
+}
+
+func Test_JournalHandler_PostVerify_JournalError(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := HoverflyJournalStub{
+		error: true,
+	}
+	unit := JournalHandler{Hoverfly: &stubHoverfly}
+
+	request, err := http.NewRequest("POST", "/api/v2/journal/verify", bytes.NewBufferString(`{"request": {}}`))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.PostVerify, request)
+
+	Expect(response.Code).To(Equal(http.StatusInternalServerError))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(errorView.Error).To(Equal("verify error"))
+}
+
+func Test_CountConstraintView_GetRange(t *testing.T) {
+	RegisterTestingT(t)
+
+	one, two := 1, 2
+	negative := -1
+
+	var noConstraint *CountConstraintView
+	min, max, err := noConstraint.GetRange()
+	Expect(err).To(BeNil())
+	Expect([]int{min, max}).To(Equal([]int{1, -1}))
+	Expect(noConstraint.String()).To(Equal("at least 1 time"))
+
+	min, max, _ = (&CountConstraintView{Exactly: &two}).GetRange()
+	Expect([]int{min, max}).To(Equal([]int{2, 2}))
+	Expect((&CountConstraintView{Exactly: &two}).String()).To(Equal("exactly 2 times"))
+
+	min, max, _ = (&CountConstraintView{AtMost: &two}).GetRange()
+	Expect([]int{min, max}).To(Equal([]int{0, 2}))
+	Expect((&CountConstraintView{AtMost: &two}).String()).To(Equal("at most 2 times"))
+
+	min, max, _ = (&CountConstraintView{AtLeast: &one, AtMost: &two}).GetRange()
+	Expect([]int{min, max}).To(Equal([]int{1, 2}))
+	Expect((&CountConstraintView{AtLeast: &one, AtMost: &two}).String()).To(Equal("between 1 and 2 times"))
+
+	min, max, _ = (&CountConstraintView{Never: true}).GetRange()
+	Expect([]int{min, max}).To(Equal([]int{0, 0}))
+	Expect((&CountConstraintView{Never: true}).String()).To(Equal("never"))
+
+	_, _, err = (&CountConstraintView{Never: true, AtLeast: &one}).GetRange()
+	Expect(err).To(MatchError("count never cannot be combined with other count constraints"))
+
+	_, _, err = (&CountConstraintView{Exactly: &one, AtMost: &two}).GetRange()
+	Expect(err).To(MatchError("count exactly cannot be combined with atLeast or atMost"))
+
+	_, _, err = (&CountConstraintView{AtMost: &negative}).GetRange()
+	Expect(err).To(MatchError("count atMost must not be negative"))
+}
+
 func Test_JournalHandler_Delete_CallsDelete(t *testing.T) {
 	RegisterTestingT(t)
 
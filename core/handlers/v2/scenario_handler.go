@@ -0,0 +1,81 @@
+package v2
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers"
+	"github.com/codegangsta/negroni"
+	"github.com/go-zoo/bone"
+)
+
+type HoverflyScenarios interface {
+	GetScenario(name string) ScenarioView
+	SetScenario(name string, scenario ScenarioView) error
+}
+
+type ScenarioHandler struct {
+	Hoverfly HoverflyScenarios
+}
+
+func (this *ScenarioHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler) {
+	mux.Get("/api/v2/scenarios/:name", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Get),
+	))
+	mux.Put("/api/v2/scenarios/:name", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Put),
+	))
+	mux.Options("/api/v2/scenarios/:name", negroni.New(
+		negroni.HandlerFunc(this.Options),
+	))
+}
+
+func (this *ScenarioHandler) Get(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	name, ok := getScenarioName(req)
+	if !ok {
+		handlers.WriteErrorResponse(w, "The name of a scenario must be set and cannot contain a colon", http.StatusBadRequest)
+		return
+	}
+
+	marshal, _ := json.Marshal(this.Hoverfly.GetScenario(name))
+
+	handlers.WriteResponse(w, marshal)
+}
+
+func (this *ScenarioHandler) Put(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	name, ok := getScenarioName(req)
+	if !ok {
+		handlers.WriteErrorResponse(w, "The name of a scenario must be set and cannot contain a colon", http.StatusBadRequest)
+		return
+	}
+
+	var scenarioView ScenarioView
+
+	body, _ := ioutil.ReadAll(req.Body)
+	if err := json.Unmarshal(body, &scenarioView); err != nil {
+		handlers.WriteErrorResponse(w, "Malformed JSON", http.StatusBadRequest)
+		return
+	}
+
+	if err := this.Hoverfly.SetScenario(name, scenarioView); err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	this.Get(w, req, next)
+}
+
+func (this *ScenarioHandler) Options(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, GET, PUT")
+	handlers.WriteResponse(w, []byte(""))
+}
+
+func getScenarioName(req *http.Request) (string, bool) {
+	name := strings.TrimPrefix(req.URL.Path, "/api/v2/scenarios/")
+
+	return name, name != "" && !strings.Contains(name, ":") && !strings.Contains(name, "/")
+}
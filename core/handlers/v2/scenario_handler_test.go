@@ -0,0 +1,129 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+type HoverflyScenariosStub struct {
+	Name     string
+	Scenario ScenarioView
+	Error    error
+}
+
+func (this *HoverflyScenariosStub) GetScenario(name string) ScenarioView {
+	this.Name = name
+
+	return this.Scenario
+}
+
+func (this *HoverflyScenariosStub) SetScenario(name string, scenario ScenarioView) error {
+	if this.Error != nil {
+		return this.Error
+	}
+
+	this.Name = name
+	this.Scenario = scenario
+
+	return nil
+}
+
+func Test_ScenarioHandler_Get_ReturnsTheScenario(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyScenariosStub{
+		Scenario: ScenarioView{
+			State:  map[string]string{"basket": "empty"},
+			States: []string{"empty", "full"},
+		},
+	}
+	unit := ScenarioHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "/api/v2/scenarios/checkout", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.Name).To(Equal("checkout"))
+	Expect(response.Body.String()).To(MatchJSON(`{"state": {"basket": "empty"}, "states": ["empty", "full"]}`))
+}
+
+func Test_ScenarioHandler_Get_RejectsANameWithAColon(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := ScenarioHandler{Hoverfly: &HoverflyScenariosStub{}}
+
+	request, err := http.NewRequest("GET", "/api/v2/scenarios/check:out", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+
+	errorViewResponse, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorViewResponse.Error).To(Equal("The name of a scenario must be set and cannot contain a colon"))
+}
+
+func Test_ScenarioHandler_Put_SetsTheScenario(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyScenariosStub{}
+	unit := ScenarioHandler{Hoverfly: stubHoverfly}
+
+	body, _ := json.Marshal(ScenarioView{
+		State: map[string]string{"basket": "empty"},
+		Transitions: []TransitionView{
+			{
+				From: "empty",
+				To:   "full",
+			},
+		},
+	})
+
+	request, err := http.NewRequest("PUT", "/api/v2/scenarios/checkout", ioutil.NopCloser(bytes.NewBuffer(body)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.Name).To(Equal("checkout"))
+	Expect(stubHoverfly.Scenario.State).To(Equal(map[string]string{"basket": "empty"}))
+	Expect(response.Body.String()).To(MatchJSON(`{"state": {"basket": "empty"}, "transitions": [{"from": "empty", "to": "full"}]}`))
+}
+
+func Test_ScenarioHandler_Put_ReturnsAnErrorIfTheScenarioCannotBeSet(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := ScenarioHandler{Hoverfly: &HoverflyScenariosStub{Error: errors.New("overflowing is not one of the states declared for basket")}}
+
+	request, err := http.NewRequest("PUT", "/api/v2/scenarios/checkout", ioutil.NopCloser(bytes.NewBuffer([]byte(`{"state": {"basket": "overflowing"}}`))))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+
+	errorViewResponse, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorViewResponse.Error).To(Equal("overflowing is not one of the states declared for basket"))
+}
+
+func Test_ScenarioHandler_Put_ReturnsAnErrorForMalformedJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := ScenarioHandler{Hoverfly: &HoverflyScenariosStub{}}
+
+	request, err := http.NewRequest("PUT", "/api/v2/scenarios/checkout", ioutil.NopCloser(bytes.NewBuffer([]byte(`{"state":`))))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+
+	errorViewResponse, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorViewResponse.Error).To(Equal("Malformed JSON"))
+}
@@ -0,0 +1,133 @@
+package v2
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers"
+	"github.com/codegangsta/negroni"
+	"github.com/go-zoo/bone"
+)
+
+const sessionsPath = "/api/v2/sessions/"
+
+type HoverflySessions interface {
+	GetSessions() []string
+	CreateSession(name string) error
+	DeleteSession(name string) error
+	GetSessionMux(name string) (http.Handler, bool)
+}
+
+type SessionHandler struct {
+	Hoverfly HoverflySessions
+}
+
+func (this *SessionHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler) {
+	mux.Get("/api/v2/sessions", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Get),
+	))
+	mux.Post("/api/v2/sessions", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Post),
+	))
+	mux.Options("/api/v2/sessions", negroni.New(
+		negroni.HandlerFunc(this.Options),
+	))
+
+	mux.Delete("/api/v2/sessions/:session", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Delete),
+	))
+	mux.Options("/api/v2/sessions/:session", negroni.New(
+		negroni.HandlerFunc(this.OptionsSession),
+	))
+
+	scoped := negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Scoped),
+	)
+	mux.Get("/api/v2/sessions/:session/*", scoped)
+	mux.Put("/api/v2/sessions/:session/*", scoped)
+	mux.Post("/api/v2/sessions/:session/*", scoped)
+	mux.Patch("/api/v2/sessions/:session/*", scoped)
+	mux.Delete("/api/v2/sessions/:session/*", scoped)
+	mux.Options("/api/v2/sessions/:session/*", scoped)
+}
+
+func (this *SessionHandler) Get(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	marshal, _ := json.Marshal(SessionsView{Sessions: this.Hoverfly.GetSessions()})
+
+	handlers.WriteResponse(w, marshal)
+}
+
+func (this *SessionHandler) Post(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	var sessionView SessionView
+
+	body, _ := ioutil.ReadAll(req.Body)
+	if err := json.Unmarshal(body, &sessionView); err != nil {
+		handlers.WriteErrorResponse(w, "Malformed JSON", http.StatusBadRequest)
+		return
+	}
+
+	if err := this.Hoverfly.CreateSession(sessionView.Name); err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	this.Get(w, req, next)
+}
+
+func (this *SessionHandler) Delete(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	name, _ := splitSessionPath(req.URL.Path)
+
+	if err := this.Hoverfly.DeleteSession(name); err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	this.Get(w, req, next)
+}
+
+// Scoped serves a request to /api/v2/sessions/{name}/... with the handler which would
+// serve /api/v2/..., but working with the simulation, state and journal of the session
+func (this *SessionHandler) Scoped(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	name, path := splitSessionPath(req.URL.Path)
+
+	mux, ok := this.Hoverfly.GetSessionMux(name)
+	if !ok {
+		handlers.WriteErrorResponse(w, "Session "+name+" does not exist", http.StatusNotFound)
+		return
+	}
+
+	sessionRequest := *req
+	sessionURL := *req.URL
+	sessionURL.Path = "/api/v2" + path
+	sessionRequest.URL = &sessionURL
+
+	mux.ServeHTTP(w, &sessionRequest)
+}
+
+func (this *SessionHandler) Options(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, GET, POST")
+	handlers.WriteResponse(w, []byte(""))
+}
+
+func (this *SessionHandler) OptionsSession(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, DELETE")
+	handlers.WriteResponse(w, []byte(""))
+}
+
+// splitSessionPath splits /api/v2/sessions/{name}/rest into the name of the session and /rest
+func splitSessionPath(path string) (string, string) {
+	name := strings.TrimPrefix(path, sessionsPath)
+
+	index := strings.Index(name, "/")
+	if index == -1 {
+		return name, ""
+	}
+
+	return name[:index], name[index:]
+}
@@ -0,0 +1,167 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers"
+	"github.com/go-zoo/bone"
+	. "github.com/onsi/gomega"
+)
+
+type HoverflySessionsStub struct {
+	Sessions []string
+	Scenario map[string]ScenarioView
+	Error    error
+}
+
+func (this *HoverflySessionsStub) GetSessions() []string {
+	return this.Sessions
+}
+
+func (this *HoverflySessionsStub) CreateSession(name string) error {
+	if this.Error != nil {
+		return this.Error
+	}
+
+	this.Sessions = append(this.Sessions, name)
+
+	return nil
+}
+
+func (this *HoverflySessionsStub) DeleteSession(name string) error {
+	if this.Error != nil {
+		return this.Error
+	}
+
+	this.Sessions = []string{}
+
+	return nil
+}
+
+func (this *HoverflySessionsStub) GetSessionMux(name string) (http.Handler, bool) {
+	scenario, ok := this.Scenario[name]
+	if !ok {
+		return nil, false
+	}
+
+	mux := bone.New()
+	scenarioHandler := &ScenarioHandler{Hoverfly: &HoverflyScenariosStub{Scenario: scenario}}
+	scenarioHandler.RegisterRoutes(mux, &handlers.AuthHandler{})
+
+	return mux, true
+}
+
+func Test_SessionHandler_Get_ReturnsTheSessions(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SessionHandler{Hoverfly: &HoverflySessionsStub{Sessions: []string{"team-a", "team-b"}}}
+
+	request, err := http.NewRequest("GET", "/api/v2/sessions", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Body.String()).To(MatchJSON(`{"sessions": ["team-a", "team-b"]}`))
+}
+
+func Test_SessionHandler_Post_CreatesASession(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySessionsStub{Sessions: []string{}}
+	unit := SessionHandler{Hoverfly: stubHoverfly}
+
+	body, _ := json.Marshal(SessionView{Name: "team-a"})
+	request, err := http.NewRequest("POST", "/api/v2/sessions", ioutil.NopCloser(bytes.NewBuffer(body)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.Sessions).To(Equal([]string{"team-a"}))
+	Expect(response.Body.String()).To(MatchJSON(`{"sessions": ["team-a"]}`))
+}
+
+func Test_SessionHandler_Post_ReturnsErrorWhenTheSessionCannotBeCreated(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SessionHandler{Hoverfly: &HoverflySessionsStub{Error: errors.New("Session team-a already exists")}}
+
+	request, err := http.NewRequest("POST", "/api/v2/sessions", ioutil.NopCloser(bytes.NewBufferString(`{"name": "team-a"}`)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(Equal("Session team-a already exists"))
+}
+
+func Test_SessionHandler_Post_ReturnsErrorWhenJsonIsMalformed(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SessionHandler{Hoverfly: &HoverflySessionsStub{}}
+
+	request, err := http.NewRequest("POST", "/api/v2/sessions", ioutil.NopCloser(bytes.NewBufferString(`{"name":`)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(Equal("Malformed JSON"))
+}
+
+func Test_SessionHandler_Delete_ReturnsNotFoundWhenTheSessionDoesNotExist(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SessionHandler{Hoverfly: &HoverflySessionsStub{Error: errors.New("Session team-a does not exist")}}
+
+	request, err := http.NewRequest("DELETE", "/api/v2/sessions/team-a", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Delete, request)
+	Expect(response.Code).To(Equal(http.StatusNotFound))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(Equal("Session team-a does not exist"))
+}
+
+func Test_SessionHandler_Scoped_UsesTheHandlersOfTheSession(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SessionHandler{Hoverfly: &HoverflySessionsStub{
+		Scenario: map[string]ScenarioView{
+			"team-a": {State: map[string]string{"basket": "full"}},
+		},
+	}}
+
+	request, err := http.NewRequest("GET", "/api/v2/sessions/team-a/scenarios/checkout", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Scoped, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Body.String()).To(MatchJSON(`{"state": {"basket": "full"}}`))
+}
+
+func Test_SessionHandler_Scoped_ReturnsNotFoundWhenTheSessionDoesNotExist(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SessionHandler{Hoverfly: &HoverflySessionsStub{}}
+
+	request, err := http.NewRequest("GET", "/api/v2/sessions/team-a/state", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Scoped, request)
+	Expect(response.Code).To(Equal(http.StatusNotFound))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(Equal("Session team-a does not exist"))
+}
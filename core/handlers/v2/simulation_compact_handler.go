@@ -0,0 +1,41 @@
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers"
+	"github.com/codegangsta/negroni"
+	"github.com/go-zoo/bone"
+)
+
+type HoverflySimulationCompact interface {
+	CompactSimulation(dryRun bool) SimulationCompactView
+}
+
+type SimulationCompactHandler struct {
+	Hoverfly HoverflySimulationCompact
+}
+
+func (this *SimulationCompactHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler) {
+	mux.Post("/api/v2/simulation/compact", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Post),
+	))
+	mux.Options("/api/v2/simulation/compact", negroni.New(
+		negroni.HandlerFunc(this.Options),
+	))
+}
+
+func (this *SimulationCompactHandler) Post(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	dryRun := req.URL.Query().Get("dryRun") == "true"
+
+	bytes, _ := json.Marshal(this.Hoverfly.CompactSimulation(dryRun))
+
+	handlers.WriteResponse(w, bytes)
+}
+
+func (this *SimulationCompactHandler) Options(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, POST")
+	handlers.WriteResponse(w, []byte(""))
+}
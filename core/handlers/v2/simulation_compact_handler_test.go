@@ -0,0 +1,75 @@
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+type HoverflySimulationCompactStub struct {
+	DryRun bool
+}
+
+func (this *HoverflySimulationCompactStub) CompactSimulation(dryRun bool) SimulationCompactView {
+	this.DryRun = dryRun
+
+	return SimulationCompactView{
+		DryRun:      dryRun,
+		PairsBefore: 3,
+		PairsAfter:  1,
+		Merges: []CompactedPairsView{
+			{
+				Pair: RequestMatcherResponsePairViewV5{
+					RequestMatcher: RequestMatcherViewV5{
+						Path: []MatcherViewV5{
+							{
+								Matcher: "glob",
+								Value:   "/users/*",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func Test_SimulationCompactHandler_Post_CompactsTheSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationCompactStub{}
+	unit := SimulationCompactHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("POST", "/api/v2/simulation/compact", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.DryRun).To(BeFalse())
+
+	var compactView SimulationCompactView
+	Expect(json.Unmarshal(response.Body.Bytes(), &compactView)).To(Succeed())
+	Expect(compactView.PairsBefore).To(Equal(3))
+	Expect(compactView.PairsAfter).To(Equal(1))
+	Expect(compactView.Merges[0].Pair.RequestMatcher.Path[0].Value).To(Equal("/users/*"))
+}
+
+func Test_SimulationCompactHandler_Post_CanBeADryRun(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationCompactStub{}
+	unit := SimulationCompactHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("POST", "/api/v2/simulation/compact?dryRun=true", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.DryRun).To(BeTrue())
+
+	var compactView SimulationCompactView
+	Expect(json.Unmarshal(response.Body.Bytes(), &compactView)).To(Succeed())
+	Expect(compactView.DryRun).To(BeTrue())
+}
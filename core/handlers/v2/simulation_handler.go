@@ -106,7 +106,7 @@ func (this *SimulationHandler) Options(w http.ResponseWriter, r *http.Request, n
 }
 
 func (this *SimulationHandler) GetSchema(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
-	bytes, _ := json.Marshal(SimulationViewV5Schema)
+	bytes, _ := json.Marshal(SimulationViewV51Schema)
 
 	handlers.WriteResponse(w, bytes)
 }
@@ -118,6 +118,12 @@ func (this *SimulationHandler) OptionsSchema(w http.ResponseWriter, r *http.Requ
 func (this *SimulationHandler) addSimulation(w http.ResponseWriter, req *http.Request, overrideExisting bool) error {
 	body, _ := ioutil.ReadAll(req.Body)
 
+	body, err := readSimulationRequestBody(body, req.Header.Get("Content-Type"))
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return err
+	}
+
 	simulationView, err := NewSimulationViewFromRequestBody(body)
 	if err != nil {
 		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
@@ -146,3 +152,17 @@ func (this *SimulationHandler) addSimulation(w http.ResponseWriter, req *http.Re
 	}
 	return nil
 }
+
+// readSimulationRequestBody converts a simulation sent as YAML into JSON. As the simulation
+// was not read from a file, it cannot load bodies from other files.
+func readSimulationRequestBody(body []byte, contentType string) ([]byte, error) {
+	if IsYAMLContentType(contentType) {
+		var err error
+		body, err = ConvertYAMLToJSON(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ResolveBodyFiles(body, "")
+}
@@ -426,6 +426,76 @@ func TestSimulationHandler_Put_ReturnsErrorIfJsonIsNotValid(t *testing.T) {
 	Expect(errorView.Error).To(Equal("Invalid JSON"))
 }
 
+func TestSimulationHandler_Put_AcceptsYAML(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{}
+
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("PUT", "", ioutil.NopCloser(bytes.NewBufferString(`
+data:
+  pairs:
+  - request:
+      path:
+      - matcher: exact
+        value: /yaml
+    response:
+      status: 200
+      body: |
+        first line
+        second line
+meta:
+  schemaVersion: v5
+`)))
+	Expect(err).To(BeNil())
+	request.Header.Set("Content-Type", "application/x-yaml")
+
+	response := makeRequestOnHandler(unit.Put, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	Expect(stubHoverfly.Simulation.RequestResponsePairs[0].RequestMatcher.Path[0].Value).To(Equal("/yaml"))
+	Expect(stubHoverfly.Simulation.RequestResponsePairs[0].Response.Body).To(Equal("first line\nsecond line\n"))
+}
+
+func TestSimulationHandler_Put_ReturnsErrorIfYAMLDoesntMatchSchema(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SimulationHandler{Hoverfly: &HoverflySimulationErrorStub{}}
+
+	request, err := http.NewRequest("PUT", "", ioutil.NopCloser(bytes.NewBufferString("meta:\n  schemaVersion: v5\n")))
+	Expect(err).To(BeNil())
+	request.Header.Set("Content-Type", "application/x-yaml")
+
+	response := makeRequestOnHandler(unit.Put, request)
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+	Expect(errorView.Error).To(Equal("Invalid v5 simulation: [Error for <data>: data is required]"))
+}
+
+func TestSimulationHandler_Put_ReturnsErrorIfBodyFileIsUsed(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SimulationHandler{Hoverfly: &HoverflySimulationErrorStub{}}
+
+	request, err := http.NewRequest("PUT", "", ioutil.NopCloser(bytes.NewBufferString(`{
+		"data": {"pairs": [{"request": {}, "response": {"status": 200, "bodyFile": "body.json"}}]},
+		"meta": {"schemaVersion": "v5"}
+	}`)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+	Expect(errorView.Error).To(Equal("data.pairs[0].response.bodyFile can only be used in a simulation imported from a file"))
+}
+
 func TestSimulationHandler_Put_ReturnsWarnings(t *testing.T) {
 	RegisterTestingT(t)
 
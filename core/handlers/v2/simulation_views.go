@@ -22,6 +22,15 @@ func NewSimulationViewFromRequestBody(responseBody []byte) (SimulationViewV5, er
 		return SimulationViewV5{}, errors.New("Invalid JSON")
 	}
 
+	if IsHar(jsonMap) {
+		harView, err := NewHarViewFromRequestBody(responseBody)
+		if err != nil {
+			return SimulationViewV5{}, err
+		}
+
+		return NewSimulationViewFromHar(harView)
+	}
+
 	if jsonMap["meta"] == nil {
 		return SimulationViewV5{}, errors.New("Invalid JSON, missing \"meta\" object")
 	}
@@ -32,16 +41,40 @@ func NewSimulationViewFromRequestBody(responseBody []byte) (SimulationViewV5, er
 
 	schemaVersion := jsonMap["meta"].(map[string]interface{})["schemaVersion"].(string)
 
-	if schemaVersion == "v5" {
-		err := ValidateSimulation(jsonMap, SimulationViewV5Schema)
+	if schemaVersion == "v5.1" {
+		err := ValidateSimulation(jsonMap, SimulationViewV51Schema)
 		if err != nil {
-			return simulationView, errors.New(fmt.Sprintf("Invalid %s simulation: ", schemaVersion) + err.Error())
+			return simulationView, errors.New("Invalid v5.1 simulation: " + err.Error())
 		}
 
 		err = json.Unmarshal(responseBody, &simulationView)
 		if err != nil {
 			return SimulationViewV5{}, err
 		}
+
+		err = ValidateMatcherConfig(simulationView)
+		if err != nil {
+			return SimulationViewV5{}, errors.New("Invalid v5.1 simulation: " + err.Error())
+		}
+	} else if schemaVersion == "v5" {
+		err := ValidateSimulation(jsonMap, SimulationViewV5Schema)
+		if err != nil {
+			return simulationView, errors.New("Invalid v5 simulation: " + err.Error())
+		}
+
+		var simulationViewV5 SimulationViewV5
+
+		err = json.Unmarshal(responseBody, &simulationViewV5)
+		if err != nil {
+			return SimulationViewV5{}, err
+		}
+
+		err = ValidateMatcherConfig(simulationViewV5)
+		if err != nil {
+			return SimulationViewV5{}, errors.New("Invalid v5 simulation: " + err.Error())
+		}
+
+		simulationView = upgradeV5(simulationViewV5)
 	} else if schemaVersion == "v4" || schemaVersion == "v3" {
 		err := ValidateSimulation(jsonMap, SimulationViewV4Schema)
 		if err != nil {
@@ -130,7 +163,7 @@ type MetaView struct {
 func NewMetaView(version string) *MetaView {
 	return &MetaView{
 		HoverflyVersion: version,
-		SchemaVersion:   "v5",
+		SchemaVersion:   "v5.1",
 		TimeExported:    time.Now().Format(time.RFC3339),
 	}
 }
@@ -169,8 +202,12 @@ type SimulationImportWarning struct {
 	DocsLink string `json:"documentation,omitempty"`
 }
 
+// AddError keeps the first error which is added, as later errors are
+// often caused by it
 func (s *SimulationImportResult) AddError(err error) {
-	s.err = err
+	if s.err == nil {
+		s.err = err
+	}
 }
 
 func (s SimulationImportResult) GetError() error {
@@ -291,6 +291,349 @@ func Test_NewSimulationViewFromRequestBody_ReturnErrorMessagesOnInvalidSimulatio
 	Expect(simulation.GlobalActions.Delays).To(HaveLen(0))
 }
 
+func Test_NewSimulationViewFromRequestBody_CanCreateSimulationWithMatcherConfig(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation, err := v2.NewSimulationViewFromRequestBody([]byte(`{
+	"data": {
+		"pairs": [
+			{
+				"request": {
+					"path": [
+						{
+							"matcher": "exact",
+							"value": "/health",
+							"config": {
+								"negate": true,
+								"ignoreCase": true
+							}
+						}
+					]
+				},
+				"response": {
+					"status": 200
+				}
+			}
+		]
+	},
+	"meta": {
+		"schemaVersion": "v5"
+	}
+}`))
+
+	Expect(err).To(BeNil())
+	Expect(simulation.RequestResponsePairs).To(HaveLen(1))
+	Expect(simulation.RequestResponsePairs[0].RequestMatcher.Path[0].Config).To(Equal(map[string]interface{}{
+		"negate":     true,
+		"ignoreCase": true,
+	}))
+}
+
+func Test_NewSimulationViewFromRequestBody_WontCreateSimulationWithUnknownMatcherConfig(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := v2.NewSimulationViewFromRequestBody([]byte(`{
+	"data": {
+		"pairs": [
+			{
+				"request": {
+					"path": [
+						{
+							"matcher": "exact",
+							"value": "/health",
+							"config": {
+								"reverse": true
+							}
+						}
+					]
+				},
+				"response": {
+					"status": 200
+				}
+			}
+		]
+	},
+	"meta": {
+		"schemaVersion": "v5"
+	}
+}`))
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Invalid v5 simulation: [Error for <reverse>: Additional property reverse is not allowed]"))
+}
+
+func Test_NewSimulationViewFromRequestBody_WontCreateSimulationWithConfigUnsupportedByMatcher(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := v2.NewSimulationViewFromRequestBody([]byte(`{
+	"data": {
+		"pairs": [
+			{
+				"request": {
+					"headers": {
+						"Content-Type": [
+							{
+								"matcher": "xpath",
+								"value": "/list",
+								"config": {
+									"ignoreCase": true
+								}
+							}
+						]
+					}
+				},
+				"response": {
+					"status": 200
+				}
+			}
+		]
+	},
+	"meta": {
+		"schemaVersion": "v5"
+	}
+}`))
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Invalid v5 simulation: Error for <data.pairs.0.request.headers.Content-Type>: xpath matcher does not support config option ignoreCase"))
+}
+
+func Test_NewSimulationViewFromRequestBody_CanCreateSimulationWithResponseDelaysAndFaults(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation, err := v2.NewSimulationViewFromRequestBody([]byte(`{
+	"data": {
+		"pairs": [
+			{
+				"request": {
+					"path": [
+						{
+							"matcher": "exact",
+							"value": "/slow"
+						}
+					]
+				},
+				"response": {
+					"status": 200,
+					"body": "slow",
+					"fixedDelay": 1000,
+					"logNormalDelay": {
+						"min": 100,
+						"max": 5000,
+						"mean": 500,
+						"median": 200
+					},
+					"fault": {
+						"type": "truncatedBody",
+						"truncateAt": 2
+					}
+				}
+			}
+		]
+	},
+	"meta": {
+		"schemaVersion": "v5.1"
+	}
+}`))
+
+	Expect(err).To(BeNil())
+	Expect(simulation.RequestResponsePairs).To(HaveLen(1))
+
+	response := simulation.RequestResponsePairs[0].Response
+	Expect(response.FixedDelay).To(Equal(1000))
+	Expect(*response.LogNormalDelay).To(Equal(v2.LogNormalDelayView{
+		Min:    100,
+		Max:    5000,
+		Mean:   500,
+		Median: 200,
+	}))
+	Expect(*response.Fault).To(Equal(v2.ResponseFaultView{
+		Type:       "truncatedBody",
+		TruncateAt: 2,
+	}))
+}
+
+func Test_NewSimulationViewFromRequestBody_CanCreateV5SimulationWithFieldsNotInTheSchema(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation, err := v2.NewSimulationViewFromRequestBody([]byte(`{
+	"data": {
+		"pairs": [
+			{
+				"description": "health check",
+				"request": {},
+				"response": {
+					"status": 200,
+					"comment": "always up"
+				}
+			}
+		]
+	},
+	"meta": {
+		"schemaVersion": "v5"
+	}
+}`))
+
+	Expect(err).To(BeNil())
+	Expect(simulation.RequestResponsePairs).To(HaveLen(1))
+	Expect(simulation.RequestResponsePairs[0].Response.Status).To(Equal(200))
+}
+
+func Test_NewSimulationViewFromRequestBody_WontCreateV51SimulationWithFieldsNotInTheSchema(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := v2.NewSimulationViewFromRequestBody([]byte(`{
+	"data": {
+		"pairs": [
+			{
+				"request": {},
+				"response": {
+					"status": 200,
+					"comment": "always up"
+				}
+			}
+		]
+	},
+	"meta": {
+		"schemaVersion": "v5.1"
+	}
+}`))
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Invalid v5.1 simulation: [Error for <comment>: Additional property comment is not allowed]"))
+}
+
+func Test_NewSimulationViewFromRequestBody_WontCreateSimulationWithUnknownFault(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := v2.NewSimulationViewFromRequestBody([]byte(`{
+	"data": {
+		"pairs": [
+			{
+				"request": {},
+				"response": {
+					"status": 200,
+					"fault": {
+						"type": "explode"
+					}
+				}
+			}
+		]
+	},
+	"meta": {
+		"schemaVersion": "v5.1"
+	}
+}`))
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal(`Invalid v5.1 simulation: [Error for <data.pairs.0.response.fault.type>: data.pairs.0.response.fault.type must be one of the following: "connectionReset", "emptyResponse", "truncatedBody", "slowBody"]`))
+}
+
+func Test_NewSimulationViewFromRequestBody_WontCreateSimulationWithNegativeFixedDelay(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := v2.NewSimulationViewFromRequestBody([]byte(`{
+	"data": {
+		"pairs": [
+			{
+				"request": {},
+				"response": {
+					"status": 200,
+					"fixedDelay": -1
+				}
+			}
+		]
+	},
+	"meta": {
+		"schemaVersion": "v5.1"
+	}
+}`))
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Invalid v5.1 simulation: [Error for <data.pairs.0.response.fixedDelay>: Must be greater than or equal to 0]"))
+}
+
+func Test_NewSimulationViewFromRequestBody_CanCreateSimulationWithSequenceOfResponses(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation, err := v2.NewSimulationViewFromRequestBody([]byte(`{
+	"data": {
+		"pairs": [
+			{
+				"request": {},
+				"responses": [
+					{
+						"status": 503,
+						"body": "unavailable"
+					},
+					{
+						"status": 200,
+						"body": "ok"
+					}
+				],
+				"responsesMode": "cycle"
+			}
+		]
+	},
+	"meta": {
+		"schemaVersion": "v5.1"
+	}
+}`))
+
+	Expect(err).To(BeNil())
+	Expect(simulation.RequestResponsePairs).To(HaveLen(1))
+
+	pair := simulation.RequestResponsePairs[0]
+	Expect(pair.ResponsesMode).To(Equal("cycle"))
+	Expect(pair.Responses).To(HaveLen(2))
+	Expect(pair.Responses[0].Status).To(Equal(503))
+	Expect(pair.Responses[1].Body).To(Equal("ok"))
+}
+
+func Test_NewSimulationViewFromRequestBody_WontCreateSimulationWithUnknownResponsesMode(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := v2.NewSimulationViewFromRequestBody([]byte(`{
+	"data": {
+		"pairs": [
+			{
+				"request": {},
+				"responses": [
+					{
+						"status": 200
+					}
+				],
+				"responsesMode": "shuffle"
+			}
+		]
+	},
+	"meta": {
+		"schemaVersion": "v5.1"
+	}
+}`))
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("data.pairs.0.responsesMode"))
+}
+
+func Test_NewSimulationViewFromRequestBody_WontCreateSimulationWithoutAResponse(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := v2.NewSimulationViewFromRequestBody([]byte(`{
+	"data": {
+		"pairs": [
+			{
+				"request": {}
+			}
+		]
+	},
+	"meta": {
+		"schemaVersion": "v5.1"
+	}
+}`))
+
+	Expect(err).ToNot(BeNil())
+}
+
 func Test_NewSimulationViewFromRequestBody_WontCreateSimulationFromUnknownSchemaVersion(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -298,6 +298,15 @@ func upgradeV4(originalSimulation SimulationViewV4) SimulationViewV5 {
 	}
 }
 
+// upgradeV5 only has to update the schema version, as v5.1 adds new fields
+// without changing any of the fields in v5
+func upgradeV5(originalSimulation SimulationViewV5) SimulationViewV5 {
+	return SimulationViewV5{
+		originalSimulation.DataViewV5,
+		newMetaView(originalSimulation.MetaView),
+	}
+}
+
 func getMatchersFromRequestHeaders(headers map[string][]string) map[string][]MatcherViewV5 {
 	requestHeaders := map[string][]MatcherViewV5{}
 	for headerKey, headerValues := range headers {
@@ -362,7 +371,7 @@ func v2GetMatchersFromRequestFieldMatchersView(requestFieldMatchers *RequestFiel
 
 func newMetaView(originalMeta MetaView) MetaView {
 	return MetaView{
-		SchemaVersion:   "v5",
+		SchemaVersion:   "v5.1",
 		HoverflyVersion: originalMeta.HoverflyVersion,
 		TimeExported:    originalMeta.TimeExported,
 	}
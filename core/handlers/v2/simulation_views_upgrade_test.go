@@ -93,7 +93,7 @@ func Test_upgradeV1_ReturnsAnUpgradedSimulation(t *testing.T) {
 	Expect(upgradedSimulation.RequestResponsePairs[0].Response.EncodedBody).To(BeFalse())
 	Expect(upgradedSimulation.RequestResponsePairs[0].Response.Headers).To(HaveKeyWithValue("Test", []string{"headers"}))
 
-	Expect(upgradedSimulation.SchemaVersion).To(Equal("v5"))
+	Expect(upgradedSimulation.SchemaVersion).To(Equal("v5.1"))
 	Expect(upgradedSimulation.HoverflyVersion).To(Equal("test"))
 	Expect(upgradedSimulation.TimeExported).To(Equal("today"))
 }
@@ -311,7 +311,7 @@ func Test_upgradeV2_ReturnsAnUpgradedSimulation(t *testing.T) {
 	Expect(upgradedSimulation.RequestResponsePairs[0].Response.EncodedBody).To(BeFalse())
 	Expect(upgradedSimulation.RequestResponsePairs[0].Response.Headers).To(HaveKeyWithValue("Test", []string{"headers"}))
 
-	Expect(upgradedSimulation.SchemaVersion).To(Equal("v5"))
+	Expect(upgradedSimulation.SchemaVersion).To(Equal("v5.1"))
 	Expect(upgradedSimulation.HoverflyVersion).To(Equal("test"))
 	Expect(upgradedSimulation.TimeExported).To(Equal("today"))
 }
@@ -534,7 +534,7 @@ func Test_upgradeV4_ReturnsAnUpgradedSimulation(t *testing.T) {
 	Expect(upgradedSimulation.RequestResponsePairs[0].Response.EncodedBody).To(BeFalse())
 	Expect(upgradedSimulation.RequestResponsePairs[0].Response.Headers).To(HaveKeyWithValue("Test", []string{"headers"}))
 
-	Expect(upgradedSimulation.SchemaVersion).To(Equal("v5"))
+	Expect(upgradedSimulation.SchemaVersion).To(Equal("v5.1"))
 	Expect(upgradedSimulation.HoverflyVersion).To(Equal("test"))
 	Expect(upgradedSimulation.TimeExported).To(Equal("today"))
 }
@@ -846,3 +846,41 @@ func Test_upgradeV4_HandlesNewQueries(t *testing.T) {
 	Expect((*upgradedSimulation.RequestResponsePairs[0].RequestMatcher.Query)["test"][1].Matcher).To(Equal("glob"))
 	Expect((*upgradedSimulation.RequestResponsePairs[0].RequestMatcher.Query)["test"][1].Value).To(Equal("testglob"))
 }
+
+func Test_upgradeV5_ReturnsAnUpgradedSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	v5Simulation := SimulationViewV5{
+		DataViewV5{
+			RequestResponsePairs: []RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: RequestMatcherViewV5{
+						Path: []MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/path",
+							},
+						},
+					},
+					Response: ResponseDetailsViewV5{
+						Status: 200,
+						Body:   "body",
+					},
+				},
+			},
+		},
+		MetaView{
+			SchemaVersion:   "v5",
+			HoverflyVersion: "test",
+			TimeExported:    "today",
+		},
+	}
+
+	upgradedSimulation := upgradeV5(v5Simulation)
+
+	Expect(upgradedSimulation.RequestResponsePairs).To(Equal(v5Simulation.RequestResponsePairs))
+
+	Expect(upgradedSimulation.SchemaVersion).To(Equal("v5.1"))
+	Expect(upgradedSimulation.HoverflyVersion).To(Equal("test"))
+	Expect(upgradedSimulation.TimeExported).To(Equal("today"))
+}
@@ -1,6 +1,8 @@
 package v2
 
 import (
+	"encoding/json"
+
 	"github.com/SpectoLabs/hoverfly/core/interfaces"
 )
 
@@ -12,11 +14,14 @@ type SimulationViewV5 struct {
 type DataViewV5 struct {
 	RequestResponsePairs []RequestMatcherResponsePairViewV5 `json:"pairs"`
 	GlobalActions        GlobalActionsView                  `json:"globalActions"`
+	Schemas              map[string]interface{}             `json:"schemas,omitempty"`
 }
 
 type RequestMatcherResponsePairViewV5 struct {
-	RequestMatcher RequestMatcherViewV5  `json:"request"`
-	Response       ResponseDetailsViewV5 `json:"response"`
+	RequestMatcher RequestMatcherViewV5    `json:"request"`
+	Response       ResponseDetailsViewV5   `json:"response"`
+	Responses      []ResponseDetailsViewV5 `json:"responses,omitempty"`
+	ResponsesMode  string                  `json:"responsesMode,omitempty"`
 }
 
 // RequestDetailsView is used when marshalling and unmarshalling RequestDetails
@@ -50,14 +55,51 @@ func NewMatcherView(matcher string, value interface{}) MatcherViewV5 {
 //Gets Response - required for interfaces.RequestResponsePairView
 func (this RequestMatcherResponsePairViewV5) GetResponse() interfaces.Response { return this.Response }
 
+// MarshalJSON leaves the response out of a pair with a sequence of responses, as its response
+// is only a copy of the first of them
+func (this RequestMatcherResponsePairViewV5) MarshalJSON() ([]byte, error) {
+	type pairView RequestMatcherResponsePairViewV5
+	if this.Responses == nil {
+		return json.Marshal(pairView(this))
+	}
+
+	return json.Marshal(struct {
+		pairView
+		Response *ResponseDetailsViewV5 `json:"response,omitempty"`
+	}{
+		pairView: pairView(this),
+	})
+}
+
 type ResponseDetailsViewV5 struct {
 	Status           int                 `json:"status"`
 	Body             string              `json:"body"`
 	EncodedBody      bool                `json:"encodedBody"`
 	Headers          map[string][]string `json:"headers,omitempty"`
 	Templated        bool                `json:"templated"`
+	StatusTemplate   string              `json:"statusTemplate,omitempty"`
+	TemplateSeed     *int64              `json:"templateSeed,omitempty"`
+	Javascript       string              `json:"javascript,omitempty"`
 	TransitionsState map[string]string   `json:"transitionsState,omitempty"`
 	RemovesState     []string            `json:"removesState,omitempty"`
+	FixedDelay       int                 `json:"fixedDelay,omitempty"`
+	LogNormalDelay   *LogNormalDelayView `json:"logNormalDelay,omitempty"`
+	Fault            *ResponseFaultView  `json:"fault,omitempty"`
+}
+
+// LogNormalDelayView is the log-normal distribution a response delay is drawn from, in milliseconds
+type LogNormalDelayView struct {
+	Min    int `json:"min"`
+	Max    int `json:"max"`
+	Mean   int `json:"mean"`
+	Median int `json:"median"`
+}
+
+// ResponseFaultView describes how a response should misbehave when it is sent to the client
+type ResponseFaultView struct {
+	Type       string `json:"type"`
+	TruncateAt int    `json:"truncateAt,omitempty"`
+	ByteDelay  int    `json:"byteDelay,omitempty"`
 }
 
 //Gets Status - required for interfaces.Response
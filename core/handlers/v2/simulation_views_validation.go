@@ -1,5 +1,12 @@
 package v2
 
+import (
+	"fmt"
+	"sort"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+)
+
 var requestResponsePairDefinition = map[string]interface{}{
 	"type": "object",
 	"required": []string{
@@ -468,6 +475,12 @@ var SimulationViewV5Schema = map[string]interface{}{
 						},
 					},
 				},
+				"schemas": map[string]interface{}{
+					"type": "object",
+					"additionalProperties": map[string]interface{}{
+						"type": "object",
+					},
+				},
 			},
 		},
 		"meta": map[string]interface{}{
@@ -488,6 +501,106 @@ var SimulationViewV5Schema = map[string]interface{}{
 	},
 }
 
+// V5.1 Schema
+
+var SimulationViewV51Schema = map[string]interface{}{
+	"description": "Hoverfly simulation schema",
+	"type":        "object",
+	"required": []string{
+		"data", "meta",
+	},
+	"additionalProperties": false,
+	"properties": map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"pairs": map[string]interface{}{
+					"type": "array",
+					"items": map[string]interface{}{
+						"$ref": "#/definitions/request-response-pair",
+					},
+				},
+				"globalActions": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"delays": map[string]interface{}{
+							"type": "array",
+							"items": map[string]interface{}{
+								"$ref": "#/definitions/delay",
+							},
+						},
+						"delaysLogNormal": map[string]interface{}{
+							"type": "array",
+							"items": map[string]interface{}{
+								"$ref": "#/definitions/delay-log-normal",
+							},
+						},
+					},
+				},
+				"schemas": map[string]interface{}{
+					"type": "object",
+					"additionalProperties": map[string]interface{}{
+						"type": "object",
+					},
+				},
+			},
+		},
+		"meta": map[string]interface{}{
+			"$ref": "#/definitions/meta",
+		},
+	},
+	"definitions": map[string]interface{}{
+		"request-response-pair": requestResponsePairDefinitionV51,
+		"request":               requestV5Definition,
+		"response":              responseDefinitionV51,
+		"field-matchers":        requestFieldMatchersV5Definition,
+		"headers":               headersDefinition,
+		"request-headers":       v5MatchersMapDefinition,
+		"request-queries":       v5MatchersMapDefinition,
+		"delay":                 delaysDefinition,
+		"delay-log-normal":      delaysLogNormalDefinition,
+		"meta":                  metaDefinition,
+	},
+}
+
+// A v5.1 pair can have a sequence of responses, instead of or as well as one response
+// The v5.1 pair and response are closed, so that a misspelt field is rejected
+// rather than ignored
+var requestResponsePairDefinitionV51 = map[string]interface{}{
+	"type": "object",
+	"required": []string{
+		"request",
+	},
+	"additionalProperties": false,
+	"anyOf": []interface{}{
+		map[string]interface{}{
+			"required": []string{"response"},
+		},
+		map[string]interface{}{
+			"required": []string{"responses"},
+		},
+	},
+	"properties": map[string]interface{}{
+		"request": map[string]interface{}{
+			"$ref": "#/definitions/request",
+		},
+		"response": map[string]interface{}{
+			"$ref": "#/definitions/response",
+		},
+		"responses": map[string]interface{}{
+			"type":     "array",
+			"minItems": 1,
+			"items": map[string]interface{}{
+				"$ref": "#/definitions/response",
+			},
+		},
+		"responsesMode": map[string]interface{}{
+			"type": "string",
+			"enum": []string{"cycle", "stickAtLast", "failAfterExhausted"},
+		},
+	},
+}
+
 var requestV5Definition = map[string]interface{}{
 	"type": "object",
 	"properties": map[string]interface{}{
@@ -530,6 +643,94 @@ var requestV5Definition = map[string]interface{}{
 	},
 }
 
+var responseDefinitionV51 = map[string]interface{}{
+	"type":                 "object",
+	"additionalProperties": false,
+	"properties": map[string]interface{}{
+		"body": map[string]interface{}{
+			"type": "string",
+		},
+		"encodedBody": map[string]interface{}{
+			"type": "boolean",
+		},
+		"headers": map[string]interface{}{
+			"$ref": "#/definitions/headers",
+		},
+		"status": map[string]interface{}{
+			"type": "integer",
+		},
+		"statusTemplate": map[string]interface{}{
+			"type": "string",
+		},
+		"templated": map[string]interface{}{
+			"type": "boolean",
+		},
+		"templateSeed": map[string]interface{}{
+			"type": "integer",
+		},
+		"javascript": map[string]interface{}{
+			"type": "string",
+		},
+		"removesState": map[string]interface{}{
+			"type": "array",
+		},
+		"transitionsState": map[string]interface{}{
+			"type": "object",
+			"patternProperties": map[string]interface{}{
+				".{1,}": map[string]interface{}{"type": "string"},
+			},
+		},
+		"fixedDelay": map[string]interface{}{
+			"type":    "integer",
+			"minimum": 0,
+		},
+		"logNormalDelay": map[string]interface{}{
+			"type": "object",
+			"required": []string{
+				"mean", "median",
+			},
+			"properties": map[string]interface{}{
+				"min": map[string]interface{}{
+					"type": "integer",
+				},
+				"max": map[string]interface{}{
+					"type": "integer",
+				},
+				"mean": map[string]interface{}{
+					"type": "integer",
+				},
+				"median": map[string]interface{}{
+					"type": "integer",
+				},
+			},
+			"additionalProperties": false,
+		},
+		"fault": map[string]interface{}{
+			"type": "object",
+			"required": []string{
+				"type",
+			},
+			"properties": map[string]interface{}{
+				"type": map[string]interface{}{
+					"type": "string",
+					"enum": []string{
+						"connectionReset", "emptyResponse", "truncatedBody", "slowBody",
+					},
+				},
+				"truncateAt": map[string]interface{}{
+					"type":    "integer",
+					"minimum": 0,
+				},
+				"byteDelay": map[string]interface{}{
+					"type":    "integer",
+					"minimum": 0,
+				},
+			},
+			"additionalProperties": false,
+		},
+	},
+}
+
 var requestFieldMatchersV5Definition = map[string]interface{}{
 	"type": "object",
 	"properties": map[string]interface{}{
@@ -537,6 +738,24 @@ var requestFieldMatchersV5Definition = map[string]interface{}{
 			"type": "string",
 		},
 		"value": map[string]interface{}{},
+		"config": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"ignoreCase": map[string]interface{}{
+					"type": "boolean",
+				},
+				"negate": map[string]interface{}{
+					"type": "boolean",
+				},
+				"ignoreWhitespace": map[string]interface{}{
+					"type": "boolean",
+				},
+				"ignoreUnknownFields": map[string]interface{}{
+					"type": "boolean",
+				},
+			},
+			"additionalProperties": false,
+		},
 	},
 }
 
@@ -549,3 +768,45 @@ var v5MatchersMapDefinition = map[string]interface{}{
 		},
 	},
 }
+
+// ValidateMatcherConfig returns an error for the first matcher in the simulation
+// which has a config option that the matcher does not support
+func ValidateMatcherConfig(simulation SimulationViewV5) error {
+	for i, pair := range simulation.RequestResponsePairs {
+		request := pair.RequestMatcher
+		fields := map[string][]MatcherViewV5{
+			"path":            request.Path,
+			"method":          request.Method,
+			"destination":     request.Destination,
+			"scheme":          request.Scheme,
+			"body":            request.Body,
+			"deprecatedQuery": request.DeprecatedQuery,
+		}
+		for key, headerMatchers := range request.Headers {
+			fields["headers."+key] = headerMatchers
+		}
+		if request.Query != nil {
+			for key, queryMatchers := range *request.Query {
+				fields["query."+key] = queryMatchers
+			}
+		}
+
+		fieldNames := []string{}
+		for fieldName := range fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+
+		for _, fieldName := range fieldNames {
+			for _, matcher := range fields[fieldName] {
+				for option := range matcher.Config {
+					if !matchers.IsConfigSupported(matcher.Matcher, option) {
+						return fmt.Errorf("Error for <data.pairs.%v.request.%s>: %s matcher does not support config option %s", i, fieldName, matcher.Matcher, option)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
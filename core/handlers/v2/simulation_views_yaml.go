@@ -0,0 +1,200 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// IsYAMLFile checks whether a simulation file is written in YAML, based on its extension
+func IsYAMLFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// IsYAMLContentType checks whether the content type of a request is YAML, such as
+// application/x-yaml or text/yaml
+func IsYAMLContentType(contentType string) bool {
+	return strings.Contains(strings.ToLower(contentType), "yaml")
+}
+
+// ConvertYAMLToJSON converts a simulation written in YAML into the same simulation in JSON,
+// so that it can be validated and read like any other simulation
+func ConvertYAMLToJSON(data []byte) ([]byte, error) {
+	var simulation interface{}
+	if err := yaml.Unmarshal(data, &simulation); err != nil {
+		return nil, fmt.Errorf("Invalid YAML: %s", err.Error())
+	}
+
+	simulation, err := convertYAMLValue(simulation)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(simulation)
+}
+
+// ConvertJSONToYAML converts a simulation in JSON into YAML, keeping the order of its fields.
+// Strings with more than one line, such as most bodies, are written as literal blocks.
+func ConvertJSONToYAML(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	simulation, err := decodeOrderedJSON(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(simulation)
+}
+
+// ResolveBodyFiles replaces the bodyFile of each response in a simulation with the contents of
+// the file it refers to. Relative paths are read from dir. When dir is empty, the simulation
+// was not read from a file, so a bodyFile cannot be used.
+func ResolveBodyFiles(data []byte, dir string) ([]byte, error) {
+	if !bytes.Contains(data, []byte(`"bodyFile"`)) {
+		return data, nil
+	}
+
+	var simulation map[string]interface{}
+	if err := json.Unmarshal(data, &simulation); err != nil {
+		return data, nil
+	}
+
+	simulationData, _ := simulation["data"].(map[string]interface{})
+	pairs, _ := simulationData["pairs"].([]interface{})
+
+	for i, pair := range pairs {
+		pair, _ := pair.(map[string]interface{})
+
+		if response, ok := pair["response"].(map[string]interface{}); ok {
+			if err := resolveBodyFile(response, dir, fmt.Sprintf("data.pairs[%v].response", i)); err != nil {
+				return nil, err
+			}
+		}
+
+		responses, _ := pair["responses"].([]interface{})
+		for j, response := range responses {
+			if response, ok := response.(map[string]interface{}); ok {
+				if err := resolveBodyFile(response, dir, fmt.Sprintf("data.pairs[%v].responses[%v]", i, j)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return json.Marshal(simulation)
+}
+
+func resolveBodyFile(response map[string]interface{}, dir, field string) error {
+	bodyFile, ok := response["bodyFile"]
+	if !ok {
+		return nil
+	}
+
+	path, ok := bodyFile.(string)
+	if !ok || path == "" {
+		return fmt.Errorf("%s.bodyFile must be the path to a file", field)
+	}
+
+	if dir == "" {
+		return fmt.Errorf("%s.bodyFile can only be used in a simulation imported from a file", field)
+	}
+
+	if _, ok := response["body"]; ok {
+		return fmt.Errorf("%s cannot have both a body and a bodyFile", field)
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	body, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%s.bodyFile could not be read: %s", field, err.Error())
+	}
+
+	delete(response, "bodyFile")
+	response["body"] = string(body)
+
+	return nil
+}
+
+// convertYAMLValue turns the maps read from YAML, which can have keys of any type,
+// into maps with string keys which can be written as JSON
+func convertYAMLValue(value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		converted := map[string]interface{}{}
+		for key, item := range value {
+			item, err := convertYAMLValue(item)
+			if err != nil {
+				return nil, err
+			}
+			converted[fmt.Sprint(key)] = item
+		}
+		return converted, nil
+	case []interface{}:
+		converted := make([]interface{}, len(value))
+		for i, item := range value {
+			item, err := convertYAMLValue(item)
+			if err != nil {
+				return nil, err
+			}
+			converted[i] = item
+		}
+		return converted, nil
+	default:
+		return value, nil
+	}
+}
+
+func decodeOrderedJSON(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token := token.(type) {
+	case json.Delim:
+		if token == '{' {
+			mapSlice := yaml.MapSlice{}
+			for decoder.More() {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := decodeOrderedJSON(decoder)
+				if err != nil {
+					return nil, err
+				}
+				mapSlice = append(mapSlice, yaml.MapItem{Key: key, Value: value})
+			}
+			_, err := decoder.Token()
+			return mapSlice, err
+		}
+
+		slice := []interface{}{}
+		for decoder.More() {
+			value, err := decodeOrderedJSON(decoder)
+			if err != nil {
+				return nil, err
+			}
+			slice = append(slice, value)
+		}
+		_, err := decoder.Token()
+		return slice, err
+	case json.Number:
+		if number, err := token.Int64(); err == nil {
+			return number, nil
+		}
+		return token.Float64()
+	default:
+		return token, nil
+	}
+}
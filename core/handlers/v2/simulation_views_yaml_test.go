@@ -0,0 +1,181 @@
+package v2
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func Test_IsYAMLFile(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(IsYAMLFile("simulation.yaml")).To(BeTrue())
+	Expect(IsYAMLFile("simulation.YML")).To(BeTrue())
+	Expect(IsYAMLFile("simulation.json")).To(BeFalse())
+}
+
+func Test_IsYAMLContentType(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(IsYAMLContentType("application/x-yaml")).To(BeTrue())
+	Expect(IsYAMLContentType("text/yaml; charset=utf-8")).To(BeTrue())
+	Expect(IsYAMLContentType("application/json")).To(BeFalse())
+}
+
+func Test_ConvertYAMLToJSON_ConvertsASimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	converted, err := ConvertYAMLToJSON([]byte(`
+data:
+  pairs:
+  - request:
+      path:
+      - matcher: exact
+        value: /path
+    response:
+      status: 200
+      body: |-
+        {
+          "hello": "world"
+        }
+      headers:
+        Content-Type:
+        - application/json
+meta:
+  schemaVersion: v5
+`))
+	Expect(err).To(BeNil())
+
+	Expect(converted).To(MatchJSON(`{
+		"data": {
+			"pairs": [{
+				"request": {
+					"path": [{"matcher": "exact", "value": "/path"}]
+				},
+				"response": {
+					"status": 200,
+					"body": "{\n  \"hello\": \"world\"\n}",
+					"headers": {"Content-Type": ["application/json"]}
+				}
+			}]
+		},
+		"meta": {"schemaVersion": "v5"}
+	}`))
+}
+
+func Test_ConvertYAMLToJSON_ReturnsErrorIfYAMLIsNotValid(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := ConvertYAMLToJSON([]byte("data: [pairs"))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Invalid YAML"))
+}
+
+func Test_ConvertJSONToYAML_KeepsTheOrderOfFieldsAndUsesLiteralBlocks(t *testing.T) {
+	RegisterTestingT(t)
+
+	converted, err := ConvertJSONToYAML([]byte(`{
+		"data": {
+			"pairs": [{
+				"request": {"path": [{"matcher": "exact", "value": "/path"}]},
+				"response": {"status": 200, "body": "first line\nsecond line", "templated": false}
+			}]
+		},
+		"meta": {"schemaVersion": "v5", "hoverflyVersion": "v1.1.0"}
+	}`))
+	Expect(err).To(BeNil())
+
+	Expect(string(converted)).To(Equal(`data:
+  pairs:
+  - request:
+      path:
+      - matcher: exact
+        value: /path
+    response:
+      status: 200
+      body: |-
+        first line
+        second line
+      templated: false
+meta:
+  schemaVersion: v5
+  hoverflyVersion: v1.1.0
+`))
+}
+
+func Test_ConvertJSONToYAML_CanBeConvertedBack(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := `{"data": {"pairs": [{"request": {}, "response": {"status": 200, "body": "yes", "fixedDelay": 1.5}}]}, "meta": {"schemaVersion": "v5.1"}}`
+
+	converted, err := ConvertJSONToYAML([]byte(simulation))
+	Expect(err).To(BeNil())
+
+	converted, err = ConvertYAMLToJSON(converted)
+	Expect(err).To(BeNil())
+	Expect(converted).To(MatchJSON(simulation))
+}
+
+func Test_ResolveBodyFiles_LoadsBodiesFromFiles(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "hoverfly-body-file")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	Expect(ioutil.WriteFile(filepath.Join(dir, "body.json"), []byte(`{"hello": "world"}`), 0644)).To(Succeed())
+
+	resolved, err := ResolveBodyFiles([]byte(`{
+		"data": {
+			"pairs": [{
+				"request": {},
+				"response": {"status": 200, "bodyFile": "body.json"}
+			}, {
+				"request": {},
+				"responses": [{"status": 200, "body": "first"}, {"status": 200, "bodyFile": "body.json"}]
+			}]
+		}
+	}`), dir)
+	Expect(err).To(BeNil())
+
+	Expect(resolved).To(MatchJSON(`{
+		"data": {
+			"pairs": [{
+				"request": {},
+				"response": {"status": 200, "body": "{\"hello\": \"world\"}"}
+			}, {
+				"request": {},
+				"responses": [{"status": 200, "body": "first"}, {"status": 200, "body": "{\"hello\": \"world\"}"}]
+			}]
+		}
+	}`))
+}
+
+func Test_ResolveBodyFiles_LeavesSimulationsWithoutBodyFilesAlone(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := []byte(`{"data": {"pairs": [{"request": {}, "response": {"body": "<html>"}}]}}`)
+
+	resolved, err := ResolveBodyFiles(simulation, "")
+	Expect(err).To(BeNil())
+	Expect(resolved).To(Equal(simulation))
+}
+
+func Test_ResolveBodyFiles_ReturnsErrorWhenBodyIsAlsoSet(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := ResolveBodyFiles([]byte(`{"data": {"pairs": [{"request": {}, "response": {"body": "body", "bodyFile": "body.json"}}]}}`), "/tmp")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("data.pairs[0].response cannot have both a body and a bodyFile"))
+}
+
+func Test_ResolveBodyFiles_ReturnsErrorWhenTheFileCannotBeRead(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := ResolveBodyFiles([]byte(`{"data": {"pairs": [{"request": {}, "response": {"bodyFile": "missing.json"}}]}}`), "/does/not/exist")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("data.pairs[0].response.bodyFile could not be read"))
+}
@@ -1,6 +1,8 @@
 package v2
 
 import (
+	"fmt"
+
 	"github.com/SpectoLabs/hoverfly/core/metrics"
 )
 
@@ -13,9 +15,28 @@ type UsageView struct {
 }
 
 type MiddlewareView struct {
-	Binary string `json:"binary"`
-	Script string `json:"script"`
-	Remote string `json:"remote"`
+	Binary     string `json:"binary"`
+	Script     string `json:"script"`
+	Remote     string `json:"remote"`
+	Grpc       string `json:"grpc,omitempty"`
+	Javascript string `json:"javascript,omitempty"`
+	Workers    int    `json:"workers,omitempty"`
+	Timeout    int    `json:"timeout,omitempty"`
+}
+
+// MiddlewareChainView is an ordered list of middleware, where each stage is given the
+// pair returned by the stage before it
+type MiddlewareChainView struct {
+	Stages []MiddlewareStageView `json:"stages"`
+}
+
+// MiddlewareStageView is middleware in a chain, along with the phase it is run in and
+// the modes and destinations it is limited to
+type MiddlewareStageView struct {
+	Phase       string   `json:"phase"`
+	Modes       []string `json:"modes,omitempty"`
+	Destination string   `json:"destination,omitempty"`
+	MiddlewareView
 }
 
 type CORSView struct {
@@ -34,9 +55,26 @@ type ModeView struct {
 }
 
 type ModeArgumentsView struct {
-	Headers          []string `json:"headersWhitelist,omitempty"`
-	MatchingStrategy *string  `json:"matchingStrategy,omitempty"`
-	Stateful         bool     `json:"stateful,omitempty"`
+	Headers          []string       `json:"headersWhitelist,omitempty"`
+	MatchingStrategy *string        `json:"matchingStrategy,omitempty"`
+	Stateful         bool           `json:"stateful,omitempty"`
+	PathMatcher      string         `json:"pathMatcher,omitempty"`
+	BodyMatcher      string         `json:"bodyMatcher,omitempty"`
+	IgnoreJsonPaths  []string       `json:"ignoreJsonPaths,omitempty"`
+	IgnoreQueryKeys  []string       `json:"ignoreQueryKeys,omitempty"`
+	DiffRules        []DiffRuleView `json:"diffRules,omitempty"`
+}
+
+// DiffRuleView changes how diff mode compares the values at a JSON path, or at an
+// XPath for XML bodies. Each rule either ignores the values, compares numbers within
+// a tolerance, compares arrays ignoring their order or matches the actual value
+// against a regular expression.
+type DiffRuleView struct {
+	Path      string  `json:"path"`
+	Ignore    bool    `json:"ignore,omitempty"`
+	Tolerance float64 `json:"tolerance,omitempty"`
+	Unordered bool    `json:"unordered,omitempty"`
+	Regex     string  `json:"regex,omitempty"`
 }
 
 type IsWebServerView struct {
@@ -51,6 +89,22 @@ type UpstreamProxyView struct {
 	UpstreamProxy string `json:"upstreamProxy"`
 }
 
+type TemplatingView struct {
+	Seed *int64 `json:"seed"`
+}
+
+type SimulationCompactView struct {
+	DryRun      bool                 `json:"dryRun"`
+	PairsBefore int                  `json:"pairsBefore"`
+	PairsAfter  int                  `json:"pairsAfter"`
+	Merges      []CompactedPairsView `json:"merges"`
+}
+
+type CompactedPairsView struct {
+	Pair     RequestMatcherResponsePairViewV5   `json:"pair"`
+	Replaces []RequestMatcherResponsePairViewV5 `json:"replaces"`
+}
+
 type HoverflyView struct {
 	CORSView 		`json:"cors"`
 	DestinationView
@@ -81,6 +135,7 @@ type ClosestMissView struct {
 	Response       ResponseDetailsViewV5 `json:"response"`
 	RequestMatcher RequestMatcherViewV5  `json:"requestMatcher"`
 	MissedFields   []string              `json:"missedFields"`
+	SchemaErrors   []string              `json:"schemaErrors,omitempty"`
 }
 
 type JournalView struct {
@@ -102,10 +157,128 @@ type JournalEntryFilterView struct {
 	Request *RequestMatcherViewV5 `json:"request"`
 }
 
+type JournalVerifyView struct {
+	Request *RequestMatcherViewV5 `json:"request"`
+	Count   *CountConstraintView  `json:"count,omitempty"`
+}
+
+// CountConstraintView is how many journal entries are expected to match. When no
+// constraint is given, at least one entry is expected to match.
+type CountConstraintView struct {
+	Exactly *int `json:"exactly,omitempty"`
+	AtLeast *int `json:"atLeast,omitempty"`
+	AtMost  *int `json:"atMost,omitempty"`
+	Never   bool `json:"never,omitempty"`
+}
+
+// GetRange returns the smallest and largest number of entries which are expected to match,
+// with a largest number of -1 meaning there is no upper limit
+func (this *CountConstraintView) GetRange() (int, int, error) {
+	if this == nil {
+		return 1, -1, nil
+	}
+
+	names := []string{"exactly", "atLeast", "atMost"}
+	for i, value := range []*int{this.Exactly, this.AtLeast, this.AtMost} {
+		if value != nil && *value < 0 {
+			return 0, 0, fmt.Errorf("count %s must not be negative", names[i])
+		}
+	}
+
+	if this.Never {
+		if this.Exactly != nil || this.AtLeast != nil || this.AtMost != nil {
+			return 0, 0, fmt.Errorf("count never cannot be combined with other count constraints")
+		}
+		return 0, 0, nil
+	}
+
+	if this.Exactly != nil {
+		if this.AtLeast != nil || this.AtMost != nil {
+			return 0, 0, fmt.Errorf("count exactly cannot be combined with atLeast or atMost")
+		}
+		return *this.Exactly, *this.Exactly, nil
+	}
+
+	min, max := 1, -1
+	if this.AtLeast != nil {
+		min = *this.AtLeast
+	} else if this.AtMost != nil {
+		min = 0
+	}
+	if this.AtMost != nil {
+		max = *this.AtMost
+		if max < min {
+			return 0, 0, fmt.Errorf("count atLeast cannot be greater than atMost")
+		}
+	}
+
+	return min, max, nil
+}
+
+// String describes the constraint, i.e. "exactly 2 times"
+func (this *CountConstraintView) String() string {
+	min, max, err := this.GetRange()
+	if err != nil {
+		return "an invalid number of times"
+	}
+
+	switch {
+	case min == 0 && max == 0:
+		return "never"
+	case min == max:
+		return fmt.Sprintf("exactly %v %s", min, pluralTimes(min))
+	case max == -1:
+		return fmt.Sprintf("at least %v %s", min, pluralTimes(min))
+	case min == 0:
+		return fmt.Sprintf("at most %v %s", max, pluralTimes(max))
+	default:
+		return fmt.Sprintf("between %v and %v times", min, max)
+	}
+}
+
+func pluralTimes(count int) string {
+	if count == 1 {
+		return "time"
+	}
+	return "times"
+}
+
+type JournalVerifyResultView struct {
+	Passed         bool                      `json:"passed"`
+	Message        string                    `json:"message"`
+	Count          int                       `json:"count"`
+	Matches        []JournalEntryView        `json:"matches"`
+	ClosestEntries []JournalClosestEntryView `json:"closestEntries,omitempty"`
+}
+
+type JournalClosestEntryView struct {
+	Entry        JournalEntryView `json:"entry"`
+	MissedFields []string         `json:"missedFields"`
+}
+
 type StateView struct {
 	State map[string]string `json:"state"`
 }
 
+type ScenarioView struct {
+	State       map[string]string `json:"state"`
+	States      []string          `json:"states,omitempty"`
+	Transitions []TransitionView  `json:"transitions,omitempty"`
+}
+
+type TransitionView struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type SessionsView struct {
+	Sessions []string `json:"sessions"`
+}
+
+type SessionView struct {
+	Name string `json:"name"`
+}
+
 type DiffView struct {
 	Diff []ResponseDiffForRequestView `json:"diff"`
 }
@@ -27,6 +27,7 @@ type Hoverfly struct {
 	HTTP    *http.Client
 	Cfg     *Configuration
 	Counter *metrics.CounterByMode
+	Metrics *metrics.ProxyMetrics
 
 	Proxy   *goproxy.ProxyHttpServer
 	SL      *StoppableListener
@@ -42,7 +43,15 @@ type Hoverfly struct {
 	Journal       *journal.Journal
 	templator     *templating.Templator
 
-	responsesDiff map[v2.SimpleRequestDefinitionView][]v2.DiffReport
+	responsesDiff   map[v2.SimpleRequestDefinitionView][]v2.DiffReport
+	diffComparisons map[v2.SimpleRequestDefinitionView]int
+	diffMutex       sync.RWMutex
+
+	modeArguments modes.ModeArguments
+
+	sessions      map[string]*Hoverfly
+	sessionsMutex sync.RWMutex
+	sessionMux    http.Handler
 }
 
 func NewHoverfly() *Hoverfly {
@@ -50,21 +59,31 @@ func NewHoverfly() *Hoverfly {
 	authBackend := backends.NewCacheBasedAuthBackend(cache.NewInMemoryCache(), cache.NewInMemoryCache())
 
 	hoverfly := &Hoverfly{
-		Simulation:     models.NewSimulation(),
-		Authentication: authBackend,
-		Counter:        metrics.NewModeCounter([]string{modes.Simulate, modes.Synthesize, modes.Modify, modes.Capture, modes.Spy, modes.Diff}),
-		StoreLogsHook:  NewStoreLogsHook(),
-		Journal:        journal.NewJournal(),
-		Cfg:            InitSettings(),
-		state:          state.NewState(),
-		templator:      templating.NewTemplator(),
-		responsesDiff:  make(map[v2.SimpleRequestDefinitionView][]v2.DiffReport),
+		Simulation:      models.NewSimulation(),
+		Authentication:  authBackend,
+		Counter:         metrics.NewModeCounter([]string{modes.Simulate, modes.Synthesize, modes.Modify, modes.Capture, modes.Spy, modes.Diff}),
+		Metrics:         metrics.NewProxyMetrics(),
+		StoreLogsHook:   NewStoreLogsHook(),
+		Journal:         journal.NewJournal(),
+		Cfg:             InitSettings(),
+		state:           state.NewState(),
+		templator:       templating.NewTemplator(),
+		responsesDiff:   make(map[v2.SimpleRequestDefinitionView][]v2.DiffReport),
+		diffComparisons: make(map[v2.SimpleRequestDefinitionView]int),
 	}
 
 	hoverfly.version = "v1.1.0"
 
 	log.AddHook(hoverfly.StoreLogsHook)
 
+	hoverfly.modeMap = newModeMap(hoverfly)
+
+	hoverfly.HTTP = GetDefaultHoverflyHTTPClient(hoverfly.Cfg.TLSVerification, hoverfly.Cfg.UpstreamProxy)
+
+	return hoverfly
+}
+
+func newModeMap(hoverfly *Hoverfly) map[string]modes.Mode {
 	modeMap := make(map[string]modes.Mode)
 
 	modeMap[modes.Capture] = &modes.CaptureMode{Hoverfly: hoverfly}
@@ -74,11 +93,7 @@ func NewHoverfly() *Hoverfly {
 	modeMap[modes.Spy] = &modes.SpyMode{Hoverfly: hoverfly}
 	modeMap[modes.Diff] = &modes.DiffMode{Hoverfly: hoverfly}
 
-	hoverfly.modeMap = modeMap
-
-	hoverfly.HTTP = GetDefaultHoverflyHTTPClient(hoverfly.Cfg.TLSVerification, hoverfly.Cfg.UpstreamProxy)
-
-	return hoverfly
+	return modeMap
 }
 
 func NewHoverflyWithConfiguration(cfg *Configuration) *Hoverfly {
@@ -165,7 +180,7 @@ func (hf *Hoverfly) StartProxy() error {
 			hf.Cfg.ProxyControlWG.Done()
 		}()
 		log.Info("serving proxy")
-		server.Handler = hf.Proxy
+		server.Handler = withResponseWriter(hf.Proxy)
 		log.Warn(server.Serve(sl))
 	}()
 
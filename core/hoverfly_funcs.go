@@ -3,14 +3,18 @@ package hoverfly
 import (
 	"github.com/aymerick/raymond"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/errors"
 	"github.com/SpectoLabs/hoverfly/core/matching"
 	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/core/middleware"
 	"github.com/SpectoLabs/hoverfly/core/models"
 	"github.com/SpectoLabs/hoverfly/core/modes"
-	"github.com/SpectoLabs/hoverfly/core/util"
+	"github.com/SpectoLabs/hoverfly/core/templating"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -43,17 +47,18 @@ func (hf *Hoverfly) DoRequest(request *http.Request) (*http.Response, error) {
 // GetResponse returns stored response from cache
 func (hf *Hoverfly) GetResponse(requestDetails models.RequestDetails) (*models.ResponseDetails, *errors.HoverflyError) {
 
-	var response models.ResponseDetails
+	var pair *models.RequestMatcherResponsePair
 	var cachedResponse *models.CachedResponse
 
 	cachedResponse, cacheErr := hf.CacheMatcher.GetCachedResponse(&requestDetails)
 
 	// Get the cached response and return if there is a miss
 	if cacheErr == nil && cachedResponse.MatchingPair == nil {
+		hf.Metrics.CountMatch(false)
 		return nil, errors.MatchingFailedError(cachedResponse.ClosestMiss)
 		// If it's cached, use that response
 	} else if cacheErr == nil {
-		response = cachedResponse.MatchingPair.Response
+		pair = cachedResponse.MatchingPair
 		//If it's not cached, perform matching to find a hit
 	} else {
 		mode := (hf.modeMap[modes.Simulate]).(*modes.SimulateMode)
@@ -76,34 +81,30 @@ func (hf *Hoverfly) GetResponse(requestDetails models.RequestDetails) (*models.R
 				"method":      requestDetails.Method,
 			}).Warn("Failed to find matching request from simulation")
 
+			hf.Metrics.CountMatch(false)
 			return nil, errors.MatchingFailedError(result.Error.ClosestMiss)
 		} else {
-			response = result.Pair.Response
+			pair = result.Pair
 		}
 	}
 
-	// Templating applies at the end, once we have loaded a response. Comes BEFORE state transitions,
-	// as we use the current state in templates
-	if response.Templated == true {
+	response, ok := pair.NextResponse()
+	if !ok {
+		hf.Metrics.CountMatch(false)
+		return nil, errors.ResponsesExhaustedError()
+	}
 
-		var template *raymond.Template
-		if cachedResponse != nil && cachedResponse.ResponseTemplate != nil {
-			template = cachedResponse.ResponseTemplate
-		} else {
-			// Parse and cache the template
-			template, _ = hf.templator.ParseTemplate(response.Body)
-			if cachedResponse != nil {
-				cachedResponse.ResponseTemplate = template
-			}
-		}
+	hf.Metrics.CountMatch(true)
 
-		responseBody, err :=  hf.templator.RenderTemplate(template, &requestDetails, hf.state.State)
+	// Parsed templates can only be kept for a pair which always has the same response
+	if pair.Responses != nil {
+		cachedResponse = nil
+	}
 
-		if err == nil {
-			response.Body = responseBody
-		} else {
-			log.Warnf("Failed to render response template: %s", err.Error())
-		}
+	// Templating applies at the end, once we have loaded a response. Comes BEFORE state transitions,
+	// as we use the current state in templates
+	if response.Templated == true {
+		hf.renderResponseTemplates(&response, cachedResponse, &requestDetails, pair.RequestMatcher.GetPathParams(requestDetails.Path))
 	}
 
 	// State transitions after we have the response
@@ -117,32 +118,96 @@ func (hf *Hoverfly) GetResponse(requestDetails models.RequestDetails) (*models.R
 	return &response, nil
 }
 
-// save gets request fingerprint, extracts request body, status code and headers, then saves it to cache
-func (hf *Hoverfly) Save(request *models.RequestDetails, response *models.ResponseDetails, headersWhitelist []string, recordSequence bool) error {
-	body := []models.RequestFieldMatchers{
-		{
-			Matcher: matchers.Exact,
-			Value:   request.Body,
-		},
+// renderResponseTemplates renders the body, headers and status of a templated response. Parsed
+// templates are kept on the cached response, so they are only parsed once. They are all rendered
+// with the same random generator, in the same order each time, so that seeded values are repeated
+// for the same request but are not repeated between the body, headers and status.
+func (hf *Hoverfly) renderResponseTemplates(response *models.ResponseDetails, cachedResponse *models.CachedResponse, requestDetails *models.RequestDetails, pathParams map[string]string) {
+	if cachedResponse == nil {
+		cachedResponse = &models.CachedResponse{}
 	}
-	contentType := util.GetContentTypeFromHeaders(request.Headers)
-	if contentType == "json" {
-		body = []models.RequestFieldMatchers{
-			{
-				Matcher: matchers.Json,
-				Value:   request.Body,
-			},
+
+	seed := response.TemplateSeed
+	if seed == nil {
+		seed = hf.Cfg.GetTemplatingSeed()
+	}
+	random := templating.NewRandom(seed, requestDetails)
+
+	state := hf.state.Copy()
+
+	if cachedResponse.ResponseTemplate == nil {
+		cachedResponse.ResponseTemplate, _ = hf.templator.ParseTemplate(response.Body)
+	}
+
+	responseBody, err := hf.templator.RenderTemplate(cachedResponse.ResponseTemplate, requestDetails, pathParams, state, random)
+	if err == nil {
+		response.Body = responseBody
+	} else {
+		log.Warnf("Failed to render response template: %s", err.Error())
+	}
+
+	if len(response.Headers) > 0 {
+		if cachedResponse.ResponseHeaderTemplates == nil {
+			cachedResponse.ResponseHeaderTemplates = map[string][]*raymond.Template{}
+			for name, values := range response.Headers {
+				for _, value := range values {
+					template, _ := hf.templator.ParseTemplate(value)
+					cachedResponse.ResponseHeaderTemplates[name] = append(cachedResponse.ResponseHeaderTemplates[name], template)
+				}
+			}
 		}
-	} else if contentType == "xml" {
-		body = []models.RequestFieldMatchers{
-			{
-				Matcher: matchers.Xml,
-				Value:   request.Body,
-			},
+
+		// The headers are copied, as the map is shared with the pair in the simulation
+		headers := map[string][]string{}
+		names := []string{}
+		for name := range response.Headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			values := response.Headers[name]
+			headers[name] = make([]string, len(values))
+			for i, value := range values {
+				headers[name][i] = value
+
+				headerValue, err := hf.templator.RenderTemplate(cachedResponse.ResponseHeaderTemplates[name][i], requestDetails, pathParams, state, random)
+				if err == nil {
+					headers[name][i] = headerValue
+				} else {
+					log.Warnf("Failed to render response header template %s: %s", name, err.Error())
+				}
+			}
+		}
+		response.Headers = headers
+	}
+
+	if response.StatusTemplate != "" {
+		if cachedResponse.ResponseStatusTemplate == nil {
+			cachedResponse.ResponseStatusTemplate, _ = hf.templator.ParseTemplate(response.StatusTemplate)
+		}
+
+		status, err := hf.templator.RenderTemplate(cachedResponse.ResponseStatusTemplate, requestDetails, pathParams, state, random)
+		if err != nil {
+			log.Warnf("Failed to render response status template: %s", err.Error())
+		} else if statusCode, err := strconv.Atoi(strings.TrimSpace(status)); err != nil || statusCode < 100 || statusCode > 999 {
+			log.Warnf("Response status template rendered an invalid status code: %s", status)
+		} else {
+			response.Status = statusCode
 		}
 	}
+}
+
+// save gets request fingerprint, extracts request body, status code and headers, then saves it to cache.
+// The mode arguments choose which request headers are saved and how the request fields are matched.
+func (hf *Hoverfly) Save(request *models.RequestDetails, response *models.ResponseDetails, modeArgs *modes.ModeArguments) error {
+	if modeArgs == nil {
+		modeArgs = &modes.ModeArguments{}
+	}
+
+	body := getCapturedBodyMatchers(request, modeArgs.BodyMatcher, modeArgs.IgnoreJsonPaths)
 
 	var headers map[string][]string
+	headersWhitelist := modeArgs.Headers
 	if headersWhitelist == nil {
 		headersWhitelist = []string{}
 	}
@@ -173,26 +238,24 @@ func (hf *Hoverfly) Save(request *models.RequestDetails, response *models.Respon
 	}
 
 	var queries *models.QueryRequestFieldMatchers
-	if len(request.Query) > 0 {
-		queries = &models.QueryRequestFieldMatchers{}
-		for key, values := range request.Query {
-			queries.Add(key, []models.RequestFieldMatchers{
-				{
-					Matcher: matchers.Exact,
-					Value:   strings.Join(values, ";"),
-				},
-			})
+	for key, values := range request.Query {
+		if containsIgnoreCase(modeArgs.IgnoreQueryKeys, key) {
+			continue
+		}
+		if queries == nil {
+			queries = &models.QueryRequestFieldMatchers{}
 		}
+		queries.Add(key, []models.RequestFieldMatchers{
+			{
+				Matcher: matchers.Exact,
+				Value:   strings.Join(values, ";"),
+			},
+		})
 	}
 
 	pair := models.RequestMatcherResponsePair{
 		RequestMatcher: models.RequestMatcher{
-			Path: []models.RequestFieldMatchers{
-				{
-					Matcher: matchers.Exact,
-					Value:   request.Path,
-				},
-			},
+			Path: getCapturedPathMatchers(request.Path, modeArgs.PathMatcher),
 			Method: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -217,7 +280,7 @@ func (hf *Hoverfly) Save(request *models.RequestDetails, response *models.Respon
 		},
 		Response: *response,
 	}
-	if recordSequence {
+	if modeArgs.Stateful {
 		hf.Simulation.AddPairInSequence(&pair, hf.state)
 	} else {
 		hf.Simulation.AddPair(&pair)
@@ -226,10 +289,37 @@ func (hf *Hoverfly) Save(request *models.RequestDetails, response *models.Respon
 	return nil
 }
 
-func (this Hoverfly) ApplyMiddleware(pair models.RequestResponsePair) (models.RequestResponsePair, error) {
-	if this.Cfg.Middleware.IsSet() {
-		return this.Cfg.Middleware.Execute(pair)
+// ApplyMiddleware runs the JavaScript of a simulated response, followed by the middleware
+// set on Hoverfly and then the stages of the middleware chain which apply to the phase.
+// Each is given access to the state. The middleware set on Hoverfly is not run on a miss,
+// as it has never been.
+func (this Hoverfly) ApplyMiddleware(pair models.RequestResponsePair, phase string) (models.RequestResponsePair, error) {
+	runMiddleware := phase != middleware.OnMiss && this.Cfg.Middleware.IsSet()
+
+	if pair.Response.Javascript == "" && !runMiddleware && len(this.Cfg.MiddlewareChain) == 0 {
+		return pair, nil
+	}
+
+	defer func(started time.Time) {
+		this.Metrics.ObserveMiddleware(time.Since(started))
+	}(time.Now())
+
+	var err error
+	if pair.Response.Javascript != "" {
+		responseMiddleware := &middleware.Middleware{Javascript: pair.Response.Javascript}
+
+		pair, err = responseMiddleware.ExecuteWithState(pair, this.state)
+		if err != nil {
+			return pair, err
+		}
+	}
+
+	if runMiddleware {
+		pair, err = this.Cfg.Middleware.ExecuteWithState(pair, this.state)
+		if err != nil {
+			return pair, err
+		}
 	}
 
-	return pair, nil
+	return this.Cfg.MiddlewareChain.Execute(phase, this.Cfg.GetMode(), pair, this.state)
 }
@@ -5,13 +5,16 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"testing"
 
 	"github.com/SpectoLabs/hoverfly/core/cache"
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/matching"
 	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/core/middleware"
 	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/modes"
 	. "github.com/onsi/gomega"
 )
 
@@ -338,6 +341,293 @@ func Test_Hoverfly_GetResponse_WillCacheTemplateIfNotInCache(t *testing.T) {
 	Expect(cachedRequestResponsePair.(*models.CachedResponse).ResponseTemplate).NotTo(BeNil())
 }
 
+func Test_Hoverfly_GetResponse_TemplatesPathParamsFromMatchedPair(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.PathTemplate,
+					Value:   "/users/{id}/orders/{orderId}",
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Status:    200,
+			Body:      "{{ Request.PathParams.id }}:{{ Request.PathParams.orderId }}",
+			Templated: true,
+		},
+	})
+
+	for i := 0; i < 2; i++ {
+		response, err := unit.GetResponse(models.RequestDetails{
+			Destination: "somehost.com",
+			Method:      "GET",
+			Path:        "/users/123/orders/456",
+		})
+
+		Expect(err).To(BeNil())
+		Expect(response.Body).To(Equal("123:456"))
+	}
+}
+
+func Test_Hoverfly_GetResponse_TemplatesNamedRegexGroupsAsPathParams(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Regex,
+					Value:   `^/users/(?P<id>\d+)$`,
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Status:    200,
+			Body:      "user {{ Request.PathParams.id }}",
+			Templated: true,
+		},
+	})
+
+	response, err := unit.GetResponse(models.RequestDetails{
+		Path: "/users/42",
+	})
+
+	Expect(err).To(BeNil())
+	Expect(response.Body).To(Equal("user 42"))
+}
+
+func Test_Hoverfly_GetResponse_UsesTheTemplateSeedOfThePairOrOfHoverfly(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	pairSeed := int64(1)
+	for _, path := range []string{"/seeded", "/unseeded"} {
+		pair := &models.RequestMatcherResponsePair{
+			RequestMatcher: models.RequestMatcher{
+				Path: []models.RequestFieldMatchers{
+					{
+						Matcher: matchers.Exact,
+						Value:   path,
+					},
+				},
+			},
+			Response: models.ResponseDetails{
+				Status:    200,
+				Body:      "{{ randomFullName }} {{ randomUuid }}",
+				Templated: true,
+			},
+		}
+		if path == "/seeded" {
+			pair.Response.TemplateSeed = &pairSeed
+		}
+		unit.Simulation.AddPair(pair)
+	}
+
+	getBody := func(path string) string {
+		response, err := unit.GetResponse(models.RequestDetails{
+			Destination: "somehost.com",
+			Method:      "GET",
+			Path:        path,
+		})
+		Expect(err).To(BeNil())
+		return response.Body
+	}
+
+	Expect(getBody("/seeded")).To(Equal(getBody("/seeded")))
+	Expect(getBody("/unseeded")).ToNot(Equal(getBody("/unseeded")))
+
+	hoverflySeed := int64(2)
+	unit.SetTemplating(v2.TemplatingView{Seed: &hoverflySeed})
+
+	Expect(getBody("/unseeded")).To(Equal(getBody("/unseeded")))
+	Expect(getBody("/seeded")).ToNot(Equal(getBody("/unseeded")))
+	Expect(unit.GetTemplating().Seed).To(Equal(&hoverflySeed))
+}
+
+func Test_Hoverfly_GetResponse_WithTemplateSeed_GivesTheBodyAndHeadersDifferentValues(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	seed := int64(1)
+	unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Destination: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "somehost.com",
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Status: 200,
+			Body:   "{{ randomUuid }}",
+			Headers: map[string][]string{
+				"X-Request-Id": {"{{ randomUuid }}"},
+				"X-Trace-Id":   {"{{ randomUuid }}"},
+			},
+			Templated:    true,
+			TemplateSeed: &seed,
+		},
+	})
+
+	getResponse := func() *models.ResponseDetails {
+		response, err := unit.GetResponse(models.RequestDetails{
+			Destination: "somehost.com",
+			Method:      "GET",
+			Path:        "/",
+		})
+		Expect(err).To(BeNil())
+		return response
+	}
+
+	first := getResponse()
+	Expect(first.Headers["X-Request-Id"][0]).ToNot(Equal(first.Body))
+	Expect(first.Headers["X-Trace-Id"][0]).ToNot(Equal(first.Body))
+	Expect(first.Headers["X-Trace-Id"][0]).ToNot(Equal(first.Headers["X-Request-Id"][0]))
+
+	second := getResponse()
+	Expect(second.Body).To(Equal(first.Body))
+	Expect(second.Headers).To(Equal(first.Headers))
+}
+
+func Test_Hoverfly_GetResponse_TemplatesHeadersAndStatus(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Destination: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "somehost.com",
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Status: 200,
+			Body:   "created",
+			Headers: map[string][]string{
+				"Location":         {"{{ Request.Scheme }}://{{ Request.Destination }}/{{ Request.Path.[0] }}/1"},
+				"X-Correlation-Id": {"{{ Request.Header.X-Correlation-Id }}"},
+			},
+			StatusTemplate: "{{ Request.QueryParam.status }}",
+			Templated:      true,
+		},
+	})
+
+	for _, status := range []int{201, 202} {
+		response, err := unit.GetResponse(models.RequestDetails{
+			Destination: "somehost.com",
+			Method:      "POST",
+			Scheme:      "http",
+			Path:        "/bookings",
+			Query: map[string][]string{
+				"status": {strconv.Itoa(status)},
+			},
+			Headers: map[string][]string{
+				"X-Correlation-Id": {"abc-123"},
+			},
+		})
+
+		Expect(err).To(BeNil())
+		Expect(response.Status).To(Equal(status))
+		Expect(response.Headers["Location"]).To(Equal([]string{"http://somehost.com/bookings/1"}))
+		Expect(response.Headers["X-Correlation-Id"]).To(Equal([]string{"abc-123"}))
+	}
+
+	Expect(unit.Simulation.GetMatchingPairs()[0].Response.Headers["X-Correlation-Id"]).To(Equal([]string{"{{ Request.Header.X-Correlation-Id }}"}))
+}
+
+func Test_Hoverfly_GetResponse_WillCacheHeaderAndStatusTemplates(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Destination: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "somehost.com",
+				},
+			},
+			Method: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "POST",
+				},
+			},
+			Scheme: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "http",
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Status: 200,
+			Headers: map[string][]string{
+				"X-Id": {"{{ randomUuid }}"},
+			},
+			StatusTemplate: "201",
+			Templated:      true,
+		},
+	})
+
+	unit.GetResponse(models.RequestDetails{
+		Destination: "somehost.com",
+		Method:      "POST",
+		Scheme:      "http",
+	})
+
+	cachedRequestResponsePair, found := unit.CacheMatcher.RequestCache.Get("75b4ae6efa2a3f6d3ee6b9fed4d8c8c5")
+	Expect(found).To(BeTrue())
+
+	cachedResponse := cachedRequestResponsePair.(*models.CachedResponse)
+	Expect(cachedResponse.ResponseHeaderTemplates["X-Id"]).To(HaveLen(1))
+	Expect(cachedResponse.ResponseHeaderTemplates["X-Id"][0]).NotTo(BeNil())
+	Expect(cachedResponse.ResponseStatusTemplate).NotTo(BeNil())
+}
+
+func Test_Hoverfly_GetResponse_KeepsStatusIfStatusTemplateIsNotANumber(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Destination: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "somehost.com",
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Status:         200,
+			StatusTemplate: "{{ Request.QueryParam.status }}",
+			Templated:      true,
+		},
+	})
+
+	response, err := unit.GetResponse(models.RequestDetails{
+		Destination: "somehost.com",
+	})
+
+	Expect(err).To(BeNil())
+	Expect(response.Status).To(Equal(200))
+}
+
 func Test_Hoverfly_GetResponse_ShouldReturnEmptyTextIfResponseTemplateIsNotRenderable(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -639,6 +929,57 @@ func Test_Hoverfly_GetResponse_TransitioningBetweenStatesWhenSimulating(t *testi
 	Expect(string(response.Body)).To(Equal(`empty`))
 }
 
+func Test_Hoverfly_GetResponse_KeepsTheStateOfEachScenarioApart(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	pair := func(path string, requiresState, transitionsState map[string]string, body string) v2.RequestMatcherResponsePairViewV5 {
+		return v2.RequestMatcherResponsePairViewV5{
+			RequestMatcher: v2.RequestMatcherViewV5{
+				Path: []v2.MatcherViewV5{
+					v2.NewMatcherView(matchers.Exact, path),
+				},
+				RequiresState: requiresState,
+			},
+			Response: v2.ResponseDetailsViewV5{
+				Status:           200,
+				Body:             body,
+				TransitionsState: transitionsState,
+			},
+		}
+	}
+
+	unit.PutSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				pair("/checkout/basket", nil, nil, "empty"),
+				pair("/checkout/basket", map[string]string{"scenario:checkout:basket": "full"}, nil, "full"),
+				pair("/checkout/add", nil, map[string]string{"scenario:checkout:basket": "full"}, "added"),
+				pair("/returns/basket", nil, nil, "empty"),
+				pair("/returns/basket", map[string]string{"scenario:returns:basket": "full"}, nil, "full"),
+			},
+		},
+	})
+
+	response, _ := unit.GetResponse(models.RequestDetails{Path: "/checkout/add"})
+	Expect(response.Body).To(Equal("added"))
+
+	response, _ = unit.GetResponse(models.RequestDetails{Path: "/checkout/basket"})
+	Expect(response.Body).To(Equal("full"))
+
+	response, _ = unit.GetResponse(models.RequestDetails{Path: "/returns/basket"})
+	Expect(response.Body).To(Equal("empty"))
+
+	Expect(unit.GetState()).To(Equal(map[string]string{"scenario:checkout:basket": "full"}))
+	Expect(unit.GetScenario("checkout").State).To(Equal(map[string]string{"basket": "full"}))
+
+	Expect(unit.SetScenario("checkout", v2.ScenarioView{})).To(Succeed())
+
+	response, _ = unit.GetResponse(models.RequestDetails{Path: "/checkout/basket"})
+	Expect(response.Body).To(Equal("empty"))
+}
+
 func Test_Hoverfly_GetResponse_GetNotRecordedRequest(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -676,7 +1017,7 @@ func Test_Hoverfly_Save_SavesRequestAndResponseToSimulation(t *testing.T) {
 		Body:    "testresponsebody",
 		Headers: map[string][]string{"testheader": {"testvalue"}},
 		Status:  200,
-	}, nil, false)
+	}, nil)
 
 	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
 
@@ -722,7 +1063,7 @@ func Test_Hoverfly_Save_SavesRequestContainsMultiValueQuery(t *testing.T) {
 		Body:    "testresponsebody",
 		Headers: map[string][]string{"testheader": {"testvalue"}},
 		Status:  200,
-	}, nil, false)
+	}, nil)
 
 	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
 
@@ -746,7 +1087,7 @@ func Test_Hoverfly_Save_DoesNotSaveRequestHeadersWhenGivenHeadersArrayIsNil(t *t
 		Body:    "testresponsebody",
 		Headers: map[string][]string{"testheader": {"testvalue"}},
 		Status:  200,
-	}, nil, false)
+	}, nil)
 
 	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Headers).To(BeEmpty())
 }
@@ -765,7 +1106,7 @@ func Test_Hoverfly_Save_SavesAllRequestHeadersWhenGivenAnAsterisk(t *testing.T)
 		Body:    "testresponsebody",
 		Headers: map[string][]string{"testheader": {"testvalue"}},
 		Status:  200,
-	}, []string{"*"}, false)
+	}, &modes.ModeArguments{Headers: []string{"*"}})
 
 	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Headers).To(HaveLen(2))
 	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Headers["testheader"]).To(HaveLen(1))
@@ -794,7 +1135,7 @@ func Test_Hoverfly_Save_SavesSpecificRequestHeadersWhenSpecifiedInHeadersArray(t
 		Body:    "testresponsebody",
 		Headers: map[string][]string{"testheader": {"testvalue"}},
 		Status:  200,
-	}, []string{"testheader"}, false)
+	}, &modes.ModeArguments{Headers: []string{"testheader"}})
 
 	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Headers).To(HaveLen(1))
 	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Headers["testheader"]).To(HaveLen(1))
@@ -818,7 +1159,7 @@ func Test_Hoverfly_Save_DoesNotSaveAnyRequestHeaderIfItDoesNotMatchEntryInHeader
 		Body:    "testresponsebody",
 		Headers: map[string][]string{"testheader": {"testvalue"}},
 		Status:  200,
-	}, []string{"nonmatch"}, false)
+	}, &modes.ModeArguments{Headers: []string{"nonmatch"}})
 
 	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Headers).To(BeEmpty())
 }
@@ -838,7 +1179,7 @@ func Test_Hoverfly_Save_SavesMultipleRequestHeadersWhenMultiplesSpecifiedInHeade
 		Body:    "testresponsebody",
 		Headers: map[string][]string{"testheader": {"testvalue"}},
 		Status:  200,
-	}, []string{"testheader", "nonmatch"}, false)
+	}, &modes.ModeArguments{Headers: []string{"testheader", "nonmatch"}})
 
 	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Headers).To(HaveLen(2))
 	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Headers["testheader"]).To(HaveLen(1))
@@ -864,7 +1205,7 @@ func Test_Hoverfly_Save_SavesIncompleteRequestAndResponseToSimulation(t *testing
 		Body:    "testresponsebody",
 		Headers: map[string][]string{"testheader": {"testvalue"}},
 		Status:  200,
-	}, nil, false)
+	}, nil)
 
 	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
 
@@ -911,7 +1252,7 @@ func Test_Hoverfly_Save_SavesRequestBodyAsJsonPathIfContentTypeIsJson(t *testing
 		Headers: map[string][]string{
 			"Content-Type": {"application/json"},
 		},
-	}, &models.ResponseDetails{}, nil, false)
+	}, &models.ResponseDetails{}, nil)
 
 	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
 
@@ -930,7 +1271,7 @@ func Test_Hoverfly_Save_SavesRequestBodyAsXmlPathIfContentTypeIsXml(t *testing.T
 		Headers: map[string][]string{
 			"Content-Type": {"application/xml"},
 		},
-	}, &models.ResponseDetails{}, nil, false)
+	}, &models.ResponseDetails{}, nil)
 
 	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
 
@@ -939,6 +1280,68 @@ func Test_Hoverfly_Save_SavesRequestBodyAsXmlPathIfContentTypeIsXml(t *testing.T
 	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Body[0].Value).To(Equal(`<xml>`))
 }
 
+func Test_Hoverfly_Save_SavesRequestBodyAsFormIfContentTypeIsFormUrlEncoded(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Save(&models.RequestDetails{
+		Body: `grant_type=client_credentials&scope=read&scope=write`,
+		Headers: map[string][]string{
+			"Content-Type": {"application/x-www-form-urlencoded"},
+		},
+	}, &models.ResponseDetails{}, nil)
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
+
+	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Body).To(HaveLen(1))
+	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Body[0].Matcher).To(Equal("form"))
+	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Body[0].Value).To(Equal(map[string][]v2.MatcherViewV5{
+		"grant_type": {v2.NewMatcherView("exact", "client_credentials")},
+		"scope":      {v2.NewMatcherView("exact", "read;write")},
+	}))
+}
+
+func Test_Hoverfly_Save_SavesRequestBodyAsMultipartIfContentTypeIsMultipartFormData(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Save(&models.RequestDetails{
+		Body: "--abc\r\nContent-Disposition: form-data; name=\"name\"\r\n\r\nKevin\r\n--abc--\r\n",
+		Headers: map[string][]string{
+			"Content-Type": {"multipart/form-data; boundary=abc"},
+		},
+	}, &models.ResponseDetails{}, nil)
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
+
+	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Body).To(HaveLen(1))
+	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Body[0].Matcher).To(Equal("multipart"))
+	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Body[0].Value).To(Equal(map[string][]v2.MatcherViewV5{
+		"name": {v2.NewMatcherView("exact", "Kevin")},
+	}))
+}
+
+func Test_Hoverfly_Save_SavesRequestBodyAsExactIfMultipartBodyIsInvalid(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Save(&models.RequestDetails{
+		Body: `not multipart`,
+		Headers: map[string][]string{
+			"Content-Type": {"multipart/form-data; boundary=abc"},
+		},
+	}, &models.ResponseDetails{}, nil)
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
+
+	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Body).To(HaveLen(1))
+	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Body[0].Matcher).To(Equal("exact"))
+	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Body[0].Value).To(Equal(`not multipart`))
+}
+
 func Test_Hoverfly_Save_CanAddPairStatefully(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -946,11 +1349,11 @@ func Test_Hoverfly_Save_CanAddPairStatefully(t *testing.T) {
 
 	unit.Save(&models.RequestDetails{
 		Body: `body`,
-	}, &models.ResponseDetails{}, nil, true)
+	}, &models.ResponseDetails{}, &modes.ModeArguments{Stateful: true})
 
 	unit.Save(&models.RequestDetails{
 		Body: `body`,
-	}, &models.ResponseDetails{}, nil, true)
+	}, &models.ResponseDetails{}, &modes.ModeArguments{Stateful: true})
 
 	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(2))
 
@@ -960,3 +1363,222 @@ func Test_Hoverfly_Save_CanAddPairStatefully(t *testing.T) {
 	Expect(unit.Simulation.GetMatchingPairs()[1].RequestMatcher.RequiresState).To(HaveLen(1))
 	Expect(unit.Simulation.GetMatchingPairs()[1].RequestMatcher.RequiresState["sequence:1"]).To(Equal("2"))
 }
+
+func Test_Hoverfly_Save_CanGlobPathSegmentsWhichLookLikeIds(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Save(&models.RequestDetails{
+		Path: "/users/123/orders/6f1c3e2a-6a2b-4a8e-9c1d-2f3e4a5b6c7d/items",
+	}, &models.ResponseDetails{}, &modes.ModeArguments{PathMatcher: "glob"})
+
+	unit.Save(&models.RequestDetails{
+		Path: "/users/me",
+	}, &models.ResponseDetails{}, &modes.ModeArguments{PathMatcher: "glob"})
+
+	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Path).To(Equal([]models.RequestFieldMatchers{
+		{
+			Matcher: matchers.Glob,
+			Value:   "/users/*/orders/*/items",
+		},
+	}))
+
+	Expect(unit.Simulation.GetMatchingPairs()[1].RequestMatcher.Path).To(Equal([]models.RequestFieldMatchers{
+		{
+			Matcher: matchers.Exact,
+			Value:   "/users/me",
+		},
+	}))
+}
+
+func Test_Hoverfly_Save_CanUseTheGivenBodyMatcher(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Save(&models.RequestDetails{
+		Body: `{"name": "hoverfly"}`,
+	}, &models.ResponseDetails{}, &modes.ModeArguments{BodyMatcher: "jsonpartial"})
+
+	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Body).To(Equal([]models.RequestFieldMatchers{
+		{
+			Matcher: matchers.JsonPartial,
+			Value:   `{"name": "hoverfly"}`,
+		},
+	}))
+}
+
+func Test_Hoverfly_Save_RemovesIgnoredJsonPathsAndMatchesTheBodyPartially(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Save(&models.RequestDetails{
+		Body:    `{"timestamp": 1, "name": "hoverfly", "items": [{"id": 1, "nonce": "a"}, {"id": 2, "nonce": "b"}], "meta": {"nonce": "c"}}`,
+		Headers: map[string][]string{"Content-Type": {"application/json"}},
+	}, &models.ResponseDetails{}, &modes.ModeArguments{
+		IgnoreJsonPaths: []string{"$.timestamp", "$.items[*].nonce", "meta.nonce", "$.missing.field"},
+	})
+
+	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Body).To(Equal([]models.RequestFieldMatchers{
+		{
+			Matcher: matchers.JsonPartial,
+			Value:   `{"items":[{"id":1},{"id":2}],"meta":{},"name":"hoverfly"}`,
+		},
+	}))
+}
+
+func Test_Hoverfly_Save_DoesNotMatchIgnoredQueryKeys(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Save(&models.RequestDetails{
+		Query: map[string][]string{
+			"page":      {"1"},
+			"timestamp": {"1234"},
+		},
+	}, &models.ResponseDetails{}, &modes.ModeArguments{IgnoreQueryKeys: []string{"Timestamp"}})
+
+	unit.Save(&models.RequestDetails{
+		Path: "/other",
+		Query: map[string][]string{
+			"timestamp": {"1234"},
+		},
+	}, &models.ResponseDetails{}, &modes.ModeArguments{IgnoreQueryKeys: []string{"timestamp"}})
+
+	Expect(*unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Query).To(Equal(models.QueryRequestFieldMatchers{
+		"page": {
+			{
+				Matcher: matchers.Exact,
+				Value:   "1",
+			},
+		},
+	}))
+	Expect(unit.Simulation.GetMatchingPairs()[1].RequestMatcher.Query).To(BeNil())
+}
+
+func Test_Hoverfly_GetResponse_ReturnsTheNextResponseOfASequence(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.PutSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Path: []v2.MatcherViewV5{
+							v2.NewMatcherView(matchers.Exact, "/flaky"),
+						},
+					},
+					Responses: []v2.ResponseDetailsViewV5{
+						{
+							Status: 503,
+						},
+						{
+							Status:    200,
+							Body:      "attempt {{ Request.QueryParam.attempt }}",
+							Templated: true,
+						},
+					},
+					ResponsesMode: models.ResponsesModeFailAfterExhausted,
+				},
+			},
+		},
+	})
+
+	response, err := unit.GetResponse(models.RequestDetails{Path: "/flaky"})
+	Expect(err).To(BeNil())
+	Expect(response.Status).To(Equal(503))
+
+	// The second request is matched from the cache
+	response, err = unit.GetResponse(models.RequestDetails{Path: "/flaky", Query: map[string][]string{"attempt": {"2"}}})
+	Expect(err).To(BeNil())
+	Expect(response.Status).To(Equal(200))
+	Expect(response.Body).To(Equal("attempt 2"))
+
+	response, err = unit.GetResponse(models.RequestDetails{Path: "/flaky"})
+	Expect(response).To(BeNil())
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Every response for the matched request has already been returned"))
+}
+
+func Test_Hoverfly_ApplyMiddleware_RunsTheJavascriptOfTheResponseWithTheState(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.state.PatchState(map[string]string{"count": "1"})
+
+	pair, err := unit.ApplyMiddleware(models.RequestResponsePair{
+		Request: models.RequestDetails{Path: "/count"},
+		Response: models.ResponseDetails{
+			Status:     200,
+			Javascript: `state.set("count", Number(state.get("count")) + 1); pair.response.body = state.get("count")`,
+		},
+	}, middleware.PostResponse)
+	Expect(err).To(BeNil())
+
+	Expect(pair.Response.Body).To(Equal("2"))
+	Expect(unit.state.Copy()["count"]).To(Equal("2"))
+}
+
+func Test_Hoverfly_ApplyMiddleware_RunsTheJavascriptOfTheResponseBeforeTheMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Cfg.Middleware.SetJavascript(`pair.response.body += " then middleware"`)
+
+	pair, err := unit.ApplyMiddleware(models.RequestResponsePair{
+		Response: models.ResponseDetails{
+			Status:     200,
+			Javascript: `pair.response.body = "response"`,
+		},
+	}, middleware.PostResponse)
+	Expect(err).To(BeNil())
+
+	Expect(pair.Response.Body).To(Equal("response then middleware"))
+}
+
+func Test_Hoverfly_ApplyMiddleware_RunsTheStagesOfTheChainForThePhaseAfterTheMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Cfg.Middleware.SetJavascript(`pair.response.body += "middleware"`)
+
+	for _, script := range []string{
+		`pair.response.body += " then first"`,
+		`pair.response.body += " never"`,
+		`pair.response.body += " then second"`,
+	} {
+		stage, _ := middleware.NewStage(middleware.PostResponse, nil, "")
+		stage.Middleware = &middleware.Middleware{}
+		stage.Middleware.SetJavascript(script)
+		unit.Cfg.MiddlewareChain = append(unit.Cfg.MiddlewareChain, stage)
+	}
+	unit.Cfg.MiddlewareChain[1].Phase = middleware.PreRequest
+
+	pair, err := unit.ApplyMiddleware(models.RequestResponsePair{}, middleware.PostResponse)
+	Expect(err).To(BeNil())
+
+	Expect(pair.Response.Body).To(Equal("middleware then first then second"))
+}
+
+func Test_Hoverfly_ApplyMiddleware_DoesNotRunTheMiddlewareOnAMiss(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Cfg.Middleware.SetJavascript(`pair.response.body = "middleware"`)
+
+	stage, _ := middleware.NewStage(middleware.OnMiss, nil, "")
+	stage.Middleware = &middleware.Middleware{}
+	stage.Middleware.SetJavascript(`pair.response.status = 404; pair.response.body += "on-miss"`)
+	unit.Cfg.MiddlewareChain = middleware.Chain{stage}
+
+	pair, err := unit.ApplyMiddleware(models.RequestResponsePair{}, middleware.OnMiss)
+	Expect(err).To(BeNil())
+
+	Expect(pair.Response.Status).To(Equal(404))
+	Expect(pair.Response.Body).To(Equal("on-miss"))
+}
@@ -1,12 +1,14 @@
 package hoverfly
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"github.com/SpectoLabs/hoverfly/core/delay"
 	"regexp"
 
 	"strings"
+	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
@@ -49,16 +51,16 @@ func (this *Hoverfly) SetMode(mode string) error {
 	})
 }
 
-func (this *Hoverfly) SetModeWithArguments(modeView v2.ModeView) error {
+var availableModes = map[string]bool{
+	modes.Simulate:   true,
+	modes.Capture:    true,
+	modes.Modify:     true,
+	modes.Synthesize: true,
+	modes.Spy:        true,
+	modes.Diff:       true,
+}
 
-	availableModes := map[string]bool{
-		modes.Simulate:   true,
-		modes.Capture:    true,
-		modes.Modify:     true,
-		modes.Synthesize: true,
-		modes.Spy:        true,
-		modes.Diff:       true,
-	}
+func (this *Hoverfly) SetModeWithArguments(modeView v2.ModeView) error {
 
 	if modeView.Mode == "" || !availableModes[modeView.Mode] {
 		log.WithFields(log.Fields{
@@ -91,22 +93,37 @@ func (this *Hoverfly) SetModeWithArguments(modeView v2.ModeView) error {
 		}
 	}
 
-	this.Cfg.SetMode(modeView.Mode)
-	if this.Cfg.GetMode() == "capture" {
-		this.CacheMatcher.FlushCache()
-	} else if this.Cfg.GetMode() == "simulate" {
-		this.CacheMatcher.PreloadCache(*this.Simulation)
-	} else if this.Cfg.GetMode() == "spy" {
-		this.CacheMatcher.PreloadCache(*this.Simulation)
+	if modeView.Mode == modes.Capture {
+		err := validateCaptureArguments(modeView.Arguments.PathMatcher, modeView.Arguments.BodyMatcher, modeView.Arguments.IgnoreJsonPaths)
+		if err != nil {
+			return err
+		}
+	}
+
+	if modeView.Mode == modes.Diff {
+		if err := modes.ValidateDiffRules(modeView.Arguments.DiffRules); err != nil {
+			return err
+		}
 	}
 
+	this.Cfg.SetMode(modeView.Mode)
+
 	modeArguments := modes.ModeArguments{
 		Headers:          modeView.Arguments.Headers,
 		MatchingStrategy: matchingStrategy,
 		Stateful:         modeView.Arguments.Stateful,
+		PathMatcher:      modeView.Arguments.PathMatcher,
+		BodyMatcher:      modeView.Arguments.BodyMatcher,
+		IgnoreJsonPaths:  modeView.Arguments.IgnoreJsonPaths,
+		IgnoreQueryKeys:  modeView.Arguments.IgnoreQueryKeys,
+		DiffRules:        modeView.Arguments.DiffRules,
 	}
 
-	this.modeMap[this.Cfg.GetMode()].SetArguments(modeArguments)
+	// Every session shares the mode, so it is changed for all of them
+	this.applyModeArguments(modeArguments)
+	for _, session := range this.getSessions() {
+		session.applyModeArguments(modeArguments)
+	}
 
 	log.WithFields(log.Fields{
 		"mode": this.Cfg.GetMode(),
@@ -115,35 +132,210 @@ func (this *Hoverfly) SetModeWithArguments(modeView v2.ModeView) error {
 	return nil
 }
 
+// applyModeArguments gets the cache ready for the current mode and gives it its arguments
+func (this *Hoverfly) applyModeArguments(modeArguments modes.ModeArguments) {
+	if this.Cfg.GetMode() == "capture" {
+		this.CacheMatcher.FlushCache()
+	} else if this.Cfg.GetMode() == "simulate" {
+		this.CacheMatcher.PreloadCache(*this.Simulation)
+	} else if this.Cfg.GetMode() == "spy" {
+		this.CacheMatcher.PreloadCache(*this.Simulation)
+	}
+
+	this.modeArguments = modeArguments
+	if mode, ok := this.modeMap[this.Cfg.GetMode()]; ok {
+		mode.SetArguments(modeArguments)
+	}
+}
+
 func (hf Hoverfly) GetMiddleware() (string, string, string) {
 	script, _ := hf.Cfg.Middleware.GetScript()
 	return hf.Cfg.Middleware.Binary, script, hf.Cfg.Middleware.Remote
 }
 
+func (hf Hoverfly) GetMiddlewareView() v2.MiddlewareView {
+	return newMiddlewareView(hf.Cfg.Middleware)
+}
+
 func (hf *Hoverfly) SetMiddleware(binary, script, remote string) error {
-	newMiddleware := &middleware.Middleware{}
-	if binary == "" && script == "" && remote == "" {
-		hf.Cfg.Middleware = *newMiddleware
+	return hf.SetMiddlewareView(v2.MiddlewareView{
+		Binary: binary,
+		Script: script,
+		Remote: remote,
+	})
+}
+
+// SetMiddlewareView sets the middleware once it has been tested. Local middleware
+// is kept running when workers is above 0 and the timeout is in milliseconds.
+func (hf *Hoverfly) SetMiddlewareView(middlewareView v2.MiddlewareView) error {
+	if middlewareView.Binary == "" && middlewareView.Script == "" && middlewareView.Remote == "" && middlewareView.Grpc == "" && middlewareView.Javascript == "" {
+		hf.Cfg.Middleware.Stop()
+		hf.Cfg.Middleware.DeleteScript()
+		hf.Cfg.Middleware = middleware.Middleware{}
 		return nil
 	}
 
-	if binary == "" && script != "" {
-		return fmt.Errorf("Cannot run script with no binary")
+	newMiddleware, err := newTestedMiddleware(middlewareView)
+	if err != nil {
+		return err
 	}
 
-	err := newMiddleware.SetBinary(binary)
+	hf.Cfg.Middleware.Stop()
+	hf.Cfg.Middleware.DeleteScript()
+	hf.Cfg.Middleware = *newMiddleware
+	return nil
+}
+
+func (hf Hoverfly) GetMiddlewareChain() v2.MiddlewareChainView {
+	chainView := v2.MiddlewareChainView{
+		Stages: []v2.MiddlewareStageView{},
+	}
+
+	for _, stage := range hf.Cfg.MiddlewareChain {
+		chainView.Stages = append(chainView.Stages, v2.MiddlewareStageView{
+			Phase:          stage.Phase,
+			Modes:          stage.Modes,
+			Destination:    stage.Destination,
+			MiddlewareView: newMiddlewareView(*stage.Middleware),
+		})
+	}
+
+	return chainView
+}
+
+// SetMiddlewareChain replaces the middleware chain once the middleware of every
+// stage has been tested. Nothing is changed if any of the stages fail.
+func (hf *Hoverfly) SetMiddlewareChain(chainView v2.MiddlewareChainView) error {
+	var newChain middleware.Chain
+
+	for i, stageView := range chainView.Stages {
+		stage, err := newMiddlewareStage(stageView)
+		if err != nil {
+			newChain.Close()
+
+			if middlewareErr, ok := err.(*middleware.MiddlewareError); ok {
+				middlewareErr.Stage = i + 1
+				middlewareErr.Phase = stageView.Phase
+				return middlewareErr
+			}
+			return fmt.Errorf("Stage %d: %s", i+1, err.Error())
+		}
+
+		newChain = append(newChain, stage)
+	}
+
+	oldChain := hf.Cfg.MiddlewareChain
+	hf.Cfg.MiddlewareChain = newChain
+	oldChain.Close()
+
+	log.WithFields(log.Fields{
+		"stages": len(newChain),
+	}).Info("Middleware chain has been set")
+
+	return nil
+}
+
+func (hf *Hoverfly) DeleteMiddlewareChain() {
+	hf.SetMiddlewareChain(v2.MiddlewareChainView{})
+}
+
+func newMiddlewareView(currentMiddleware middleware.Middleware) v2.MiddlewareView {
+	script, _ := currentMiddleware.GetScript()
+	return v2.MiddlewareView{
+		Binary:     currentMiddleware.Binary,
+		Script:     script,
+		Remote:     currentMiddleware.Remote,
+		Grpc:       currentMiddleware.Grpc,
+		Javascript: currentMiddleware.Javascript,
+		Workers:    currentMiddleware.Workers,
+		Timeout:    int(currentMiddleware.Timeout / time.Millisecond),
+	}
+}
+
+func newMiddlewareStage(stageView v2.MiddlewareStageView) (*middleware.Stage, error) {
+	for _, mode := range stageView.Modes {
+		if !availableModes[mode] {
+			return nil, fmt.Errorf("Not a valid mode: %s", mode)
+		}
+	}
+
+	stage, err := middleware.NewStage(stageView.Phase, stageView.Modes, stageView.Destination)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = newMiddleware.SetScript(script)
+	stage.Middleware, err = newTestedMiddleware(stageView.MiddlewareView)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return stage, nil
+}
+
+// newTestedMiddleware creates middleware from the view and runs it against a test
+// pair, so middleware which does not work is never used
+func newTestedMiddleware(middlewareView v2.MiddlewareView) (*middleware.Middleware, error) {
+	newMiddleware := &middleware.Middleware{}
+	if middlewareView.Binary == "" && middlewareView.Script != "" {
+		return nil, fmt.Errorf("Cannot run script with no binary")
+	}
+
+	if middlewareView.Grpc != "" && (middlewareView.Binary != "" || middlewareView.Remote != "") {
+		return nil, fmt.Errorf("Cannot use gRPC middleware with a binary or remote middleware")
+	}
+
+	if middlewareView.Javascript != "" && (middlewareView.Binary != "" || middlewareView.Remote != "" || middlewareView.Grpc != "") {
+		return nil, fmt.Errorf("Cannot use JavaScript middleware with a binary, remote or gRPC middleware")
 	}
 
-	err = newMiddleware.SetRemote(remote)
+	if middlewareView.Workers > 0 && (middlewareView.Remote != "" || middlewareView.Grpc != "") {
+		return nil, fmt.Errorf("Cannot use workers with remote middleware")
+	}
+
+	if middlewareView.Workers > 0 && middlewareView.Javascript != "" {
+		return nil, fmt.Errorf("Cannot use workers with JavaScript middleware")
+	}
+
+	err := newMiddleware.SetBinary(middlewareView.Binary)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	err = newMiddleware.SetScript(middlewareView.Script)
+	if err != nil {
+		return nil, err
+	}
+
+	err = newMiddleware.SetRemote(middlewareView.Remote)
+	if err != nil {
+		return nil, err
+	}
+
+	err = newMiddleware.SetGrpc(middlewareView.Grpc)
+	if err != nil {
+		return nil, err
+	}
+
+	err = newMiddleware.SetJavascript(middlewareView.Javascript)
+	if err != nil {
+		return nil, err
+	}
+
+	err = newMiddleware.SetTimeout(time.Duration(middlewareView.Timeout) * time.Millisecond)
+	if err != nil {
+		return nil, err
+	}
+
+	err = newMiddleware.SetWorkers(middlewareView.Workers)
+	if err != nil {
+		return nil, err
+	}
+
+	err = newMiddleware.CheckHealth()
+	if err != nil {
+		newMiddleware.Stop()
+		newMiddleware.DeleteScript()
+		return nil, err
 	}
 
 	testData := models.RequestResponsePair{
@@ -165,10 +357,12 @@ func (hf *Hoverfly) SetMiddleware(binary, script, remote string) error {
 
 	_, err = newMiddleware.Execute(testData)
 	if err != nil {
-		return err
+		newMiddleware.Stop()
+		newMiddleware.DeleteScript()
+		return nil, err
 	}
-	hf.Cfg.Middleware = *newMiddleware
-	return nil
+
+	return newMiddleware, nil
 }
 
 func (hf Hoverfly) GetRequestCacheCount() (int, error) {
@@ -244,6 +438,16 @@ func (hf Hoverfly) GetStats() metrics.Stats {
 	return hf.Counter.Flush()
 }
 
+// GetMetrics returns the proxy metrics in the Prometheus text exposition format
+func (hf *Hoverfly) GetMetrics() string {
+	hits, misses := hf.CacheMatcher.GetCacheStats()
+
+	var buffer bytes.Buffer
+	hf.Metrics.Write(&buffer, hits, misses)
+
+	return buffer.String()
+}
+
 func (hf Hoverfly) GetSimulation() (v2.SimulationViewV5, error) {
 	pairViews := make([]v2.RequestMatcherResponsePairViewV5, 0)
 
@@ -251,10 +455,13 @@ func (hf Hoverfly) GetSimulation() (v2.SimulationViewV5, error) {
 		pairViews = append(pairViews, v.BuildView())
 	}
 
-	return v2.BuildSimulationView(pairViews,
+	simulationView := v2.BuildSimulationView(pairViews,
 		hf.Simulation.ResponseDelays.ConvertToResponseDelayPayloadView(),
 		hf.Simulation.ResponseDelaysLogNormal.ConvertToResponseDelayLogNormalPayloadView(),
-		hf.version), nil
+		hf.version)
+	simulationView.Schemas = hf.getSchemasView()
+
+	return simulationView, nil
 }
 
 func (hf Hoverfly) GetFilteredSimulation(urlPattern string) (v2.SimulationViewV5, error) {
@@ -280,13 +487,27 @@ func (hf Hoverfly) GetFilteredSimulation(urlPattern string) (v2.SimulationViewV5
 		}
 	}
 
-	return v2.BuildSimulationView(pairViews,
+	simulationView := v2.BuildSimulationView(pairViews,
 		hf.Simulation.ResponseDelays.ConvertToResponseDelayPayloadView(),
 		hf.Simulation.ResponseDelaysLogNormal.ConvertToResponseDelayLogNormalPayloadView(),
-		hf.version), nil
+		hf.version)
+	simulationView.Schemas = hf.getSchemasView()
+
+	return simulationView, nil
+}
+
+func (hf Hoverfly) getSchemasView() map[string]interface{} {
+	schemas := hf.Simulation.GetSchemas()
+	if len(schemas) == 0 {
+		return nil
+	}
+
+	return schemas
 }
 
 func (this *Hoverfly) PutSimulation(simulationView v2.SimulationViewV5) v2.SimulationImportResult {
+	this.Simulation.AddSchemas(simulationView.DataViewV5.Schemas)
+
 	result := this.importRequestResponsePairViews(simulationView.DataViewV5.RequestResponsePairs)
 
 	result.AddError(this.SetResponseDelays(v1.ResponseDelayPayloadView{Data: simulationView.GlobalActions.Delays}))
@@ -297,6 +518,7 @@ func (this *Hoverfly) PutSimulation(simulationView v2.SimulationViewV5) v2.Simul
 
 func (this *Hoverfly) DeleteSimulation() {
 	this.Simulation.DeleteMatchingPairs()
+	this.Simulation.DeleteSchemas()
 	this.DeleteResponseDelays()
 	this.DeleteResponseDelaysLogNormal()
 	this.FlushCache()
@@ -316,7 +538,7 @@ func (this Hoverfly) IsWebServer() bool {
 }
 
 func (this Hoverfly) IsMiddlewareSet() bool {
-	return this.Cfg.Middleware.IsSet()
+	return this.Cfg.Middleware.IsSet() || len(this.Cfg.MiddlewareChain) > 0
 }
 
 func (this Hoverfly) GetCORS() v2.CORSView {
@@ -332,9 +554,20 @@ func (this Hoverfly) GetCORS() v2.CORSView {
 	}
 }
 
+func (this Hoverfly) GetTemplating() v2.TemplatingView {
+	return v2.TemplatingView{
+		Seed: this.Cfg.GetTemplatingSeed(),
+	}
+}
+
+func (this *Hoverfly) SetTemplating(templatingView v2.TemplatingView) {
+	this.Cfg.SetTemplatingSeed(templatingView.Seed)
+}
 
+// GetState returns a copy of the state, including the state of each scenario
+// under keys of the form scenario:<name>:<key>
 func (this *Hoverfly) GetState() map[string]string {
-	return this.state.State
+	return this.state.Copy()
 }
 
 func (this *Hoverfly) SetState(state map[string]string) {
@@ -347,17 +580,83 @@ func (this *Hoverfly) PatchState(toPatch map[string]string) {
 
 func (this *Hoverfly) ClearState() {
 	this.state = state.NewState()
+	this.Simulation.ResetResponseSequences()
 }
 
+func (this *Hoverfly) GetScenario(name string) v2.ScenarioView {
+	scenario := this.state.GetScenario(name)
+
+	scenarioView := v2.ScenarioView{
+		State:  scenario.State,
+		States: scenario.States,
+	}
+	for _, transition := range scenario.Transitions {
+		scenarioView.Transitions = append(scenarioView.Transitions, v2.TransitionView{
+			From: transition.From,
+			To:   transition.To,
+		})
+	}
+
+	return scenarioView
+}
+
+func (this *Hoverfly) SetScenario(name string, scenarioView v2.ScenarioView) error {
+	scenario := state.Scenario{
+		State:  scenarioView.State,
+		States: scenarioView.States,
+	}
+	for _, transition := range scenarioView.Transitions {
+		scenario.Transitions = append(scenario.Transitions, state.Transition{
+			From: transition.From,
+			To:   transition.To,
+		})
+	}
+
+	return this.state.SetScenario(name, scenario)
+}
+
+// GetDiff returns a copy of the reports of the responses which differed, as diffs
+// are added by proxied requests while they are being read
 func (this *Hoverfly) GetDiff() map[v2.SimpleRequestDefinitionView][]v2.DiffReport {
-	return this.responsesDiff
+	this.diffMutex.RLock()
+	defer this.diffMutex.RUnlock()
+
+	responsesDiff := make(map[v2.SimpleRequestDefinitionView][]v2.DiffReport, len(this.responsesDiff))
+	for request, diffReports := range this.responsesDiff {
+		responsesDiff[request] = append([]v2.DiffReport{}, diffReports...)
+	}
+
+	return responsesDiff
+}
+
+// GetDiffComparisons returns how many responses have been compared for each request,
+// including those which had no differences
+func (this *Hoverfly) GetDiffComparisons() map[v2.SimpleRequestDefinitionView]int {
+	this.diffMutex.RLock()
+	defer this.diffMutex.RUnlock()
+
+	diffComparisons := make(map[v2.SimpleRequestDefinitionView]int, len(this.diffComparisons))
+	for request, comparisons := range this.diffComparisons {
+		diffComparisons[request] = comparisons
+	}
+
+	return diffComparisons
 }
 
 func (this *Hoverfly) ClearDiff() {
+	this.diffMutex.Lock()
+	defer this.diffMutex.Unlock()
+
 	this.responsesDiff = make(map[v2.SimpleRequestDefinitionView][]v2.DiffReport)
+	this.diffComparisons = make(map[v2.SimpleRequestDefinitionView]int)
 }
 
 func (this *Hoverfly) AddDiff(requestView v2.SimpleRequestDefinitionView, diffReport v2.DiffReport) {
+	this.diffMutex.Lock()
+	defer this.diffMutex.Unlock()
+
+	this.diffComparisons[requestView]++
+
 	if len(diffReport.DiffEntries) > 0 {
 		diffs := this.responsesDiff[requestView]
 		this.responsesDiff[requestView] = append(diffs, diffReport)
@@ -5,7 +5,9 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
@@ -122,7 +124,7 @@ func Test_Hoverfly_GetSimulation_ReturnsBlankSimulation_ifThereIsNoData(t *testi
 	Expect(simulation.RequestResponsePairs).To(HaveLen(0))
 	Expect(simulation.GlobalActions.Delays).To(HaveLen(0))
 
-	Expect(simulation.MetaView.SchemaVersion).To(Equal("v5"))
+	Expect(simulation.MetaView.SchemaVersion).To(Equal("v5.1"))
 	Expect(simulation.MetaView.HoverflyVersion).To(MatchRegexp(`v\d+.\d+.\d+(-rc.\d)*`))
 	Expect(simulation.MetaView.TimeExported).ToNot(BeNil())
 }
@@ -419,7 +421,7 @@ func Test_Hoverfly_GetFilteredSimulation_ReturnBlankSimulation_IfThereIsNoMatch(
 	Expect(simulation.GlobalActions.Delays).To(HaveLen(0))
 	Expect(simulation.GlobalActions.DelaysLogNormal).To(HaveLen(0))
 
-	Expect(simulation.MetaView.SchemaVersion).To(Equal("v5"))
+	Expect(simulation.MetaView.SchemaVersion).To(Equal("v5.1"))
 	Expect(simulation.MetaView.HoverflyVersion).To(MatchRegexp(`v\d+.\d+.\d+(-rc.\d)*`))
 	Expect(simulation.MetaView.TimeExported).ToNot(BeNil())
 }
@@ -576,6 +578,121 @@ func Test_Hoverfly_PutSimulation_ImportsSimulationViews(t *testing.T) {
 	Expect(importedSimulation.RequestResponsePairs[0].Response.Body).To(Equal("pair2-body"))
 }
 
+func Test_Hoverfly_PutSimulation_ImportsSchemasAndResolvesJsonSchemaMatchers(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	result := unit.PutSimulation(v2.SimulationViewV5{
+		v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Body: []v2.MatcherViewV5{
+							v2.NewMatcherView("jsonschema", "user"),
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Body: "valid user",
+					},
+				},
+			},
+			Schemas: map[string]interface{}{
+				"user": map[string]interface{}{
+					"type":     "object",
+					"required": []interface{}{"name"},
+				},
+			},
+		},
+		v2.MetaView{},
+	})
+	Expect(result.GetError()).To(BeNil())
+
+	response, matchErr := unit.GetResponse(models.RequestDetails{
+		Body: `{"name": "Kevin"}`,
+	})
+	Expect(matchErr).To(BeNil())
+	Expect(response.Body).To(Equal("valid user"))
+
+	exportedSimulation, err := unit.GetSimulation()
+	Expect(err).To(BeNil())
+	Expect(exportedSimulation.Schemas).To(HaveKey("user"))
+
+	exportedBytes, err := json.Marshal(exportedSimulation.RequestResponsePairs[0].RequestMatcher.Body)
+	Expect(err).To(BeNil())
+	Expect(string(exportedBytes)).To(Equal(`[{"matcher":"jsonschema","value":"user"}]`))
+}
+
+func Test_Hoverfly_PutSimulation_ReturnsErrorWhenJsonSchemaIsNotInSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	result := unit.PutSimulation(v2.SimulationViewV5{
+		v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Body: []v2.MatcherViewV5{
+							v2.NewMatcherView("jsonschema", "user"),
+						},
+					},
+				},
+			},
+		},
+		v2.MetaView{},
+	})
+
+	Expect(result.GetError()).ToNot(BeNil())
+	Expect(result.GetError().Error()).To(Equal("data.pairs[0] is not added: JSON schema user is not in the simulation"))
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(0))
+}
+
+func Test_Hoverfly_DeleteSimulation_DeletesSchemas(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Simulation.AddSchemas(map[string]interface{}{
+		"user": map[string]interface{}{},
+	})
+
+	unit.DeleteSimulation()
+
+	Expect(unit.Simulation.GetSchemas()).To(BeEmpty())
+}
+
+func Test_Hoverfly_PutSimulation_ReturnsErrorWhenResponseLogNormalDelayIsInvalid(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	result := unit.PutSimulation(v2.SimulationViewV5{
+		v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Path: []v2.MatcherViewV5{
+							v2.NewMatcherView("exact", "/slow"),
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						LogNormalDelay: &v2.LogNormalDelayView{
+							Mean:   100,
+							Median: 200,
+						},
+					},
+				},
+			},
+		},
+		v2.MetaView{},
+	})
+
+	Expect(result.GetError()).ToNot(BeNil())
+	Expect(result.GetError().Error()).To(Equal("data.pairs[0] is not added: Config error - mean delay can't be less than median one"))
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(0))
+}
+
 func Test_Hoverfly_PutSimulation_ImportsDelays(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -795,6 +912,119 @@ func Test_Hoverfly_SetMiddleware_WillSetBinaryWithNoScript(t *testing.T) {
 	Expect(script).To(Equal(""))
 }
 
+func Test_Hoverfly_SetMiddlewareView_SetsWorkersAndTimeout(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	defer unit.Cfg.Middleware.Stop()
+
+	err := unit.SetMiddlewareView(v2.MiddlewareView{
+		Binary:  "python",
+		Script:  pythonMiddlewareWorker,
+		Workers: 2,
+		Timeout: 1500,
+	})
+	Expect(err).To(BeNil())
+
+	Expect(unit.Cfg.Middleware.Binary).To(Equal("python"))
+	Expect(unit.Cfg.Middleware.Workers).To(Equal(2))
+	Expect(unit.Cfg.Middleware.Timeout).To(Equal(1500 * time.Millisecond))
+
+	middlewareView := unit.GetMiddlewareView()
+	Expect(middlewareView.Binary).To(Equal("python"))
+	Expect(middlewareView.Script).To(Equal(pythonMiddlewareWorker))
+	Expect(middlewareView.Workers).To(Equal(2))
+	Expect(middlewareView.Timeout).To(Equal(1500))
+}
+
+func Test_Hoverfly_SetMiddlewareView_WillErrorIfGivenWorkersAndRemoteAndWillNotChangeMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err := unit.SetMiddlewareView(v2.MiddlewareView{Remote: "http://test.com", Workers: 2})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Cannot use workers with remote middleware"))
+
+	Expect(unit.Cfg.Middleware.Remote).To(Equal(""))
+	Expect(unit.Cfg.Middleware.Workers).To(Equal(0))
+}
+
+func Test_Hoverfly_SetMiddlewareView_WontSetMiddlewareIfWorkerDoesNotRespond(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err := unit.SetMiddlewareView(v2.MiddlewareView{Binary: "python", Script: pythonMiddlewareBasic, Workers: 1, Timeout: 1000})
+	Expect(err).ToNot(BeNil())
+
+	Expect(unit.Cfg.Middleware.Binary).To(Equal(""))
+	Expect(unit.Cfg.Middleware.Workers).To(Equal(0))
+}
+
+func Test_Hoverfly_SetMiddlewareView_WillErrorIfGivenGrpcAndBinaryAndWillNotChangeMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err := unit.SetMiddlewareView(v2.MiddlewareView{Binary: "python", Grpc: "grpc://localhost:50051"})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Cannot use gRPC middleware with a binary or remote middleware"))
+
+	Expect(unit.Cfg.Middleware.Grpc).To(Equal(""))
+	Expect(unit.Cfg.Middleware.Binary).To(Equal(""))
+}
+
+func Test_Hoverfly_SetMiddlewareView_WontSetGrpcMiddlewareIfItCannotBeReached(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err := unit.SetMiddlewareView(v2.MiddlewareView{Grpc: "grpc://localhost:4321", Timeout: 500})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("gRPC middleware health check failed"))
+
+	Expect(unit.Cfg.Middleware.Grpc).To(Equal(""))
+}
+
+func Test_Hoverfly_SetMiddlewareView_SetsJavascriptMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err := unit.SetMiddlewareView(v2.MiddlewareView{Javascript: `pair.response.body = "changed"`, Timeout: 500})
+	Expect(err).To(BeNil())
+
+	Expect(unit.Cfg.Middleware.Javascript).To(Equal(`pair.response.body = "changed"`))
+	Expect(unit.GetMiddlewareView().Javascript).To(Equal(`pair.response.body = "changed"`))
+	Expect(unit.GetMiddlewareView().Timeout).To(Equal(500))
+}
+
+func Test_Hoverfly_SetMiddlewareView_WontSetJavascriptMiddlewareThatFails(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err := unit.SetMiddlewareView(v2.MiddlewareView{Javascript: `pair.nothing.body = "changed"`})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("JavaScript middleware failed"))
+
+	Expect(unit.Cfg.Middleware.Javascript).To(Equal(""))
+}
+
+func Test_Hoverfly_SetMiddlewareView_WillErrorIfGivenJavascriptAndBinaryAndWillNotChangeMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err := unit.SetMiddlewareView(v2.MiddlewareView{Binary: "python", Javascript: `pair.response.body = "changed"`})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Cannot use JavaScript middleware with a binary, remote or gRPC middleware"))
+
+	Expect(unit.Cfg.Middleware.Javascript).To(Equal(""))
+	Expect(unit.Cfg.Middleware.Binary).To(Equal(""))
+}
+
 func Test_Hoverfly_GetVersion_GetsVersion(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -923,6 +1153,94 @@ func Test_Hoverfly_SetModeWithArguments_Stateful(t *testing.T) {
 	Expect(storedMode.Arguments.Stateful).To(BeTrue())
 }
 
+func Test_Hoverfly_SetModeWithArguments_CaptureMatchers(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "capture",
+		Arguments: v2.ModeArgumentsView{
+			PathMatcher:     "glob",
+			BodyMatcher:     "jsonpartial",
+			IgnoreJsonPaths: []string{"$.timestamp"},
+			IgnoreQueryKeys: []string{"nonce"},
+		},
+	})).To(Succeed())
+
+	storedMode := unit.modeMap[modes.Capture].View()
+	Expect(storedMode.Arguments.PathMatcher).To(Equal("glob"))
+	Expect(storedMode.Arguments.BodyMatcher).To(Equal("jsonpartial"))
+	Expect(storedMode.Arguments.IgnoreJsonPaths).To(ConsistOf("$.timestamp"))
+	Expect(storedMode.Arguments.IgnoreQueryKeys).To(ConsistOf("nonce"))
+}
+
+func Test_Hoverfly_SetModeWithArguments_RejectsInvalidCaptureMatchers(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "capture",
+		Arguments: v2.ModeArgumentsView{
+			PathMatcher: "regex",
+		},
+	})).To(MatchError("Only path matchers of 'exact', 'glob' are permitted"))
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "capture",
+		Arguments: v2.ModeArgumentsView{
+			BodyMatcher: "xpath",
+		},
+	})).To(MatchError("Only body matchers of 'exact', 'json', 'jsonpartial', 'xml' are permitted"))
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "capture",
+		Arguments: v2.ModeArgumentsView{
+			IgnoreJsonPaths: []string{"$"},
+		},
+	})).To(MatchError("Cannot ignore JSON path '$', it should be in the form $.field.list[0].field"))
+
+	Expect(unit.Cfg.Mode).ToNot(Equal("capture"))
+}
+
+func Test_Hoverfly_SetModeWithArguments_DiffRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "diff",
+		Arguments: v2.ModeArgumentsView{
+			DiffRules: []v2.DiffRuleView{
+				{Path: "$.timestamp", Ignore: true},
+				{Path: "/order/total", Tolerance: 0.01},
+			},
+		},
+	})).To(Succeed())
+
+	storedMode := unit.modeMap[modes.Diff].View()
+	Expect(storedMode.Arguments.DiffRules).To(ConsistOf(
+		v2.DiffRuleView{Path: "$.timestamp", Ignore: true},
+		v2.DiffRuleView{Path: "/order/total", Tolerance: 0.01},
+	))
+}
+
+func Test_Hoverfly_SetModeWithArguments_RejectsInvalidDiffRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "diff",
+		Arguments: v2.ModeArgumentsView{
+			DiffRules: []v2.DiffRuleView{{Path: "$.id", Regex: "[0-9"}},
+		},
+	})).To(MatchError(ContainSubstring("Diff rule regex '[0-9' is not valid")))
+
+	Expect(unit.Cfg.Mode).ToNot(Equal("diff"))
+}
+
 func Test_Hoverfly_SetModeWithArguments_AsteriskCanOnlyBeValidAsTheOnlyHeader(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -947,6 +1265,104 @@ func Test_Hoverfly_SetModeWithArguments_AsteriskCanOnlyBeValidAsTheOnlyHeader(t
 	})).ToNot(Succeed())
 }
 
+func Test_Hoverfly_ClearState_ResetsSequencesOfResponses(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.PutSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Path: []v2.MatcherViewV5{
+							v2.NewMatcherView(matchers.Exact, "/"),
+						},
+					},
+					Responses: []v2.ResponseDetailsViewV5{
+						{
+							Status: 503,
+						},
+						{
+							Status: 200,
+						},
+					},
+				},
+			},
+		},
+	})
+
+	unit.state.PatchState(map[string]string{"key": "value"})
+
+	response, _ := unit.GetResponse(models.RequestDetails{Path: "/"})
+	Expect(response.Status).To(Equal(503))
+	response, _ = unit.GetResponse(models.RequestDetails{Path: "/"})
+	Expect(response.Status).To(Equal(200))
+
+	unit.ClearState()
+
+	Expect(unit.GetState()).To(BeEmpty())
+
+	response, _ = unit.GetResponse(models.RequestDetails{Path: "/"})
+	Expect(response.Status).To(Equal(503))
+}
+
+func Test_Hoverfly_SetScenario_CanBeRetrievedWithGetScenario(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	scenario := v2.ScenarioView{
+		State:  map[string]string{"basket": "empty"},
+		States: []string{"empty", "full"},
+		Transitions: []v2.TransitionView{
+			{
+				From: "empty",
+				To:   "full",
+			},
+		},
+	}
+
+	Expect(unit.SetScenario("checkout", scenario)).To(Succeed())
+
+	Expect(unit.GetScenario("checkout")).To(Equal(scenario))
+	Expect(unit.GetState()).To(Equal(map[string]string{"scenario:checkout:basket": "empty"}))
+}
+
+func Test_Hoverfly_GetState_ReturnsScenarioKeysWhichWerePatched(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.PatchState(map[string]string{
+		"key":                      "value",
+		"scenario:checkout:basket": "full",
+	})
+
+	state := unit.GetState()
+	Expect(state).To(Equal(map[string]string{
+		"key":                      "value",
+		"scenario:checkout:basket": "full",
+	}))
+
+	state["key"] = "changed"
+	Expect(unit.GetState()).To(HaveKeyWithValue("key", "value"))
+}
+
+func Test_Hoverfly_SetScenario_RejectsStatesWhichHaveNotBeenDeclared(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err := unit.SetScenario("checkout", v2.ScenarioView{
+		State:  map[string]string{"basket": "overflowing"},
+		States: []string{"empty", "full"},
+	})
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("overflowing is not one of the states declared for basket"))
+}
+
 func Test_Hoverfly_AddDiff_AddEntry(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -1031,6 +1447,51 @@ func Test_Hoverfly_AddDiff_DoesntAddDiffReport_NoEntries(t *testing.T) {
 	Expect(unit.responsesDiff).To(HaveLen(0))
 }
 
+func Test_Hoverfly_AddDiff_CountsEveryComparison(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	key := v2.SimpleRequestDefinitionView{
+		Host: "test.com",
+	}
+
+	unit.AddDiff(key, v2.DiffReport{Timestamp: "now"})
+	unit.AddDiff(key, v2.DiffReport{Timestamp: "now", DiffEntries: []v2.DiffReportEntry{{Actual: "1"}}})
+
+	Expect(unit.GetDiffComparisons()).To(Equal(map[v2.SimpleRequestDefinitionView]int{key: 2}))
+
+	unit.ClearDiff()
+
+	Expect(unit.GetDiffComparisons()).To(BeEmpty())
+	Expect(unit.GetDiff()).To(BeEmpty())
+}
+
+func Test_Hoverfly_AddDiff_CanBeCalledConcurrently(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	key := v2.SimpleRequestDefinitionView{
+		Host: "test.com",
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unit.AddDiff(key, v2.DiffReport{Timestamp: "now", DiffEntries: []v2.DiffReportEntry{{Actual: "1"}}})
+			unit.GetDiff()
+			unit.GetDiffComparisons()
+		}()
+	}
+	wg.Wait()
+
+	Expect(unit.GetDiffComparisons()).To(Equal(map[v2.SimpleRequestDefinitionView]int{key: 50}))
+	Expect(unit.GetDiff()[key]).To(HaveLen(50))
+}
+
 func Test_Hoverfly_GetPACFile_GetsPACFile(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -1074,3 +1535,128 @@ func Test_Hoverfly_DeletePACFile(t *testing.T) {
 
 	Expect(unit.Cfg.PACFile).To(BeNil())
 }
+
+func Test_Hoverfly_GetMetrics_IncludesMatchesAndCacheLookups(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.PutSimulation(v2.SimulationViewV5{
+		v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Path: []v2.MatcherViewV5{
+							v2.NewMatcherView("exact", "/hit"),
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 200,
+					},
+				},
+			},
+		},
+		v2.MetaView{},
+	})
+
+	unit.GetResponse(models.RequestDetails{Path: "/hit"})
+	unit.GetResponse(models.RequestDetails{Path: "/hit"})
+	unit.GetResponse(models.RequestDetails{Path: "/miss"})
+
+	metrics := unit.GetMetrics()
+	Expect(metrics).To(ContainSubstring(`hoverfly_matches_total{result="hit"} 2`))
+	Expect(metrics).To(ContainSubstring(`hoverfly_matches_total{result="miss"} 1`))
+	Expect(metrics).To(ContainSubstring("hoverfly_cache_hits_total 1\n"))
+	Expect(metrics).To(ContainSubstring("hoverfly_cache_misses_total 2\n"))
+}
+
+func Test_Hoverfly_SetMiddlewareChain_SetsTheStagesInOrder(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err := unit.SetMiddlewareChain(v2.MiddlewareChainView{
+		Stages: []v2.MiddlewareStageView{
+			{
+				Phase:          "pre-request",
+				Modes:          []string{"modify"},
+				MiddlewareView: v2.MiddlewareView{Javascript: `pair.request.path = "/changed"`},
+			},
+			{
+				Phase:          "post-response",
+				Destination:    "test.com",
+				MiddlewareView: v2.MiddlewareView{Javascript: `pair.response.body = "changed"`, Timeout: 500},
+			},
+		},
+	})
+	Expect(err).To(BeNil())
+
+	Expect(unit.Cfg.MiddlewareChain).To(HaveLen(2))
+	Expect(unit.GetMiddlewareChain().Stages).To(Equal([]v2.MiddlewareStageView{
+		{
+			Phase:          "pre-request",
+			Modes:          []string{"modify"},
+			MiddlewareView: v2.MiddlewareView{Javascript: `pair.request.path = "/changed"`},
+		},
+		{
+			Phase:          "post-response",
+			Destination:    "test.com",
+			MiddlewareView: v2.MiddlewareView{Javascript: `pair.response.body = "changed"`, Timeout: 500},
+		},
+	}))
+}
+
+func Test_Hoverfly_SetMiddlewareChain_WillErrorIfGivenAnInvalidModeAndWillNotChangeTheChain(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.SetMiddlewareChain(v2.MiddlewareChainView{
+		Stages: []v2.MiddlewareStageView{
+			{Phase: "on-miss", MiddlewareView: v2.MiddlewareView{Javascript: `pair.response.status = 404`}},
+		},
+	})
+
+	err := unit.SetMiddlewareChain(v2.MiddlewareChainView{
+		Stages: []v2.MiddlewareStageView{
+			{Phase: "pre-request", MiddlewareView: v2.MiddlewareView{Javascript: `pair.request.path = "/changed"`}},
+			{Phase: "pre-request", Modes: []string{"recording"}, MiddlewareView: v2.MiddlewareView{Javascript: `pair.request.path = "/changed"`}},
+		},
+	})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Stage 2: Not a valid mode: recording"))
+
+	Expect(unit.GetMiddlewareChain().Stages).To(HaveLen(1))
+	Expect(unit.GetMiddlewareChain().Stages[0].Phase).To(Equal("on-miss"))
+}
+
+func Test_Hoverfly_SetMiddlewareChain_ReturnsTheStageOfMiddlewareWhichFails(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err := unit.SetMiddlewareChain(v2.MiddlewareChainView{
+		Stages: []v2.MiddlewareStageView{
+			{Phase: "post-response", MiddlewareView: v2.MiddlewareView{Javascript: `pair.nothing.body = "changed"`}},
+		},
+	})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("JavaScript middleware failed\nStage: 1 (post-response)"))
+
+	Expect(unit.Cfg.MiddlewareChain).To(BeEmpty())
+}
+
+func Test_Hoverfly_DeleteMiddlewareChain_RemovesEveryStage(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.SetMiddlewareChain(v2.MiddlewareChainView{
+		Stages: []v2.MiddlewareStageView{
+			{Phase: "on-miss", MiddlewareView: v2.MiddlewareView{Javascript: `pair.response.status = 404`}},
+		},
+	})
+
+	unit.DeleteMiddlewareChain()
+
+	Expect(unit.Cfg.MiddlewareChain).To(BeEmpty())
+	Expect(unit.GetMiddlewareChain().Stages).To(Equal([]v2.MiddlewareStageView{}))
+}
@@ -19,6 +19,11 @@ import (
 
 const pythonMiddlewareBasic = "import sys\nprint(sys.stdin.readlines()[0])"
 
+const pythonMiddlewareWorker = "import sys\n" +
+	"for line in iter(sys.stdin.readline, ''):\n" +
+	"	sys.stdout.write(line)\n" +
+	"	sys.stdout.flush()\n"
+
 const pythonModifyResponse = "#!/usr/bin/env python\n" +
 	"import sys\n" +
 	"import json\n" +
@@ -702,7 +707,7 @@ func TestMatchOnRequestBody(t *testing.T) {
 			Body:   fmt.Sprintf("body here, number=%d", i),
 		}
 
-		unit.Save(req, resp, nil, false)
+		unit.Save(req, resp, nil)
 	}
 
 	// now getting responses
@@ -6,7 +6,7 @@ import (
 	"github.com/SpectoLabs/hoverfly/core/state"
 	"net/url"
 	"os"
-	"path"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -33,9 +33,8 @@ func (hf *Hoverfly) Import(uri string) error {
 		return hf.ImportFromURL(uri)
 	}
 	// assuming file URI is disk location
-	ext := path.Ext(uri)
-	if ext != ".json" {
-		return fmt.Errorf("Failed to import payloads, only JSON files are acceppted. Given file: %s", uri)
+	if !isSimulationFile(uri) {
+		return fmt.Errorf("Failed to import payloads, only JSON, YAML and HAR files are acceppted. Given file: %s", uri)
 	}
 	// checking whether it exists
 	exists, err := exists(uri)
@@ -92,14 +91,17 @@ func (hf *Hoverfly) ImportFromDisk(path string) error {
 		return fmt.Errorf("Got error while opening payloads file, error %s", err.Error())
 	}
 
-	var simulation v2.SimulationViewV5
-
 	body, err := ioutil.ReadAll(pairsFile)
 	if err != nil {
 		return fmt.Errorf("Got error while parsing payloads, error %s", err.Error())
 	}
 
-	err = json.Unmarshal(body, &simulation)
+	body, err = prepareSimulationFile(path, body)
+	if err != nil {
+		return fmt.Errorf("Got error while parsing payloads, error %s", err.Error())
+	}
+
+	simulation, err := unmarshalSimulation(body)
 	if err != nil {
 		return fmt.Errorf("Got error while parsing payloads, error %s", err.Error())
 	}
@@ -117,14 +119,19 @@ func (hf *Hoverfly) ImportFromURL(url string) error {
 		return fmt.Errorf("Failed to fetch given URL, error %s", err.Error())
 	}
 
-	var simulation v2.SimulationViewV5
-
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return fmt.Errorf("Got error while parsing payloads, error %s", err.Error())
 	}
 
-	err = json.Unmarshal(body, &simulation)
+	if v2.IsYAMLFile(resp.Request.URL.Path) || v2.IsYAMLContentType(resp.Header.Get("Content-Type")) {
+		body, err = v2.ConvertYAMLToJSON(body)
+		if err != nil {
+			return fmt.Errorf("Got error while parsing payloads, error %s", err.Error())
+		}
+	}
+
+	simulation, err := unmarshalSimulation(body)
 	if err != nil {
 		return fmt.Errorf("Got error while parsing payloads, error %s", err.Error())
 	}
@@ -132,6 +139,39 @@ func (hf *Hoverfly) ImportFromURL(url string) error {
 	return hf.PutSimulation(simulation).GetError()
 }
 
+// prepareSimulationFile converts a simulation file written in YAML into JSON, then loads
+// any bodies which are kept in files next to it
+func prepareSimulationFile(file string, body []byte) ([]byte, error) {
+	if v2.IsYAMLFile(file) {
+		var err error
+		body, err = v2.ConvertYAMLToJSON(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return v2.ResolveBodyFiles(body, filepath.Dir(file))
+}
+
+// unmarshalSimulation reads either a simulation or an HTTP Archive, converting the
+// entries of the archive into request matcher and response pairs
+func unmarshalSimulation(body []byte) (v2.SimulationViewV5, error) {
+	var simulation v2.SimulationViewV5
+
+	jsonMap := make(map[string]interface{})
+	if err := json.Unmarshal(body, &jsonMap); err == nil && v2.IsHar(jsonMap) {
+		harView, err := v2.NewHarViewFromRequestBody(body)
+		if err != nil {
+			return simulation, err
+		}
+
+		return v2.NewSimulationViewFromHar(harView)
+	}
+
+	err := json.Unmarshal(body, &simulation)
+	return simulation, err
+}
+
 // importRequestResponsePairViews - a function to save given pairs into the database.
 func (hf *Hoverfly) importRequestResponsePairViews(pairViews []v2.RequestMatcherResponsePairViewV5) v2.SimulationImportResult {
 	importResult := v2.SimulationImportResult{}
@@ -143,6 +183,18 @@ func (hf *Hoverfly) importRequestResponsePairViews(pairViews []v2.RequestMatcher
 
 			pair := models.NewRequestMatcherResponsePairFromView(&pairView)
 
+			if err := pair.RequestMatcher.ResolveSchemaReferences(hf.Simulation.GetSchemas()); err != nil {
+				importResult.AddError(fmt.Errorf("data.pairs[%v] is not added: %s", i, err.Error()))
+				failed++
+				continue
+			}
+
+			if err := validateLogNormalDelays(pair); err != nil {
+				importResult.AddError(fmt.Errorf("data.pairs[%v] is not added: %s", i, err.Error()))
+				failed++
+				continue
+			}
+
 			var isPairAdded bool
 			if hf.Cfg.NoImportCheck {
 				hf.Simulation.AddPairWithoutCheck(pair)
@@ -193,3 +245,20 @@ func (hf *Hoverfly) importRequestResponsePairViews(pairViews []v2.RequestMatcher
 
 	return importResult
 }
+
+func validateLogNormalDelays(pair *models.RequestMatcherResponsePair) error {
+	responses := []models.ResponseDetails{pair.Response}
+	if pair.Responses != nil {
+		responses = pair.Responses.Responses
+	}
+
+	for _, response := range responses {
+		if delay := response.LogNormalDelay; delay != nil {
+			if err := models.ValidateLogNormalDelay(delay.Min, delay.Max, delay.Mean, delay.Median); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
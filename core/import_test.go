@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path"
 	"testing"
 
 	"github.com/SpectoLabs/hoverfly/core/cache"
@@ -108,6 +109,109 @@ func TestImportFromDiskWrongJson(t *testing.T) {
 	Expect(err).ToNot(BeNil())
 }
 
+const harFile = `{
+	"log": {
+		"version": "1.2",
+		"creator": {"name": "test", "version": "1"},
+		"entries": [{
+			"startedDateTime": "2018-01-02T03:04:05.000Z",
+			"time": 10,
+			"request": {
+				"method": "POST",
+				"url": "https://hoverfly.io/api?q=1",
+				"httpVersion": "HTTP/2.0",
+				"headers": [{"name": ":authority", "value": "hoverfly.io"}, {"name": "content-type", "value": "application/json"}],
+				"queryString": [{"name": "q", "value": "1"}],
+				"postData": {"mimeType": "application/json", "text": "{\"id\": 1}"}
+			},
+			"response": {
+				"status": 201,
+				"statusText": "Created",
+				"headers": [{"name": "content-encoding", "value": "gzip"}, {"name": "x-test", "value": "test"}],
+				"content": {"size": 2, "mimeType": "application/json", "text": "{}"}
+			}
+		}]
+	}
+}`
+
+func TestImportFromDisk_ImportsHar(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, _ := ioutil.TempDir("", "hoverfly-import")
+	defer os.RemoveAll(dir)
+
+	harPath := path.Join(dir, "traffic.har")
+	Expect(ioutil.WriteFile(harPath, []byte(harFile), 0600)).To(Succeed())
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err := unit.Import(harPath)
+	Expect(err).To(BeNil())
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
+
+	pair := unit.Simulation.GetMatchingPairs()[0]
+	Expect(pair.RequestMatcher.Method).To(Equal([]models.RequestFieldMatchers{{Matcher: matchers.Exact, Value: "POST"}}))
+	Expect(pair.RequestMatcher.Scheme).To(Equal([]models.RequestFieldMatchers{{Matcher: matchers.Exact, Value: "https"}}))
+	Expect(pair.RequestMatcher.Destination).To(Equal([]models.RequestFieldMatchers{{Matcher: matchers.Exact, Value: "hoverfly.io"}}))
+	Expect(pair.RequestMatcher.Path).To(Equal([]models.RequestFieldMatchers{{Matcher: matchers.Exact, Value: "/api"}}))
+	Expect(pair.RequestMatcher.Body).To(Equal([]models.RequestFieldMatchers{{Matcher: matchers.Json, Value: `{"id": 1}`}}))
+	Expect(pair.RequestMatcher.Headers).To(BeEmpty())
+	Expect(pair.Response.Status).To(Equal(201))
+	Expect(pair.Response.Body).To(Equal("{}"))
+	Expect(pair.Response.Headers).To(Equal(map[string][]string{"X-Test": {"test"}}))
+}
+
+func TestImportFromDisk_RejectsOtherExtensions(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err := unit.Import("simulation.xml")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("only JSON, YAML and HAR files"))
+}
+
+func TestImportFromDisk_ImportsYAMLWithBodyFiles(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, _ := ioutil.TempDir("", "hoverfly-import")
+	defer os.RemoveAll(dir)
+
+	Expect(ioutil.WriteFile(path.Join(dir, "body.json"), []byte(`{"id": 1}`), 0600)).To(Succeed())
+	Expect(ioutil.WriteFile(path.Join(dir, "simulation.yaml"), []byte(`
+data:
+  pairs:
+  - request:
+      path:
+      - matcher: exact
+        value: /inline
+    response:
+      status: 200
+      body: |
+        inline body
+  - request:
+      path:
+      - matcher: exact
+        value: /file
+    response:
+      status: 200
+      bodyFile: body.json
+meta:
+  schemaVersion: v5
+`), 0600)).To(Succeed())
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err := unit.Import(path.Join(dir, "simulation.yaml"))
+	Expect(err).To(BeNil())
+
+	pairs := unit.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(2))
+	Expect(pairs[0].Response.Body).To(Equal("inline body\n"))
+	Expect(pairs[1].Response.Body).To(Equal(`{"id": 1}`))
+}
+
 func TestImportFromURL(t *testing.T) {
 	RegisterTestingT(t)
 
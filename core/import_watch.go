@@ -0,0 +1,154 @@
+package hoverfly
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/state"
+	"github.com/fsnotify/fsnotify"
+	log "github.com/sirupsen/logrus"
+)
+
+// importWatchDelay gives editors time to finish writing a file before it is reloaded,
+// as saving a file can produce several events
+const importWatchDelay = 100 * time.Millisecond
+
+// ImportDirectory loads every simulation file in a directory, adding them together like
+// simulations added with the API. The simulation is only replaced once every file has been
+// imported, so a file which cannot be imported leaves the previous simulation in place.
+func (hf *Hoverfly) ImportDirectory(dir string) error {
+	files, err := getSimulationFiles(dir)
+	if err != nil {
+		return fmt.Errorf("Failed to read the simulations in %s, error %s", dir, err.Error())
+	}
+
+	loaded := &Hoverfly{
+		Cfg:        hf.Cfg,
+		Simulation: models.NewSimulation(),
+		state:      state.NewState(),
+	}
+
+	for _, file := range files {
+		simulation, err := readSimulationFile(file)
+		if err != nil {
+			return fmt.Errorf("Failed to import %s, error %s", file, err.Error())
+		}
+
+		if err := loaded.PutSimulation(simulation).GetError(); err != nil {
+			return fmt.Errorf("Failed to import %s, error %s", file, err.Error())
+		}
+	}
+
+	hf.Simulation.Replace(loaded.Simulation)
+	hf.state.InitializeSequences(loaded.state.Copy())
+	hf.FlushCache()
+
+	log.WithFields(log.Fields{
+		"directory": dir,
+		"files":     len(files),
+	}).Info("Simulations imported from directory")
+
+	return nil
+}
+
+// WatchImportDirectory imports the simulations in a directory, then imports them again
+// whenever a simulation file in it changes. Errors while reloading are logged.
+func (hf *Hoverfly) WatchImportDirectory(dir string) error {
+	if err := hf.ImportDirectory(dir); err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return fmt.Errorf("Failed to watch %s, error %s", dir, err.Error())
+	}
+
+	go hf.watchImportDirectory(dir, watcher)
+
+	return nil
+}
+
+func (hf *Hoverfly) watchImportDirectory(dir string, watcher *fsnotify.Watcher) {
+	defer watcher.Close()
+
+	var reload <-chan time.Time
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			// Any file may be the body of a response, so every change reloads the simulations
+			log.WithFields(log.Fields{
+				"file": event.Name,
+			}).Debug("File changed in the directory of simulations")
+			reload = time.After(importWatchDelay)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.WithFields(log.Fields{
+				"error":     err.Error(),
+				"directory": dir,
+			}).Error("Failed to watch the directory of simulations")
+		case <-reload:
+			reload = nil
+			if err := hf.ImportDirectory(dir); err != nil {
+				log.WithFields(log.Fields{
+					"error":     err.Error(),
+					"directory": dir,
+				}).Error("Failed to reload simulations, the previous simulation has been kept")
+			}
+		}
+	}
+}
+
+func getSimulationFiles(dir string) ([]string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	for _, info := range infos {
+		if !info.IsDir() && isSimulationFile(info.Name()) {
+			files = append(files, filepath.Join(dir, info.Name()))
+		}
+	}
+	sort.Strings(files)
+
+	return files, nil
+}
+
+func isSimulationFile(name string) bool {
+	ext := filepath.Ext(name)
+	return ext == ".json" || ext == ".har" || v2.IsYAMLFile(name)
+}
+
+func readSimulationFile(file string) (v2.SimulationViewV5, error) {
+	body, err := ioutil.ReadFile(file)
+	if err != nil {
+		return v2.SimulationViewV5{}, err
+	}
+
+	body, err = prepareSimulationFile(file, body)
+	if err != nil {
+		return v2.SimulationViewV5{}, err
+	}
+
+	if filepath.Ext(file) == ".har" {
+		return unmarshalSimulation(body)
+	}
+
+	return v2.NewSimulationViewFromRequestBody(body)
+}
@@ -0,0 +1,187 @@
+package hoverfly
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	log "github.com/sirupsen/logrus"
+)
+
+func simulationFile(path, body string) string {
+	return `{
+		"data": {
+			"pairs": [{
+				"request": {
+					"path": [{"matcher": "exact", "value": "` + path + `"}]
+				},
+				"response": {
+					"status": 200,
+					"body": "` + body + `"
+				}
+			}]
+		},
+		"meta": {
+			"schemaVersion": "v5"
+		}
+	}`
+}
+
+func writeSimulationFile(dir, name, content string) {
+	Expect(ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)).To(BeNil())
+}
+
+func getBodies(hf *Hoverfly) []string {
+	bodies := []string{}
+	for _, pair := range hf.Simulation.GetMatchingPairs() {
+		bodies = append(bodies, pair.Response.Body)
+	}
+	return bodies
+}
+
+func Test_Hoverfly_ImportDirectory_ImportsEverySimulationFile(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "hoverfly-import")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	writeSimulationFile(dir, "a.json", simulationFile("/a", "a"))
+	writeSimulationFile(dir, "b.json", simulationFile("/b", "b"))
+	writeSimulationFile(dir, "notes.txt", "not a simulation")
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.ImportDirectory(dir)).To(BeNil())
+	Expect(getBodies(unit)).To(Equal([]string{"a", "b"}))
+}
+
+func Test_Hoverfly_ImportDirectory_ReplacesThePreviousSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "hoverfly-import")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	writeSimulationFile(dir, "a.json", simulationFile("/a", "a"))
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	Expect(unit.ImportDirectory(dir)).To(BeNil())
+
+	writeSimulationFile(dir, "a.json", simulationFile("/a", "changed"))
+
+	Expect(unit.ImportDirectory(dir)).To(BeNil())
+	Expect(getBodies(unit)).To(Equal([]string{"changed"}))
+}
+
+func Test_Hoverfly_ImportDirectory_KeepsThePreviousSimulationWhenAFileIsInvalid(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "hoverfly-import")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	writeSimulationFile(dir, "a.json", simulationFile("/a", "a"))
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	Expect(unit.ImportDirectory(dir)).To(BeNil())
+
+	writeSimulationFile(dir, "a.json", simulationFile("/a", "changed"))
+	writeSimulationFile(dir, "b.json", `{"data": {"pairs": [{"request": {}}]}}`)
+
+	err = unit.ImportDirectory(dir)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Failed to import " + filepath.Join(dir, "b.json")))
+
+	Expect(getBodies(unit)).To(Equal([]string{"a"}))
+}
+
+func Test_Hoverfly_ImportDirectory_ErrorsWhenTheDirectoryDoesNotExist(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err := unit.ImportDirectory("/does/not/exist")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Failed to read the simulations in /does/not/exist"))
+}
+
+func Test_Hoverfly_WatchImportDirectory_ReloadsChangedFiles(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "hoverfly-import")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	writeSimulationFile(dir, "a.json", simulationFile("/a", "a"))
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	Expect(unit.WatchImportDirectory(dir)).To(BeNil())
+	Expect(getBodies(unit)).To(Equal([]string{"a"}))
+
+	writeSimulationFile(dir, "b.json", simulationFile("/b", "b"))
+
+	Eventually(func() []string {
+		return getBodies(unit)
+	}).Should(Equal([]string{"a", "b"}))
+}
+
+func Test_Hoverfly_WatchImportDirectory_LogsErrorsWhenReloading(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "hoverfly-import")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	writeSimulationFile(dir, "a.json", simulationFile("/a", "a"))
+
+	log.SetLevel(log.ErrorLevel)
+	defer log.SetLevel(log.FatalLevel)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	Expect(unit.WatchImportDirectory(dir)).To(BeNil())
+
+	writeSimulationFile(dir, "a.json", "{")
+
+	Eventually(func() []string {
+		entries, _ := unit.StoreLogsHook.GetLogs(1000, nil)
+		messages := []string{}
+		for _, entry := range entries {
+			messages = append(messages, entry.Message)
+		}
+		return messages
+	}).Should(ContainElement("Failed to reload simulations, the previous simulation has been kept"))
+
+	Expect(getBodies(unit)).To(Equal([]string{"a"}))
+}
+
+func Test_Hoverfly_ImportDirectory_ImportsYAMLSimulations(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "hoverfly-import")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	writeSimulationFile(dir, "a.json", simulationFile("/a", "a"))
+	writeSimulationFile(dir, "b.body", "b")
+	writeSimulationFile(dir, "b.yml", `
+data:
+  pairs:
+  - request:
+      path:
+      - matcher: exact
+        value: /b
+    response:
+      status: 200
+      bodyFile: b.body
+meta:
+  schemaVersion: v5
+`)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.ImportDirectory(dir)).To(BeNil())
+	Expect(getBodies(unit)).To(Equal([]string{"a", "b"}))
+}
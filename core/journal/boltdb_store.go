@@ -0,0 +1,79 @@
+package journal
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/boltdb/bolt"
+)
+
+// BoltDBStore keeps entries in a BoltDB bucket, keyed by the order they were added in. Unlike
+// the file store it is never rotated or trimmed, so it grows until its entries are deleted.
+type BoltDBStore struct {
+	DS            *bolt.DB
+	CurrentBucket []byte
+}
+
+// NewBoltDBStore - returns new BoltDB store which keeps entries in the given bucket
+func NewBoltDBStore(db *bolt.DB, bucket []byte) *BoltDBStore {
+	return &BoltDBStore{
+		DS:            db,
+		CurrentBucket: bucket,
+	}
+}
+
+func (this *BoltDBStore) Add(entry JournalEntry) error {
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return this.DS.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(this.CurrentBucket)
+		if err != nil {
+			return err
+		}
+
+		sequence, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		key := make([]byte, 8)
+		binary.BigEndian.PutUint64(key, sequence)
+
+		return bucket.Put(key, value)
+	})
+}
+
+func (this *BoltDBStore) ForEach(fn func(entry JournalEntry) bool) error {
+	return this.DS.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(this.CurrentBucket)
+		if bucket == nil {
+			return nil
+		}
+
+		cursor := bucket.Cursor()
+		for key, value := cursor.First(); key != nil; key, value = cursor.Next() {
+			var entry JournalEntry
+			if err := json.Unmarshal(value, &entry); err != nil {
+				return err
+			}
+			if !fn(entry) {
+				break
+			}
+		}
+
+		return nil
+	})
+}
+
+func (this *BoltDBStore) DeleteAll() error {
+	return this.DS.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(this.CurrentBucket) == nil {
+			return nil
+		}
+
+		return tx.DeleteBucket(this.CurrentBucket)
+	})
+}
@@ -0,0 +1,311 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// FileStore appends entries to a file as JSON Lines. Once the file reaches its maximum
+// size or age it is rotated, by renaming it to path.1 (and any previous path.1 to path.2
+// and so on) and starting a new file.
+type FileStore struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+	file       *os.File
+	size       int64
+	created    time.Time
+}
+
+// NewFileStore - returns new file store which writes to the file at the given path, appending
+// to it if it already exists. A maxSize or maxAge of zero disables that kind of rotation, and
+// a maxBackups of zero keeps every rotated file.
+func NewFileStore(path string, maxSize int64, maxAge time.Duration, maxBackups int) (*FileStore, error) {
+	store := &FileStore{
+		path:       path,
+		maxSize:    maxSize,
+		maxAge:     maxAge,
+		maxBackups: maxBackups,
+	}
+
+	if err := store.open(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (this *FileStore) Add(entry JournalEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	if this.shouldRotate(int64(len(line))) {
+		if err := this.rotate(); err != nil {
+			return err
+		}
+	}
+
+	if this.size == 0 {
+		this.created = time.Now()
+	}
+
+	written, err := this.file.Write(line)
+	this.size += int64(written)
+
+	return err
+}
+
+// ForEach reads the entries from the rotated files, oldest first, and then from the current file.
+// The files are only opened while holding the lock, so entries can still be added while they are
+// read. Entries added after ForEach was called are not read.
+func (this *FileStore) ForEach(fn func(entry JournalEntry) bool) error {
+	readers, err := this.openReaders()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, reader := range readers {
+			reader.Close()
+		}
+	}()
+
+	for _, reader := range readers {
+		more, err := readEntries(reader, fn)
+		if err != nil || !more {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// openReaders opens each file which holds entries, oldest first. A file which is open can still
+// be read after it has been rotated or removed. The current file is only read up to its size
+// now, so that an entry which is being added is not read part way through.
+func (this *FileStore) openReaders() ([]*entryReader, error) {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	readers := []*entryReader{}
+	closeAll := func() {
+		for _, reader := range readers {
+			reader.Close()
+		}
+	}
+
+	for i := this.lastBackup(); i > 0; i-- {
+		file, err := os.Open(this.backupPath(i))
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+		readers = append(readers, &entryReader{file: file, reader: file})
+	}
+
+	file, err := os.Open(this.path)
+	if err != nil {
+		closeAll()
+		return nil, err
+	}
+
+	return append(readers, &entryReader{file: file, reader: io.LimitReader(file, this.size)}), nil
+}
+
+func (this *FileStore) DeleteAll() error {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	for i := this.lastBackup(); i > 0; i-- {
+		if err := os.Remove(this.backupPath(i)); err != nil {
+			return err
+		}
+	}
+
+	if err := this.file.Truncate(0); err != nil {
+		return err
+	}
+	this.size = 0
+
+	return nil
+}
+
+// Close closes the current file
+func (this *FileStore) Close() error {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	return this.file.Close()
+}
+
+func (this *FileStore) open() error {
+	file, err := os.OpenFile(this.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	this.file = file
+	this.size = info.Size()
+	this.created = time.Now()
+
+	if this.size > 0 {
+		// The age of an existing file is taken from its oldest entry
+		if entry, err := readFirstEntry(this.path); err == nil {
+			this.created = entry.TimeStarted
+		}
+
+		// An incomplete last line is ended, so that it doesn't run into the next entry
+		if !endsWithNewline(this.path, this.size) {
+			written, err := file.Write([]byte("\n"))
+			this.size += int64(written)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func endsWithNewline(path string, size int64) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	last := make([]byte, 1)
+	if _, err := file.ReadAt(last, size-1); err != nil {
+		return false
+	}
+
+	return last[0] == '\n'
+}
+
+func (this *FileStore) shouldRotate(lineSize int64) bool {
+	if this.size == 0 {
+		return false
+	}
+
+	if this.maxSize > 0 && this.size+lineSize > this.maxSize {
+		return true
+	}
+
+	return this.maxAge > 0 && time.Since(this.created) >= this.maxAge
+}
+
+func (this *FileStore) rotate() error {
+	if err := this.file.Close(); err != nil {
+		return err
+	}
+
+	lastBackup := this.lastBackup()
+	if this.maxBackups > 0 {
+		for i := lastBackup; i >= this.maxBackups; i-- {
+			if err := os.Remove(this.backupPath(i)); err != nil {
+				return err
+			}
+		}
+		if lastBackup >= this.maxBackups {
+			lastBackup = this.maxBackups - 1
+		}
+	}
+
+	for i := lastBackup; i > 0; i-- {
+		if err := os.Rename(this.backupPath(i), this.backupPath(i+1)); err != nil {
+			return err
+		}
+	}
+
+	if err := os.Rename(this.path, this.backupPath(1)); err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"path": this.path,
+		"size": this.size,
+	}).Debug("Rotated journal file")
+
+	return this.open()
+}
+
+// lastBackup returns the number of the oldest rotated file, which is zero if there are none
+func (this *FileStore) lastBackup() int {
+	last := 0
+	for {
+		if _, err := os.Stat(this.backupPath(last + 1)); err != nil {
+			return last
+		}
+		last++
+	}
+}
+
+func (this *FileStore) backupPath(number int) string {
+	return fmt.Sprintf("%s.%d", this.path, number)
+}
+
+func readFirstEntry(path string) (JournalEntry, error) {
+	var entry JournalEntry
+
+	file, err := os.Open(path)
+	if err != nil {
+		return entry, err
+	}
+	defer file.Close()
+
+	line, err := bufio.NewReader(file).ReadBytes('\n')
+	if err != nil {
+		return entry, err
+	}
+
+	return entry, json.Unmarshal(line, &entry)
+}
+
+type entryReader struct {
+	file   *os.File
+	reader io.Reader
+}
+
+func (this *entryReader) Close() error {
+	return this.file.Close()
+}
+
+// readEntries calls fn with each entry read, returning false if fn stopped the reading
+func readEntries(entryReader *entryReader, fn func(entry JournalEntry) bool) (bool, error) {
+	scanner := bufio.NewScanner(entryReader.reader)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// A line can be left incomplete if Hoverfly was stopped part way through writing it
+			log.WithFields(log.Fields{
+				"error": err.Error(),
+				"path":  entryReader.file.Name(),
+			}).Warn("Skipping journal entry which could not be read")
+			continue
+		}
+		if !fn(entry) {
+			return false, nil
+		}
+	}
+
+	return true, scanner.Err()
+}
@@ -17,25 +17,116 @@ import (
 var RFC3339Milli = "2006-01-02T15:04:05.000Z07:00"
 
 type JournalEntry struct {
-	Request     *models.RequestDetails
-	Response    *models.ResponseDetails
-	Mode        string
-	TimeStarted time.Time
-	Latency     time.Duration
+	Request     *models.RequestDetails  `json:"request"`
+	Response    *models.ResponseDetails `json:"response"`
+	Mode        string                  `json:"mode"`
+	TimeStarted time.Time               `json:"timeStarted"`
+	Latency     time.Duration           `json:"latency"`
 }
 
+// Journal records the requests and responses which passed through Hoverfly. Entries are
+// kept in memory by default, holding at most EntryLimit entries, but can be kept in any
+// store. Entries are read back from the store a page at a time, so every entry in it can
+// be reached whichever store is used. An EntryLimit of zero disables the journal.
 type Journal struct {
-	entries    []JournalEntry
+	store      Store
 	EntryLimit int
 }
 
 func NewJournal() *Journal {
 	return &Journal{
-		entries:    []JournalEntry{},
+		store:      NewRingBufferStore(1000),
 		EntryLimit: 1000,
 	}
 }
 
+// SetStore sets the store the journal keeps its entries in
+func (this *Journal) SetStore(store Store) {
+	this.store = store
+}
+
+// getStore returns the store the journal keeps its entries in. The entry limit can be changed
+// after the journal is created, so the in-memory store is resized to match it first.
+func (this Journal) getStore() Store {
+	if ringBuffer, ok := this.store.(*RingBufferStore); ok {
+		ringBuffer.SetCapacity(this.EntryLimit)
+	}
+
+	return this.store
+}
+
+const maxInt = int(^uint(0) >> 1)
+
+// entryPage collects the entries from offset up to offset+limit in the order given by less,
+// or in the order they were added in if less is nil, while counting every entry it is given.
+// Only the entries which can still end up on the page are kept in memory.
+type entryPage struct {
+	offset  int
+	end     int
+	less    func(a, b JournalEntry) bool
+	entries []JournalEntry
+	total   int
+}
+
+func newEntryPage(offset, limit int, less func(a, b JournalEntry) bool) *entryPage {
+	end := offset + limit
+	if limit < 0 {
+		end = offset
+	} else if end < offset {
+		end = maxInt
+	}
+
+	return &entryPage{
+		offset:  offset,
+		end:     end,
+		less:    less,
+		entries: []JournalEntry{},
+	}
+}
+
+func (this *entryPage) add(entry JournalEntry) {
+	index := this.total
+	this.total++
+
+	if this.less == nil {
+		if index >= this.offset && index < this.end {
+			this.entries = append(this.entries, entry)
+		}
+		return
+	}
+
+	this.entries = append(this.entries, entry)
+	if len(this.entries)-this.end > this.end {
+		this.trim()
+	}
+}
+
+// trim sorts the entries kept so far and drops those which can no longer end up on the page.
+// The sort is stable and kept entries come before any added later, so entries which are equal
+// stay in the order they were added in.
+func (this *entryPage) trim() {
+	sorting.SliceStable(this.entries, func(i, j int) bool {
+		return this.less(this.entries[i], this.entries[j])
+	})
+
+	if len(this.entries) > this.end {
+		this.entries = this.entries[:this.end]
+	}
+}
+
+func (this *entryPage) get() []JournalEntry {
+	if this.less == nil {
+		return this.entries
+	}
+
+	this.trim()
+	if this.offset >= len(this.entries) {
+		return []JournalEntry{}
+	}
+
+	return this.entries[this.offset:]
+}
+
 func (this *Journal) NewEntry(request *http.Request, response *http.Response, mode string, started time.Time) error {
 	if this.EntryLimit == 0 {
 		return fmt.Errorf("Journal disabled")
@@ -51,19 +142,13 @@ func (this *Journal) NewEntry(request *http.Request, response *http.Response, mo
 		Headers: response.Header,
 	}
 
-	if len(this.entries) >= this.EntryLimit {
-		this.entries = append(this.entries[:0], this.entries[1:]...)
-	}
-
-	this.entries = append(this.entries, JournalEntry{
+	return this.getStore().Add(JournalEntry{
 		Request:     &payloadRequest,
 		Response:    payloadResponse,
 		Mode:        mode,
 		TimeStarted: started,
 		Latency:     time.Since(started),
 	})
-
-	return nil
 }
 
 func (this Journal) GetEntries(offset int, limit int, from *time.Time, to *time.Time, sort string) (v2.JournalView, error) {
@@ -84,55 +169,50 @@ func (this Journal) GetEntries(offset int, limit int, from *time.Time, to *time.
 		return journalView, err
 	}
 
-	selectedEntries := []JournalEntry{}
-
-	// Filtering
-	if from != nil || to != nil {
-		for _, entry := range this.entries {
-			if from != nil && entry.TimeStarted.Before(*from) {
-				continue
-			}
-			if to != nil && entry.TimeStarted.After(*to) {
-				continue
-			}
-			selectedEntries = append(selectedEntries, entry)
-		}
-	} else {
-		selectedEntries = append(selectedEntries, this.entries...)
+	if offset < 0 {
+		offset = 0
 	}
 
 	// Sorting
+	var less func(a, b JournalEntry) bool
 	if sortKey == "timestarted" && sortOrder == "desc" {
-		sorting.Slice(selectedEntries, func(i, j int) bool {
-			return selectedEntries[i].TimeStarted.After(selectedEntries[j].TimeStarted)
-		})
+		less = func(a, b JournalEntry) bool {
+			return a.TimeStarted.After(b.TimeStarted)
+		}
 	} else if sortKey == "latency" {
-		sorting.Slice(selectedEntries, func(i, j int) bool {
+		less = func(a, b JournalEntry) bool {
 			if sortOrder == "desc" {
-				return selectedEntries[i].Latency > selectedEntries[j].Latency
+				return a.Latency > b.Latency
 			} else {
-				return selectedEntries[i].Latency < selectedEntries[j].Latency
+				return a.Latency < b.Latency
 			}
-		})
+		}
 	}
 
-	totalElements := len(selectedEntries)
-
-	if offset < 0 {
-		offset = 0
-	} else if offset >= totalElements {
-		return journalView, nil
+	// Filtering and pagination
+	page := newEntryPage(offset, limit, less)
+	err = this.getStore().ForEach(func(entry JournalEntry) bool {
+		if from != nil && entry.TimeStarted.Before(*from) {
+			return true
+		}
+		if to != nil && entry.TimeStarted.After(*to) {
+			return true
+		}
+		page.add(entry)
+		return true
+	})
+	if err != nil {
+		return journalView, err
 	}
 
-	endIndex := offset + limit
-	if endIndex > totalElements {
-		endIndex = totalElements
+	if offset >= page.total {
+		return journalView, nil
 	}
 
-	journalView.Journal = convertJournalEntries(selectedEntries[offset:endIndex])
+	journalView.Journal = convertJournalEntries(page.get())
 	journalView.Offset = offset
 	journalView.Limit = limit
-	journalView.Total = totalElements
+	journalView.Total = page.total
 	return journalView, nil
 }
 
@@ -142,54 +222,81 @@ func (this Journal) GetFilteredEntries(journalEntryFilterView v2.JournalEntryFil
 		return filteredEntries, fmt.Errorf("Journal disabled")
 	}
 
-	requestMatcher := models.RequestMatcher{
-		Path:            models.NewRequestFieldMatchersFromView(journalEntryFilterView.Request.Path),
-		Method:          models.NewRequestFieldMatchersFromView(journalEntryFilterView.Request.Method),
-		Destination:     models.NewRequestFieldMatchersFromView(journalEntryFilterView.Request.Destination),
-		Scheme:          models.NewRequestFieldMatchersFromView(journalEntryFilterView.Request.Scheme),
-		DeprecatedQuery: models.NewRequestFieldMatchersFromView(journalEntryFilterView.Request.DeprecatedQuery),
-		Body:            models.NewRequestFieldMatchersFromView(journalEntryFilterView.Request.Body),
-		Query:           models.NewQueryRequestFieldMatchersFromMapView(journalEntryFilterView.Request.Query),
-		Headers:         models.NewRequestFieldMatchersFromMapView(journalEntryFilterView.Request.Headers),
-	}
+	requestMatcher := newRequestMatcher(journalEntryFilterView.Request)
 
-	allEntries := convertJournalEntries(this.entries)
+	if requestMatcher.Body == nil && requestMatcher.Destination == nil &&
+		requestMatcher.Headers == nil && requestMatcher.Method == nil &&
+		requestMatcher.Path == nil && requestMatcher.DeprecatedQuery == nil &&
+		requestMatcher.Scheme == nil && requestMatcher.Query == nil {
+		return filteredEntries, nil
+	}
 
-	for _, entry := range allEntries {
-		if requestMatcher.Body == nil && requestMatcher.Destination == nil &&
-			requestMatcher.Headers == nil && requestMatcher.Method == nil &&
-			requestMatcher.Path == nil && requestMatcher.DeprecatedQuery == nil &&
-			requestMatcher.Scheme == nil && requestMatcher.Query == nil {
-			continue
-		}
-		if !matching.FieldMatcher(requestMatcher.Body, *entry.Request.Body).Matched {
-			continue
-		}
-		if !matching.FieldMatcher(requestMatcher.Destination, *entry.Request.Destination).Matched {
-			continue
-		}
-		if !matching.FieldMatcher(requestMatcher.Method, *entry.Request.Method).Matched {
-			continue
-		}
-		if !matching.FieldMatcher(requestMatcher.Path, *entry.Request.Path).Matched {
-			continue
-		}
-		if !matching.FieldMatcher(requestMatcher.DeprecatedQuery, *entry.Request.Query).Matched {
-			continue
-		}
-		if !matching.FieldMatcher(requestMatcher.Scheme, *entry.Request.Scheme).Matched {
-			continue
-		}
-		if !matching.QueryMatching(requestMatcher, entry.Request.QueryMap).Matched {
-			continue
+	err := this.getStore().ForEach(func(entry JournalEntry) bool {
+		view := convertJournalEntry(entry)
+		if missedFields, _, _ := matchEntry(requestMatcher, view); len(missedFields) == 0 {
+			filteredEntries = append(filteredEntries, view)
 		}
-		if !matching.HeaderMatching(requestMatcher, entry.Request.Headers).Matched {
-			continue
+		return true
+	})
+
+	return filteredEntries, err
+}
+
+// VerifyEntries checks that the number of entries in the store which match the request matcher
+// meets the count constraint, where a request matcher without any matchers matches every entry.
+// At most the newest EntryLimit matching entries are returned with the result, and if the count
+// isn't met the entries which came closest to matching are returned too.
+func (this Journal) VerifyEntries(journalVerifyView v2.JournalVerifyView) (v2.JournalVerifyResultView, error) {
+	result := v2.JournalVerifyResultView{
+		Matches: []v2.JournalEntryView{},
+	}
+
+	if this.EntryLimit == 0 {
+		return result, fmt.Errorf("Journal disabled")
+	}
+
+	min, max, err := journalVerifyView.Count.GetRange()
+	if err != nil {
+		return result, err
+	}
+
+	requestMatcher := newRequestMatcher(journalVerifyView.Request)
+	matches := NewRingBufferStore(this.EntryLimit)
+	closestEntries := newClosestEntries()
+
+	err = this.getStore().ForEach(func(entry JournalEntry) bool {
+		view := convertJournalEntry(entry)
+		missedFields, matchedFields, score := matchEntry(requestMatcher, view)
+		if len(missedFields) == 0 {
+			result.Count++
+			matches.Add(entry)
+		} else {
+			closestEntries.add(view, missedFields, matchedFields, score)
 		}
-		filteredEntries = append(filteredEntries, entry)
+		return true
+	})
+	if err != nil {
+		return result, err
+	}
+
+	matchingEntries, err := matches.GetAll()
+	if err != nil {
+		return result, err
+	}
+
+	result.Matches = convertJournalEntries(matchingEntries)
+	result.Passed = result.Count >= min && (max == -1 || result.Count <= max)
+
+	if result.Passed {
+		result.Message = fmt.Sprintf("Request was made %s, as expected", countDescription(result.Count))
+		return result, nil
 	}
 
-	return filteredEntries, nil
+	result.Message = fmt.Sprintf("Expected request to be made %s, but it was made %s",
+		journalVerifyView.Count.String(), countDescription(result.Count))
+	result.ClosestEntries = closestEntries.views()
+
+	return result, nil
 }
 
 func (this *Journal) DeleteEntries() error {
@@ -197,9 +304,7 @@ func (this *Journal) DeleteEntries() error {
 		return fmt.Errorf("Journal disabled")
 	}
 
-	this.entries = []JournalEntry{}
-
-	return nil
+	return this.getStore().DeleteAll()
 }
 
 func convertJournalEntries(entries []JournalEntry) []v2.JournalEntryView {
@@ -207,18 +312,22 @@ func convertJournalEntries(entries []JournalEntry) []v2.JournalEntryView {
 	journalEntryViews := []v2.JournalEntryView{}
 
 	for _, journalEntry := range entries {
-		journalEntryViews = append(journalEntryViews, v2.JournalEntryView{
-			Request:     journalEntry.Request.ConvertToRequestDetailsView(),
-			Response:    journalEntry.Response.ConvertToResponseDetailsView(),
-			Mode:        journalEntry.Mode,
-			TimeStarted: journalEntry.TimeStarted.Format(RFC3339Milli),
-			Latency:     journalEntry.Latency.Seconds() * 1e3,
-		})
+		journalEntryViews = append(journalEntryViews, convertJournalEntry(journalEntry))
 	}
 
 	return journalEntryViews
 }
 
+func convertJournalEntry(journalEntry JournalEntry) v2.JournalEntryView {
+	return v2.JournalEntryView{
+		Request:     journalEntry.Request.ConvertToRequestDetailsView(),
+		Response:    journalEntry.Response.ConvertToResponseDetailsView(),
+		Mode:        journalEntry.Mode,
+		TimeStarted: journalEntry.TimeStarted.Format(RFC3339Milli),
+		Latency:     journalEntry.Latency.Seconds() * 1e3,
+	}
+}
+
 func getSortParameters(sort string) (string, string, error) {
 	sortParams := strings.Split(sort, ":")
 
@@ -242,3 +351,123 @@ func getSortParameters(sort string) (string, string, error) {
 
 	return sortKey, sortOrder, nil
 }
+
+// ClosestEntriesLimit is the most entries which are returned as closest to matching when verification fails
+const ClosestEntriesLimit = 3
+
+func newRequestMatcher(view *v2.RequestMatcherViewV5) models.RequestMatcher {
+	return models.RequestMatcher{
+		Path:            models.NewRequestFieldMatchersFromView(view.Path),
+		Method:          models.NewRequestFieldMatchersFromView(view.Method),
+		Destination:     models.NewRequestFieldMatchersFromView(view.Destination),
+		Scheme:          models.NewRequestFieldMatchersFromView(view.Scheme),
+		DeprecatedQuery: models.NewRequestFieldMatchersFromView(view.DeprecatedQuery),
+		Body:            models.NewRequestFieldMatchersFromView(view.Body),
+		Query:           models.NewQueryRequestFieldMatchersFromMapView(view.Query),
+		Headers:         models.NewRequestFieldMatchersFromMapView(view.Headers),
+	}
+}
+
+// matchEntry returns the fields of the entry which were not matched by the request matcher,
+// how many of the fields with matchers were matched and how strongly the entry was matched
+func matchEntry(requestMatcher models.RequestMatcher, entry v2.JournalEntryView) ([]string, int, int) {
+	missedFields := []string{}
+	matchedFields := 0
+	score := 0
+
+	fieldMatches := []struct {
+		field    string
+		hasMatch bool
+		match    *matching.FieldMatch
+	}{
+		{"body", len(requestMatcher.Body) > 0, matching.FieldMatcher(requestMatcher.Body, *entry.Request.Body)},
+		{"destination", len(requestMatcher.Destination) > 0, matching.FieldMatcher(requestMatcher.Destination, *entry.Request.Destination)},
+		{"method", len(requestMatcher.Method) > 0, matching.FieldMatcher(requestMatcher.Method, *entry.Request.Method)},
+		{"path", len(requestMatcher.Path) > 0, matching.FieldMatcher(requestMatcher.Path, *entry.Request.Path)},
+		{"query", len(requestMatcher.DeprecatedQuery) > 0, matching.FieldMatcher(requestMatcher.DeprecatedQuery, *entry.Request.Query)},
+		{"scheme", len(requestMatcher.Scheme) > 0, matching.FieldMatcher(requestMatcher.Scheme, *entry.Request.Scheme)},
+		{"queries", requestMatcher.Query != nil, matching.QueryMatching(requestMatcher, entry.Request.QueryMap)},
+		{"headers", len(requestMatcher.Headers) > 0, matching.HeaderMatching(requestMatcher, entry.Request.Headers)},
+	}
+
+	for _, fieldMatch := range fieldMatches {
+		if !fieldMatch.match.Matched {
+			missedFields = append(missedFields, fieldMatch.field)
+		} else if fieldMatch.hasMatch {
+			matchedFields++
+		}
+		score += fieldMatch.match.Score
+	}
+
+	return missedFields, matchedFields, score
+}
+
+type closestEntry struct {
+	view  v2.JournalClosestEntryView
+	score int
+}
+
+// closestEntries keeps the entries which did not match but missed on the fewest fields,
+// preferring those with the highest score and then those added first. Entries which did not
+// match on any field are left out.
+type closestEntries struct {
+	entries []closestEntry
+}
+
+func newClosestEntries() *closestEntries {
+	return &closestEntries{
+		entries: []closestEntry{},
+	}
+}
+
+// add keeps the entry if it is one of the ClosestEntriesLimit closest entries added so far,
+// given what matchEntry returned for it
+func (this *closestEntries) add(entry v2.JournalEntryView, missedFields []string, matchedFields int, score int) {
+	if len(missedFields) == 0 || matchedFields == 0 {
+		return
+	}
+
+	index := sorting.Search(len(this.entries), func(i int) bool {
+		if len(this.entries[i].view.MissedFields) != len(missedFields) {
+			return len(this.entries[i].view.MissedFields) > len(missedFields)
+		}
+		return this.entries[i].score < score
+	})
+	if index >= ClosestEntriesLimit {
+		return
+	}
+
+	this.entries = append(this.entries, closestEntry{})
+	copy(this.entries[index+1:], this.entries[index:])
+	this.entries[index] = closestEntry{
+		view: v2.JournalClosestEntryView{
+			Entry:        entry,
+			MissedFields: missedFields,
+		},
+		score: score,
+	}
+
+	if len(this.entries) > ClosestEntriesLimit {
+		this.entries = this.entries[:ClosestEntriesLimit]
+	}
+}
+
+func (this *closestEntries) views() []v2.JournalClosestEntryView {
+	closestEntryViews := []v2.JournalClosestEntryView{}
+	for _, entry := range this.entries {
+		closestEntryViews = append(closestEntryViews, entry.view)
+	}
+
+	return closestEntryViews
+}
+
+func countDescription(count int) string {
+	switch count {
+	case 0:
+		return "0 times"
+	case 1:
+		return "once"
+	default:
+		return fmt.Sprintf("%v times", count)
+	}
+}
@@ -389,6 +389,36 @@ func Test_Journal_GetEntries_ReturnPaginationResults(t *testing.T) {
 	Expect(*journalView.Journal[1].Request.Query).To(Equal("id=1"))
 }
 
+func Test_Journal_GetEntries_ReturnPaginationResultsSortedByLatency(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := journal.NewJournal()
+
+	response := &http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewBufferString("test body")),
+	}
+
+	for i := 0; i < 5; i++ {
+		request, _ := http.NewRequest("GET", "http://hoverfly.io/path?id="+strconv.Itoa(i), nil)
+		unit.NewEntry(request, response, "test-mode", time.Now().Add(-time.Duration(i)*time.Hour))
+	}
+
+	journalView, err := unit.GetEntries(1, 2, nil, nil, "latency:desc")
+	Expect(err).To(BeNil())
+	Expect(journalView.Total).To(Equal(5))
+	Expect(journalView.Journal).To(HaveLen(2))
+	Expect(*journalView.Journal[0].Request.Query).To(Equal("id=3"))
+	Expect(*journalView.Journal[1].Request.Query).To(Equal("id=2"))
+
+	journalView, err = unit.GetEntries(3, 25, nil, nil, "latency:asc")
+	Expect(err).To(BeNil())
+	Expect(journalView.Total).To(Equal(5))
+	Expect(journalView.Journal).To(HaveLen(2))
+	Expect(*journalView.Journal[0].Request.Query).To(Equal("id=3"))
+	Expect(*journalView.Journal[1].Request.Query).To(Equal("id=4"))
+}
+
 func Test_Journal_GetEntries_ReturnEmptyPageIfOffsetIsLargerThanTotalElements(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -761,3 +791,140 @@ func Test_Journal_GetFilteredEntries_WillReturnEmptyIfRequestMatcherIsEmpty(t *t
 		Request: &v2.RequestMatcherViewV5{},
 	})).To(HaveLen(0))
 }
+
+func Test_Journal_VerifyEntries_PassesWhenTheCountIsMet(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := journal.NewJournal()
+
+	for _, path := range []string{"/one", "/two", "/one"} {
+		request, _ := http.NewRequest("POST", "http://hoverfly.io"+path, bytes.NewBufferString("body"))
+		unit.NewEntry(request, &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewBufferString("test body")),
+		}, "test-mode", time.Now())
+	}
+
+	exactly := 2
+	result, err := unit.VerifyEntries(v2.JournalVerifyView{
+		Request: &v2.RequestMatcherViewV5{
+			Path: []v2.MatcherViewV5{
+				v2.NewMatcherView(matchers.Exact, "/one"),
+			},
+		},
+		Count: &v2.CountConstraintView{
+			Exactly: &exactly,
+		},
+	})
+	Expect(err).To(BeNil())
+
+	Expect(result.Passed).To(BeTrue())
+	Expect(result.Count).To(Equal(2))
+	Expect(result.Message).To(Equal("Request was made 2 times, as expected"))
+	Expect(result.Matches).To(HaveLen(2))
+	Expect(*result.Matches[0].Request.Path).To(Equal("/one"))
+	Expect(result.ClosestEntries).To(BeNil())
+
+	result, err = unit.VerifyEntries(v2.JournalVerifyView{
+		Request: &v2.RequestMatcherViewV5{
+			Path: []v2.MatcherViewV5{
+				v2.NewMatcherView(matchers.Exact, "/three"),
+			},
+		},
+		Count: &v2.CountConstraintView{
+			Never: true,
+		},
+	})
+	Expect(err).To(BeNil())
+	Expect(result.Passed).To(BeTrue())
+	Expect(result.Matches).To(BeEmpty())
+}
+
+func Test_Journal_VerifyEntries_MatchesEveryEntryIfRequestMatcherIsEmpty(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := journal.NewJournal()
+
+	for _, path := range []string{"/one", "/two", "/three"} {
+		request, _ := http.NewRequest("GET", "http://hoverfly.io"+path, nil)
+		unit.NewEntry(request, &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewBufferString("test body")),
+		}, "test-mode", time.Now())
+	}
+
+	exactly := 3
+	result, err := unit.VerifyEntries(v2.JournalVerifyView{
+		Request: &v2.RequestMatcherViewV5{},
+		Count: &v2.CountConstraintView{
+			Exactly: &exactly,
+		},
+	})
+	Expect(err).To(BeNil())
+
+	Expect(result.Passed).To(BeTrue())
+	Expect(result.Count).To(Equal(3))
+	Expect(result.Matches).To(HaveLen(3))
+}
+
+func Test_Journal_VerifyEntries_ReturnsTheClosestEntriesWhenTheCountIsNotMet(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := journal.NewJournal()
+
+	for _, path := range []string{"/one", "/two", "/one"} {
+		request, _ := http.NewRequest("POST", "http://hoverfly.io"+path, bytes.NewBufferString("body"))
+		unit.NewEntry(request, &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewBufferString("test body")),
+		}, "test-mode", time.Now())
+	}
+
+	request, _ := http.NewRequest("GET", "http://other.io/three", nil)
+	unit.NewEntry(request, &http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewBufferString("test body")),
+	}, "test-mode", time.Now())
+
+	result, err := unit.VerifyEntries(v2.JournalVerifyView{
+		Request: &v2.RequestMatcherViewV5{
+			Method: []v2.MatcherViewV5{
+				v2.NewMatcherView(matchers.Exact, "POST"),
+			},
+			Destination: []v2.MatcherViewV5{
+				v2.NewMatcherView(matchers.Exact, "hoverfly.io"),
+			},
+			Path: []v2.MatcherViewV5{
+				v2.NewMatcherView(matchers.Exact, "/two"),
+			},
+			Body: []v2.MatcherViewV5{
+				v2.NewMatcherView(matchers.Exact, "other body"),
+			},
+		},
+	})
+	Expect(err).To(BeNil())
+
+	Expect(result.Passed).To(BeFalse())
+	Expect(result.Count).To(Equal(0))
+	Expect(result.Message).To(Equal("Expected request to be made at least 1 time, but it was made 0 times"))
+	Expect(result.Matches).To(BeEmpty())
+
+	Expect(result.ClosestEntries).To(HaveLen(3))
+	Expect(*result.ClosestEntries[0].Entry.Request.Path).To(Equal("/two"))
+	Expect(result.ClosestEntries[0].MissedFields).To(Equal([]string{"body"}))
+	Expect(*result.ClosestEntries[1].Entry.Request.Path).To(Equal("/one"))
+	Expect(result.ClosestEntries[1].MissedFields).To(Equal([]string{"body", "path"}))
+	Expect(*result.ClosestEntries[2].Entry.Request.Path).To(Equal("/one"))
+}
+
+func Test_Journal_VerifyEntries_WhenDisabledReturnsError(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := journal.NewJournal()
+	unit.EntryLimit = 0
+
+	_, err := unit.VerifyEntries(v2.JournalVerifyView{
+		Request: &v2.RequestMatcherViewV5{},
+	})
+	Expect(err).To(MatchError("Journal disabled"))
+}
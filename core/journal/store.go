@@ -0,0 +1,124 @@
+package journal
+
+import (
+	"sync"
+)
+
+// Store holds the entries of a journal, oldest first. Stores can hold more entries than fit
+// in memory, so they are read one at a time with ForEach, which stops once fn returns false.
+type Store interface {
+	Add(entry JournalEntry) error
+	ForEach(fn func(entry JournalEntry) bool) error
+	DeleteAll() error
+}
+
+// RingBufferStore keeps a fixed number of entries in memory, overwriting the oldest
+// entry once it is full
+type RingBufferStore struct {
+	mu      sync.RWMutex
+	entries []JournalEntry
+	start   int
+	count   int
+}
+
+// NewRingBufferStore - returns new ring buffer store which holds up to capacity entries
+func NewRingBufferStore(capacity int) *RingBufferStore {
+	if capacity < 0 {
+		capacity = 0
+	}
+
+	return &RingBufferStore{
+		entries: make([]JournalEntry, capacity),
+	}
+}
+
+func (this *RingBufferStore) Add(entry JournalEntry) error {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	capacity := len(this.entries)
+	if capacity == 0 {
+		return nil
+	}
+
+	if this.count < capacity {
+		this.entries[(this.start+this.count)%capacity] = entry
+		this.count++
+	} else {
+		this.entries[this.start] = entry
+		this.start = (this.start + 1) % capacity
+	}
+
+	return nil
+}
+
+func (this *RingBufferStore) GetAll() ([]JournalEntry, error) {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	return this.getAll(), nil
+}
+
+func (this *RingBufferStore) ForEach(fn func(entry JournalEntry) bool) error {
+	entries, _ := this.GetAll()
+	for _, entry := range entries {
+		if !fn(entry) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (this *RingBufferStore) DeleteAll() error {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	this.entries = make([]JournalEntry, len(this.entries))
+	this.start = 0
+	this.count = 0
+
+	return nil
+}
+
+// Capacity returns the number of entries the store can hold
+func (this *RingBufferStore) Capacity() int {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	return len(this.entries)
+}
+
+// SetCapacity changes the number of entries the store can hold, keeping the newest entries
+// if there are more than will fit
+func (this *RingBufferStore) SetCapacity(capacity int) {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	if capacity < 0 {
+		capacity = 0
+	}
+
+	if capacity == len(this.entries) {
+		return
+	}
+
+	entries := this.getAll()
+	if len(entries) > capacity {
+		entries = entries[len(entries)-capacity:]
+	}
+
+	this.entries = make([]JournalEntry, capacity)
+	copy(this.entries, entries)
+	this.start = 0
+	this.count = len(entries)
+}
+
+func (this *RingBufferStore) getAll() []JournalEntry {
+	entries := make([]JournalEntry, this.count)
+	for i := 0; i < this.count; i++ {
+		entries[i] = this.entries[(this.start+i)%len(this.entries)]
+	}
+
+	return entries
+}
@@ -0,0 +1,465 @@
+package journal_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/journal"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/boltdb/bolt"
+	. "github.com/onsi/gomega"
+)
+
+func newTestEntry(mode string, started time.Time) journal.JournalEntry {
+	return journal.JournalEntry{
+		Request: &models.RequestDetails{
+			Method:      "GET",
+			Scheme:      "http",
+			Destination: "hoverfly.io",
+			Path:        "/",
+			Query:       map[string][]string{"q": {"1"}},
+			Headers:     map[string][]string{"Accept": {"text/plain"}},
+		},
+		Response: &models.ResponseDetails{
+			Status:  200,
+			Body:    "test body",
+			Headers: map[string][]string{"test-header": {"one", "two"}},
+		},
+		Mode:        mode,
+		TimeStarted: started,
+		Latency:     3 * time.Millisecond,
+	}
+}
+
+func getModes(entries []journal.JournalEntry) []string {
+	modes := []string{}
+	for _, entry := range entries {
+		modes = append(modes, entry.Mode)
+	}
+	return modes
+}
+
+func readAll(store journal.Store) ([]journal.JournalEntry, error) {
+	entries := []journal.JournalEntry{}
+	err := store.ForEach(func(entry journal.JournalEntry) bool {
+		entries = append(entries, entry)
+		return true
+	})
+	return entries, err
+}
+
+func newTempDir() string {
+	dir, _ := ioutil.TempDir("", "hoverfly-journal")
+	return dir
+}
+
+func Test_RingBufferStore_KeepsTheNewestEntriesInOrder(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := journal.NewRingBufferStore(3)
+
+	for i := 1; i <= 5; i++ {
+		Expect(unit.Add(newTestEntry(strconv.Itoa(i), time.Now()))).To(Succeed())
+	}
+
+	entries, err := unit.GetAll()
+	Expect(err).To(BeNil())
+	Expect(getModes(entries)).To(Equal([]string{"3", "4", "5"}))
+}
+
+func Test_RingBufferStore_SetCapacity_KeepsTheNewestEntries(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := journal.NewRingBufferStore(5)
+	for i := 1; i <= 7; i++ {
+		unit.Add(newTestEntry(strconv.Itoa(i), time.Now()))
+	}
+
+	unit.SetCapacity(2)
+	Expect(unit.Capacity()).To(Equal(2))
+
+	entries, _ := unit.GetAll()
+	Expect(getModes(entries)).To(Equal([]string{"6", "7"}))
+
+	unit.SetCapacity(4)
+	unit.Add(newTestEntry("8", time.Now()))
+
+	entries, _ = unit.GetAll()
+	Expect(getModes(entries)).To(Equal([]string{"6", "7", "8"}))
+}
+
+func Test_RingBufferStore_DeleteAll_RemovesEveryEntry(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := journal.NewRingBufferStore(3)
+	unit.Add(newTestEntry("1", time.Now()))
+
+	Expect(unit.DeleteAll()).To(Succeed())
+
+	entries, _ := unit.GetAll()
+	Expect(entries).To(BeEmpty())
+}
+
+func Test_FileStore_WritesEntriesAsJsonLinesWhichCanBeReadBack(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir := newTempDir()
+	defer os.RemoveAll(dir)
+
+	started := time.Date(2018, 1, 2, 3, 4, 5, 6000000, time.UTC)
+
+	unit, err := journal.NewFileStore(path.Join(dir, "journal.jsonl"), 0, 0, 0)
+	Expect(err).To(BeNil())
+	Expect(unit.Add(newTestEntry("simulate", started))).To(Succeed())
+	Expect(unit.Add(newTestEntry("capture", started))).To(Succeed())
+
+	contents, _ := ioutil.ReadFile(path.Join(dir, "journal.jsonl"))
+	Expect(bytes.Count(contents, []byte("\n"))).To(Equal(2))
+
+	entries, err := readAll(unit)
+	Expect(err).To(BeNil())
+	Expect(entries).To(HaveLen(2))
+	Expect(entries[0]).To(Equal(newTestEntry("simulate", started)))
+	Expect(entries[1].Mode).To(Equal("capture"))
+}
+
+func Test_FileStore_AppendsToAnExistingFile(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir := newTempDir()
+	defer os.RemoveAll(dir)
+
+	unit, _ := journal.NewFileStore(path.Join(dir, "journal.jsonl"), 0, 0, 0)
+	unit.Add(newTestEntry("1", time.Now()))
+	unit.Close()
+
+	unit, err := journal.NewFileStore(path.Join(dir, "journal.jsonl"), 0, 0, 0)
+	Expect(err).To(BeNil())
+	unit.Add(newTestEntry("2", time.Now()))
+
+	entries, _ := readAll(unit)
+	Expect(getModes(entries)).To(Equal([]string{"1", "2"}))
+}
+
+func Test_FileStore_RotatesOnSize(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir := newTempDir()
+	defer os.RemoveAll(dir)
+
+	// Two entries fit in each file. The time is fixed, as the length of a marshalled time varies.
+	started := time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)
+	line, _ := json.Marshal(newTestEntry("1", started))
+	unit, _ := journal.NewFileStore(path.Join(dir, "journal.jsonl"), int64(2*(len(line)+1)), 0, 0)
+	for i := 1; i <= 5; i++ {
+		Expect(unit.Add(newTestEntry(strconv.Itoa(i), started))).To(Succeed())
+	}
+
+	Expect(path.Join(dir, "journal.jsonl.1")).To(BeAnExistingFile())
+	Expect(path.Join(dir, "journal.jsonl.2")).To(BeAnExistingFile())
+	Expect(path.Join(dir, "journal.jsonl.3")).ToNot(BeAnExistingFile())
+
+	entries, err := readAll(unit)
+	Expect(err).To(BeNil())
+	Expect(getModes(entries)).To(Equal([]string{"1", "2", "3", "4", "5"}))
+}
+
+func Test_FileStore_RotatesOnAge(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir := newTempDir()
+	defer os.RemoveAll(dir)
+
+	unit, _ := journal.NewFileStore(path.Join(dir, "journal.jsonl"), 0, 50*time.Millisecond, 0)
+	unit.Add(newTestEntry("1", time.Now()))
+	unit.Add(newTestEntry("2", time.Now()))
+	Expect(path.Join(dir, "journal.jsonl.1")).ToNot(BeAnExistingFile())
+
+	time.Sleep(60 * time.Millisecond)
+	unit.Add(newTestEntry("3", time.Now()))
+	Expect(path.Join(dir, "journal.jsonl.1")).To(BeAnExistingFile())
+
+	entries, _ := readAll(unit)
+	Expect(getModes(entries)).To(Equal([]string{"1", "2", "3"}))
+}
+
+func Test_FileStore_KeepsOnlyTheMaximumNumberOfBackups(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir := newTempDir()
+	defer os.RemoveAll(dir)
+
+	unit, _ := journal.NewFileStore(path.Join(dir, "journal.jsonl"), 1, 0, 2)
+	for i := 1; i <= 5; i++ {
+		unit.Add(newTestEntry(strconv.Itoa(i), time.Now()))
+	}
+
+	Expect(path.Join(dir, "journal.jsonl.2")).To(BeAnExistingFile())
+	Expect(path.Join(dir, "journal.jsonl.3")).ToNot(BeAnExistingFile())
+
+	entries, _ := readAll(unit)
+	Expect(getModes(entries)).To(Equal([]string{"3", "4", "5"}))
+}
+
+func Test_FileStore_SkipsIncompleteLines(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir := newTempDir()
+	defer os.RemoveAll(dir)
+
+	unit, _ := journal.NewFileStore(path.Join(dir, "journal.jsonl"), 0, 0, 0)
+	unit.Add(newTestEntry("1", time.Now()))
+	unit.Close()
+
+	file, _ := os.OpenFile(path.Join(dir, "journal.jsonl"), os.O_WRONLY|os.O_APPEND, 0600)
+	file.WriteString(`{"request":{"Method":"GE`)
+	file.Close()
+
+	unit, _ = journal.NewFileStore(path.Join(dir, "journal.jsonl"), 0, 0, 0)
+	unit.Add(newTestEntry("2", time.Now()))
+
+	entries, err := readAll(unit)
+	Expect(err).To(BeNil())
+	Expect(getModes(entries)).To(Equal([]string{"1", "2"}))
+}
+
+func Test_FileStore_DeleteAll_RemovesEveryEntryAndBackup(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir := newTempDir()
+	defer os.RemoveAll(dir)
+
+	unit, _ := journal.NewFileStore(path.Join(dir, "journal.jsonl"), 1, 0, 0)
+	unit.Add(newTestEntry("1", time.Now()))
+	unit.Add(newTestEntry("2", time.Now()))
+
+	Expect(unit.DeleteAll()).To(Succeed())
+	Expect(path.Join(dir, "journal.jsonl.1")).ToNot(BeAnExistingFile())
+
+	entries, _ := readAll(unit)
+	Expect(entries).To(BeEmpty())
+
+	unit.Add(newTestEntry("3", time.Now()))
+	entries, _ = readAll(unit)
+	Expect(getModes(entries)).To(Equal([]string{"3"}))
+}
+
+func Test_BoltDBStore_KeepsEntriesInOrder(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir := newTempDir()
+	defer os.RemoveAll(dir)
+
+	db, err := bolt.Open(path.Join(dir, "journal.db"), 0600, nil)
+	Expect(err).To(BeNil())
+	defer db.Close()
+
+	started := time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	unit := journal.NewBoltDBStore(db, []byte("journal"))
+
+	entries, err := readAll(unit)
+	Expect(err).To(BeNil())
+	Expect(entries).To(BeEmpty())
+
+	for i := 1; i <= 12; i++ {
+		Expect(unit.Add(newTestEntry(strconv.Itoa(i), started))).To(Succeed())
+	}
+
+	entries, err = readAll(unit)
+	Expect(err).To(BeNil())
+	Expect(entries).To(HaveLen(12))
+	Expect(entries[0]).To(Equal(newTestEntry("1", started)))
+	Expect(entries[11].Mode).To(Equal("12"))
+
+	Expect(unit.DeleteAll()).To(Succeed())
+
+	entries, _ = readAll(unit)
+	Expect(entries).To(BeEmpty())
+}
+
+func Test_Journal_WithFileStore_GetEntriesAndGetFilteredEntriesReadFromTheStore(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir := newTempDir()
+	defer os.RemoveAll(dir)
+
+	store, _ := journal.NewFileStore(path.Join(dir, "journal.jsonl"), 0, 0, 0)
+
+	unit := journal.NewJournal()
+	unit.SetStore(store)
+
+	request, _ := http.NewRequest("GET", "http://hoverfly.io/path?q=1", nil)
+	for i := 1; i <= 3; i++ {
+		err := unit.NewEntry(request, &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewBufferString("test body")),
+			Header:     http.Header{},
+		}, strconv.Itoa(i), time.Now())
+		Expect(err).To(BeNil())
+	}
+
+	journalView, err := unit.GetEntries(1, 25, nil, nil, "timestarted:desc")
+	Expect(err).To(BeNil())
+	Expect(journalView.Total).To(Equal(3))
+	Expect(journalView.Journal).To(HaveLen(2))
+	Expect(journalView.Journal[0].Mode).To(Equal("2"))
+	Expect(*journalView.Journal[0].Request.Query).To(Equal("q=1"))
+	Expect(journalView.Journal[0].Response.Body).To(Equal("test body"))
+
+	Expect(unit.DeleteEntries()).To(Succeed())
+
+	journalView, _ = unit.GetEntries(0, 25, nil, nil, "")
+	Expect(journalView.Journal).To(BeEmpty())
+}
+
+func Test_FileStore_ForEach_StopsWhenFnReturnsFalse(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir := newTempDir()
+	defer os.RemoveAll(dir)
+
+	unit, _ := journal.NewFileStore(path.Join(dir, "journal.jsonl"), 1, 0, 0)
+
+	for i := 1; i <= 4; i++ {
+		Expect(unit.Add(newTestEntry(strconv.Itoa(i), time.Now()))).To(Succeed())
+	}
+
+	modes := []string{}
+	err := unit.ForEach(func(entry journal.JournalEntry) bool {
+		modes = append(modes, entry.Mode)
+		return len(modes) < 2
+	})
+
+	Expect(err).To(BeNil())
+	Expect(modes).To(Equal([]string{"1", "2"}))
+}
+
+func Test_FileStore_ForEach_CanAddEntriesWhileReading(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir := newTempDir()
+	defer os.RemoveAll(dir)
+
+	unit, _ := journal.NewFileStore(path.Join(dir, "journal.jsonl"), 0, 0, 0)
+	Expect(unit.Add(newTestEntry("1", time.Now()))).To(Succeed())
+
+	modes := []string{}
+	err := unit.ForEach(func(entry journal.JournalEntry) bool {
+		modes = append(modes, entry.Mode)
+		Expect(unit.Add(newTestEntry("2", time.Now()))).To(Succeed())
+		return true
+	})
+
+	Expect(err).To(BeNil())
+	Expect(modes).To(Equal([]string{"1"}))
+
+	entries, _ := readAll(unit)
+	Expect(getModes(entries)).To(Equal([]string{"1", "2"}))
+}
+
+func Test_Journal_WithFileStore_PagesThroughEveryEntryBeyondTheEntryLimit(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir := newTempDir()
+	defer os.RemoveAll(dir)
+
+	store, _ := journal.NewFileStore(path.Join(dir, "journal.jsonl"), 1, 0, 0)
+
+	unit := journal.NewJournal()
+	unit.EntryLimit = 2
+	unit.SetStore(store)
+
+	request, _ := http.NewRequest("GET", "http://hoverfly.io/path", nil)
+	for i := 1; i <= 5; i++ {
+		err := unit.NewEntry(request, &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewBufferString("test body")),
+			Header:     http.Header{},
+		}, strconv.Itoa(i), time.Date(2018, 2, 1, 2, 0, i, 0, time.UTC))
+		Expect(err).To(BeNil())
+	}
+
+	journalView, err := unit.GetEntries(0, 3, nil, nil, "")
+	Expect(err).To(BeNil())
+	Expect(journalView.Total).To(Equal(5))
+	Expect(journalView.Journal).To(HaveLen(3))
+	Expect(journalView.Journal[0].Mode).To(Equal("1"))
+	Expect(journalView.Journal[2].Mode).To(Equal("3"))
+
+	journalView, err = unit.GetEntries(3, 3, nil, nil, "")
+	Expect(err).To(BeNil())
+	Expect(journalView.Total).To(Equal(5))
+	Expect(journalView.Journal).To(HaveLen(2))
+	Expect(journalView.Journal[0].Mode).To(Equal("4"))
+	Expect(journalView.Journal[1].Mode).To(Equal("5"))
+
+	journalView, err = unit.GetEntries(3, 3, nil, nil, "timestarted:desc")
+	Expect(err).To(BeNil())
+	Expect(journalView.Total).To(Equal(5))
+	Expect(journalView.Journal).To(HaveLen(2))
+	Expect(journalView.Journal[0].Mode).To(Equal("2"))
+	Expect(journalView.Journal[1].Mode).To(Equal("1"))
+
+	filteredEntries, err := unit.GetFilteredEntries(v2.JournalEntryFilterView{
+		Request: &v2.RequestMatcherViewV5{
+			Path: []v2.MatcherViewV5{
+				v2.NewMatcherView(matchers.Exact, "/path"),
+			},
+		},
+	})
+	Expect(err).To(BeNil())
+	Expect(filteredEntries).To(HaveLen(5))
+}
+
+func Test_Journal_WithFileStore_VerifyEntriesCountsEveryEntryButReturnsOnlyTheNewestUpToTheEntryLimit(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir := newTempDir()
+	defer os.RemoveAll(dir)
+
+	store, _ := journal.NewFileStore(path.Join(dir, "journal.jsonl"), 0, 0, 0)
+
+	unit := journal.NewJournal()
+	unit.EntryLimit = 2
+	unit.SetStore(store)
+
+	request, _ := http.NewRequest("GET", "http://hoverfly.io/path", nil)
+	for i := 1; i <= 5; i++ {
+		err := unit.NewEntry(request, &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewBufferString("test body")),
+			Header:     http.Header{},
+		}, strconv.Itoa(i), time.Now())
+		Expect(err).To(BeNil())
+	}
+
+	exactly := 5
+	result, err := unit.VerifyEntries(v2.JournalVerifyView{
+		Request: &v2.RequestMatcherViewV5{
+			Path: []v2.MatcherViewV5{
+				v2.NewMatcherView(matchers.Exact, "/path"),
+			},
+		},
+		Count: &v2.CountConstraintView{
+			Exactly: &exactly,
+		},
+	})
+	Expect(err).To(BeNil())
+
+	Expect(result.Passed).To(BeTrue())
+	Expect(result.Count).To(Equal(5))
+	Expect(result.Message).To(Equal("Request was made 5 times, as expected"))
+	Expect(result.Matches).To(HaveLen(2))
+	Expect(result.Matches[0].Mode).To(Equal("4"))
+	Expect(result.Matches[1].Mode).To(Equal("5"))
+}
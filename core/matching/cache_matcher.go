@@ -1,6 +1,8 @@
 package matching
 
 import (
+	"sync/atomic"
+
 	log "github.com/sirupsen/logrus"
 	"github.com/SpectoLabs/hoverfly/core/cache"
 	"github.com/SpectoLabs/hoverfly/core/errors"
@@ -11,6 +13,9 @@ import (
 type CacheMatcher struct {
 	Webserver    bool
 	RequestCache cache.FastCache
+
+	hits   int64
+	misses int64
 }
 
 // getResponse returns stored response from cache
@@ -32,6 +37,8 @@ func (this *CacheMatcher) GetCachedResponse(req *models.RequestDetails) (*models
 	cachedResponse, found := this.RequestCache.Get(key)
 
 	if !found {
+		atomic.AddInt64(&this.misses, 1)
+
 		log.WithFields(log.Fields{
 			"key":         key,
 			"query":       req.Query,
@@ -51,10 +58,18 @@ func (this *CacheMatcher) GetCachedResponse(req *models.RequestDetails) (*models
 		"destination": req.Destination,
 	}).Info("Response found interface{} cache")
 
+	atomic.AddInt64(&this.hits, 1)
+
 	response := cachedResponse.(*models.CachedResponse)
 	return response, nil
 }
 
+// GetCacheStats returns the number of lookups which found a response in the cache, and
+// the number which did not
+func (this *CacheMatcher) GetCacheStats() (hits int64, misses int64) {
+	return atomic.LoadInt64(&this.hits), atomic.LoadInt64(&this.misses)
+}
+
 func (this *CacheMatcher) GetAllResponses() (v2.CacheView, error) {
 	cacheView := v2.CacheView{}
 
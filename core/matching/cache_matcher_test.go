@@ -341,3 +341,22 @@ func Test_CacheMatcher_PreloadCache_WillNotCacheMatchersWithHeaders(t *testing.T
 	Expect(err).To(BeNil())
 	Expect(unit.RequestCache.RecordsCount()).To(Equal(0))
 }
+
+func Test_CacheMatcher_GetCacheStats_CountsHitsAndMisses(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := matching.CacheMatcher{
+		RequestCache: cache.NewDefaultLRUCache(),
+	}
+
+	_, err := unit.SaveRequestMatcherResponsePair(models.RequestDetails{Path: "/cached"}, nil, nil)
+	Expect(err).To(BeNil())
+
+	unit.GetCachedResponse(&models.RequestDetails{Path: "/cached"})
+	unit.GetCachedResponse(&models.RequestDetails{Path: "/cached"})
+	unit.GetCachedResponse(&models.RequestDetails{Path: "/not-cached"})
+
+	hits, misses := unit.GetCacheStats()
+	Expect(hits).To(Equal(int64(2)))
+	Expect(misses).To(Equal(int64(1)))
+}
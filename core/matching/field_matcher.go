@@ -15,8 +15,11 @@ func FieldMatcher(fields []models.RequestFieldMatchers, toMatch string) *FieldMa
 	}
 
 	for _, field := range fields {
-		if matchers.Matchers[strings.ToLower(field.Matcher)](field.Value, toMatch) {
-			if field.Matcher == matchers.Exact {
+		matched := matchers.Matchers[strings.ToLower(field.Matcher)](field.Value, toMatch, field.Config)
+		negated := matchers.IsConfigEnabled(field.Config, matchers.Negate)
+
+		if matched != negated {
+			if field.Matcher == matchers.Exact && !negated {
 				fieldMatch.Score = fieldMatch.Score + 2
 			} else {
 				fieldMatch.Score = fieldMatch.Score + 1
@@ -155,6 +155,65 @@ var fieldMatcherTests = []fieldMatcherTest{
 		toMatch: `<document></document>`,
 		equals:  BeTrue(),
 	},
+	{
+		name: "MatchesFalseWithNegatedExactMatch",
+		matchers: []models.RequestFieldMatchers{
+			{
+				Matcher: matchers.Exact,
+				Value:   "/health",
+				Config: map[string]interface{}{
+					"negate": true,
+				},
+			},
+		},
+		toMatch: "/health",
+		equals:  BeFalse(),
+	},
+	{
+		name: "MatchesTrueWithNegatedExactMatchAndScoresLikeOtherMatchers",
+		matchers: []models.RequestFieldMatchers{
+			{
+				Matcher: matchers.Exact,
+				Value:   "/health",
+				Config: map[string]interface{}{
+					"negate": true,
+				},
+			},
+		},
+		toMatch:     "/api/users",
+		equals:      BeTrue(),
+		scoreEquals: Equal(1),
+	},
+	{
+		name: "MatchesTrueWithNegatedRegexMatch",
+		matchers: []models.RequestFieldMatchers{
+			{
+				Matcher: matchers.Regex,
+				Value:   "^/admin",
+				Config: map[string]interface{}{
+					"negate":     true,
+					"ignoreCase": true,
+				},
+			},
+		},
+		toMatch: "/users",
+		equals:  BeTrue(),
+	},
+	{
+		name: "MatchesFalseWithNegatedRegexMatchIgnoringCase",
+		matchers: []models.RequestFieldMatchers{
+			{
+				Matcher: matchers.Regex,
+				Value:   "^/admin",
+				Config: map[string]interface{}{
+					"negate":     true,
+					"ignoreCase": true,
+				},
+			},
+		},
+		toMatch: "/ADMIN/users",
+		equals:  BeFalse(),
+	},
 }
 
 func Test_FieldMatcher(t *testing.T) {
@@ -0,0 +1,63 @@
+package matchers
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Options which can be set in the config block of a matcher
+var (
+	IgnoreCase          = "ignoreCase"
+	Negate              = "negate"
+	IgnoreWhitespace    = "ignoreWhitespace"
+	IgnoreUnknownFields = "ignoreUnknownFields"
+)
+
+// SupportedConfig lists the config options each matcher understands. Negate is
+// applied to the result of a matcher, so every matcher supports it.
+var SupportedConfig = map[string][]string{
+	"":           {IgnoreCase, IgnoreWhitespace, Negate},
+	Exact:        {IgnoreCase, IgnoreWhitespace, Negate},
+	Glob:         {IgnoreCase, IgnoreWhitespace, Negate},
+	Regex:        {IgnoreCase, IgnoreWhitespace, Negate},
+	PathTemplate: {IgnoreCase, Negate},
+	Json:         {IgnoreUnknownFields, Negate},
+	JsonPath:     {Negate},
+	JsonPartial:  {Negate},
+	JsonSchema:   {Negate},
+	Xml:          {Negate},
+	Xpath:        {Negate},
+	Form:         {Negate},
+	Multipart:    {Negate},
+}
+
+// IsConfigSupported returns true if the given option can be used with the matcher
+func IsConfigSupported(matcher, option string) bool {
+	for _, supported := range SupportedConfig[strings.ToLower(matcher)] {
+		if supported == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsConfigEnabled returns true if the option is set to true in the config
+func IsConfigEnabled(config map[string]interface{}, option string) bool {
+	enabled, ok := config[option].(bool)
+	return ok && enabled
+}
+
+// applyStringConfig trims the whitespace and lowers the case of a value
+// when ignoreWhitespace and ignoreCase are enabled
+func applyStringConfig(value string, config map[string]interface{}) string {
+	if IsConfigEnabled(config, IgnoreWhitespace) {
+		value = strings.TrimFunc(value, unicode.IsSpace)
+	}
+
+	if IsConfigEnabled(config, IgnoreCase) {
+		value = strings.ToLower(value)
+	}
+
+	return value
+}
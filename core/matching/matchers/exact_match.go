@@ -2,11 +2,11 @@ package matchers
 
 var Exact = "exact"
 
-func ExactMatch(match interface{}, toMatch string) bool {
+func ExactMatch(match interface{}, toMatch string, config map[string]interface{}) bool {
 	matchString, ok := match.(string)
 	if !ok {
 		return false
 	}
 
-	return matchString == toMatch
+	return applyStringConfig(matchString, config) == applyStringConfig(toMatch, config)
 }
@@ -10,25 +10,25 @@ import (
 func Test_ExactMatch_MatchesFalseWithIncorrectDataType(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.ExactMatch(1, "yes")).To(BeFalse())
+	Expect(matchers.ExactMatch(1, "yes", nil)).To(BeFalse())
 }
 
 func Test_ExactMatch_MatchesTrueWithExactMatch(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.ExactMatch("yes", "yes")).To(BeTrue())
+	Expect(matchers.ExactMatch("yes", "yes", nil)).To(BeTrue())
 }
 
 func Test_ExactMatch_MatchesFalseWithIncorrectExactMatch(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.ExactMatch("yes", "no")).To(BeFalse())
+	Expect(matchers.ExactMatch("yes", "no", nil)).To(BeFalse())
 }
 
 func Test_ExactMatch_MatchesTrueWithJSON(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.ExactMatch(`{"test":{"json":true,"minified":true}}`, `{"test":{"json":true,"minified":true}}`)).To(BeTrue())
+	Expect(matchers.ExactMatch(`{"test":{"json":true,"minified":true}}`, `{"test":{"json":true,"minified":true}}`, nil)).To(BeTrue())
 }
 
 func Test_ExactMatch_MatchesTrueWithUnminifiedJSON(t *testing.T) {
@@ -39,5 +39,30 @@ func Test_ExactMatch_MatchesTrueWithUnminifiedJSON(t *testing.T) {
 			"json": true,
 			"minified": true
 		}
-	}`)).To(BeFalse())
+	}`, nil)).To(BeFalse())
+}
+
+func Test_ExactMatch_MatchesTrueWithIgnoreCase(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.ExactMatch("Yes", "yES", map[string]interface{}{
+		"ignoreCase": true,
+	})).To(BeTrue())
+}
+
+func Test_ExactMatch_MatchesTrueWithIgnoreWhitespace(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.ExactMatch("yes", " yes\n", map[string]interface{}{
+		"ignoreWhitespace": true,
+	})).To(BeTrue())
+}
+
+func Test_ExactMatch_MatchesFalseWithConfigDisabled(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.ExactMatch("Yes", " yes", map[string]interface{}{
+		"ignoreCase":       false,
+		"ignoreWhitespace": false,
+	})).To(BeFalse())
 }
@@ -0,0 +1,75 @@
+package matchers
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+var Form = "form"
+
+// fieldMatcher is one of the matchers for a field of a form or multipart body.
+// The values of these matchers are maps of field names to lists of these.
+type fieldMatcher struct {
+	Matcher string                 `json:"matcher"`
+	Value   interface{}            `json:"value"`
+	Config  map[string]interface{} `json:"config,omitempty"`
+}
+
+func FormMatch(match interface{}, toMatch string, config map[string]interface{}) bool {
+	form, err := url.ParseQuery(toMatch)
+	if err != nil {
+		return false
+	}
+
+	return fieldsMatch(match, form)
+}
+
+// fieldsMatch matches each field with its matchers, in the same way that query
+// parameters are matched. Fields without matchers are ignored.
+func fieldsMatch(match interface{}, fields map[string][]string) bool {
+	fieldMatchers, ok := toFieldMatchers(match)
+	if !ok {
+		return false
+	}
+
+	for key, matchers := range fieldMatchers {
+		values, found := fields[key]
+		if !found {
+			return false
+		}
+
+		for _, matcher := range matchers {
+			matcherFunc, ok := Matchers[strings.ToLower(matcher.Matcher)]
+			if !ok {
+				return false
+			}
+
+			if matcherFunc(matcher.Value, strings.Join(values, ";"), matcher.Config) == IsConfigEnabled(matcher.Config, Negate) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// toFieldMatchers converts the matcher value, which is a plain map when it comes
+// from a simulation and a typed map when it is built by Hoverfly
+func toFieldMatchers(match interface{}) (map[string][]fieldMatcher, bool) {
+	if match == nil {
+		return nil, false
+	}
+
+	matchBytes, err := json.Marshal(match)
+	if err != nil {
+		return nil, false
+	}
+
+	var fieldMatchers map[string][]fieldMatcher
+	if err := json.Unmarshal(matchBytes, &fieldMatchers); err != nil {
+		return nil, false
+	}
+
+	return fieldMatchers, true
+}
@@ -0,0 +1,88 @@
+package matchers_test
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func Test_FormMatch_MatchesFalseWithIncorrectDataType(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.FormMatch("name=Kevin", "name=Kevin", nil)).To(BeFalse())
+}
+
+func Test_FormMatch_MatchesTrueWhenAllFieldsMatchInAnyOrder(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.FormMatch(map[string]interface{}{
+		"name": []interface{}{
+			map[string]interface{}{
+				"matcher": "exact",
+				"value":   "Kevin",
+			},
+		},
+		"email": []interface{}{
+			map[string]interface{}{
+				"matcher": "glob",
+				"value":   "*@test.com",
+			},
+		},
+	}, "email=kevin%40test.com&name=Kevin&age=30", nil)).To(BeTrue())
+}
+
+func Test_FormMatch_MatchesFalseWhenAFieldDoesNotMatch(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.FormMatch(map[string]interface{}{
+		"name": []interface{}{
+			map[string]interface{}{
+				"matcher": "exact",
+				"value":   "Kevin",
+			},
+		},
+	}, "name=Bob", nil)).To(BeFalse())
+}
+
+func Test_FormMatch_MatchesFalseWhenAFieldIsMissing(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.FormMatch(map[string]interface{}{
+		"name": []interface{}{
+			map[string]interface{}{
+				"matcher": "exact",
+				"value":   "Kevin",
+			},
+		},
+	}, "age=30", nil)).To(BeFalse())
+}
+
+func Test_FormMatch_MatchesTrueWithNegatedFieldMatcher(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.FormMatch(map[string]interface{}{
+		"name": []interface{}{
+			map[string]interface{}{
+				"matcher": "exact",
+				"value":   "Kevin",
+				"config": map[string]interface{}{
+					"negate": true,
+				},
+			},
+		},
+	}, "name=Bob", nil)).To(BeTrue())
+}
+
+func Test_FormMatch_MatchesMultipleValuesJoinedBySemicolon(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.FormMatch(map[string]interface{}{
+		"scope": []interface{}{
+			map[string]interface{}{
+				"matcher": "exact",
+				"value":   "read;write",
+			},
+		},
+	}, "scope=read&scope=write", nil)).To(BeTrue())
+}
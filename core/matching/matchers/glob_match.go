@@ -4,11 +4,11 @@ import "github.com/ryanuber/go-glob"
 
 var Glob = "glob"
 
-func GlobMatch(match interface{}, toMatch string) bool {
+func GlobMatch(match interface{}, toMatch string, config map[string]interface{}) bool {
 	matchString, ok := match.(string)
 	if !ok {
 		return false
 	}
 
-	return glob.Glob(matchString, toMatch)
+	return glob.Glob(applyStringConfig(matchString, config), applyStringConfig(toMatch, config))
 }
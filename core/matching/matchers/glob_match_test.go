@@ -10,61 +10,69 @@ import (
 func Test_GlobMatch_MatchesFalseWithIncorrectDataType(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.GlobMatch(1, "yes")).To(BeFalse())
+	Expect(matchers.GlobMatch(1, "yes", nil)).To(BeFalse())
 }
 
 func Test_GlobMatch_MatchesTrueWithGlobMatch(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.GlobMatch("t*st", `test`)).To(BeTrue())
+	Expect(matchers.GlobMatch("t*st", `test`, nil)).To(BeTrue())
 }
 
 func Test_GlobMatch_MatchesTrueWithGlobMatch_MatchesZeroExtraCharactersAtEnd(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.GlobMatch("test*", `test`)).To(BeTrue())
+	Expect(matchers.GlobMatch("test*", `test`, nil)).To(BeTrue())
 }
 
 func Test_GlobMatch_MatchesTrueWithGlobMatch_MatchesZeroExtraCharactersAtStart(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.GlobMatch("*test", `test`)).To(BeTrue())
+	Expect(matchers.GlobMatch("*test", `test`, nil)).To(BeTrue())
 }
 
 func Test_GlobMatch_MatchesTrueWithGlobMatch_MatchesZeroExtraCharactersAtStartAndEnd(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.GlobMatch("*test*", `test`)).To(BeTrue())
+	Expect(matchers.GlobMatch("*test*", `test`, nil)).To(BeTrue())
 }
 
 func Test_GlobMatch_MatchesTrueWithGlobMatch_MatchesUpperCase(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.GlobMatch("*est", `Test`)).To(BeTrue())
+	Expect(matchers.GlobMatch("*est", `Test`, nil)).To(BeTrue())
 }
 
 func Test_GlobMatch_MatchesTrueWithGlobMatch_MatchesLowerCase(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.GlobMatch("*est", `test`)).To(BeTrue())
+	Expect(matchers.GlobMatch("*est", `test`, nil)).To(BeTrue())
 }
 
 func Test_GlobMatch_MatchesTrueWithGlobMatch_MatchesAstrik(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.GlobMatch("*est", `*est`)).To(BeTrue())
-	Expect(matchers.GlobMatch("t*est", `t*est`)).To(BeTrue())
-	Expect(matchers.GlobMatch("test*", `test*`)).To(BeTrue())
+	Expect(matchers.GlobMatch("*est", `*est`, nil)).To(BeTrue())
+	Expect(matchers.GlobMatch("t*est", `t*est`, nil)).To(BeTrue())
+	Expect(matchers.GlobMatch("test*", `test*`, nil)).To(BeTrue())
 }
 
 func Test_GlobMatch_MatchesFalseWithGlobMatch_UpperCase(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.GlobMatch("*esT", `test`)).To(BeFalse())
+	Expect(matchers.GlobMatch("*esT", `test`, nil)).To(BeFalse())
 }
 
 func Test_GlobMatch_MatchesFalseWithIncorrectGlobMatch(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.GlobMatch("t*st", `tset`)).To(BeFalse())
+	Expect(matchers.GlobMatch("t*st", `tset`, nil)).To(BeFalse())
+}
+
+func Test_GlobMatch_MatchesTrueWithIgnoreCase(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.GlobMatch("/API/*", "/api/users", map[string]interface{}{
+		"ignoreCase": true,
+	})).To(BeTrue())
 }
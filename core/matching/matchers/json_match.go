@@ -7,7 +7,7 @@ import (
 
 var Json = "json"
 
-func JsonMatch(match interface{}, toMatch string) bool {
+func JsonMatch(match interface{}, toMatch string, config map[string]interface{}) bool {
 	matchString, ok := match.(string)
 	if !ok {
 		return false
@@ -28,5 +28,41 @@ func JsonMatch(match interface{}, toMatch string) bool {
 		return false
 	}
 
+	if IsConfigEnabled(config, IgnoreUnknownFields) {
+		return jsonContainsFields(matchingObject, toMatchObject)
+	}
+
 	return reflect.DeepEqual(matchingObject, toMatchObject)
 }
+
+// jsonContainsFields compares JSON values like reflect.DeepEqual, except that
+// objects in toMatch may have fields which are not in match
+func jsonContainsFields(match, toMatch interface{}) bool {
+	switch expected := match.(type) {
+	case map[string]interface{}:
+		actual, ok := toMatch.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range expected {
+			actualValue, exists := actual[key]
+			if !exists || !jsonContainsFields(value, actualValue) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		actual, ok := toMatch.([]interface{})
+		if !ok || len(expected) != len(actual) {
+			return false
+		}
+		for i := range expected {
+			if !jsonContainsFields(expected[i], actual[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(match, toMatch)
+	}
+}
@@ -10,18 +10,18 @@ import (
 func Test_JsonMatch_MatchesFalseWithIncorrectDataType(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.JsonMatch(1, "yes")).To(BeFalse())
+	Expect(matchers.JsonMatch(1, "yes", nil)).To(BeFalse())
 }
 func Test_JsonMatch_MatchesTrueWithJSON(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.JsonMatch(`{"test":{"json":true,"minified":true}}`, `{"test":{"json":true,"minified":true}}`)).To(BeTrue())
+	Expect(matchers.JsonMatch(`{"test":{"json":true,"minified":true}}`, `{"test":{"json":true,"minified":true}}`, nil)).To(BeTrue())
 }
 
 func Test_JsonMatch_MatchesTrueWithJSON_InADifferentOrder(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.JsonMatch(`{"test":{"minified":true, "json":true}}`, `{"test":{"json":true,"minified":true}}`)).To(BeTrue())
+	Expect(matchers.JsonMatch(`{"test":{"minified":true, "json":true}}`, `{"test":{"json":true,"minified":true}}`, nil)).To(BeTrue())
 }
 
 func Test_JsonMatch_MatchesTrueWithUnminifiedJSON(t *testing.T) {
@@ -32,7 +32,7 @@ func Test_JsonMatch_MatchesTrueWithUnminifiedJSON(t *testing.T) {
 			"json": true,
 			"minified": true
 		}
-	}`)).To(BeTrue())
+	}`, nil)).To(BeTrue())
 }
 
 func Test_JsonMatch_MatchesFalseWithInvalidJSONAsMatcher(t *testing.T) {
@@ -43,7 +43,7 @@ func Test_JsonMatch_MatchesFalseWithInvalidJSONAsMatcher(t *testing.T) {
 			"json": true,
 			"minified": true
 		}
-	}`)).To(BeFalse())
+	}`, nil)).To(BeFalse())
 }
 
 func Test_JsonMatch_MatchesFalseWithInvalidJSON(t *testing.T) {
@@ -54,13 +54,13 @@ func Test_JsonMatch_MatchesFalseWithInvalidJSON(t *testing.T) {
 			"json": true,
 			"minified": 
 		}
-	}`)).To(BeFalse())
+	}`, nil)).To(BeFalse())
 }
 
 func Test_JsonMatch_MatchesTrueWithTwoEmptyString(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.JsonMatch(``, ``)).To(BeTrue())
+	Expect(matchers.JsonMatch(``, ``, nil)).To(BeTrue())
 }
 
 func Test_JsonMatch_MatchesFalseAgainstEmptyString(t *testing.T) {
@@ -71,7 +71,7 @@ func Test_JsonMatch_MatchesFalseAgainstEmptyString(t *testing.T) {
 			"json": true,
 			"minified": 
 		}
-	}`, ``)).To(BeFalse())
+	}`, ``, nil)).To(BeFalse())
 }
 
 func Test_JsonMatch_MatchesFalseWithEmptyString(t *testing.T) {
@@ -82,5 +82,37 @@ func Test_JsonMatch_MatchesFalseWithEmptyString(t *testing.T) {
 			"json": true,
 			"minified": 
 		}
-	}`)).To(BeFalse())
+	}`, nil)).To(BeFalse())
+}
+
+func Test_JsonMatch_MatchesTrueWithUnknownFieldsWhenIgnoreUnknownFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.JsonMatch(`{"name":"Kevin","address":{"city":"London"},"items":[{"id":1}]}`,
+		`{"name":"Kevin","id":7,"address":{"city":"London","postcode":"N1"},"items":[{"id":1,"price":2}]}`,
+		map[string]interface{}{
+			"ignoreUnknownFields": true,
+		})).To(BeTrue())
+}
+
+func Test_JsonMatch_MatchesFalseWithUnknownFieldsWithoutIgnoreUnknownFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.JsonMatch(`{"name":"Kevin"}`, `{"name":"Kevin","id":7}`, nil)).To(BeFalse())
+}
+
+func Test_JsonMatch_MatchesFalseWithMissingFieldsWhenIgnoreUnknownFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.JsonMatch(`{"name":"Kevin","id":7}`, `{"name":"Kevin"}`, map[string]interface{}{
+		"ignoreUnknownFields": true,
+	})).To(BeFalse())
+}
+
+func Test_JsonMatch_MatchesFalseWithDifferentArrayLengthsWhenIgnoreUnknownFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.JsonMatch(`{"items":[1,2]}`, `{"items":[1,2,3]}`, map[string]interface{}{
+		"ignoreUnknownFields": true,
+	})).To(BeFalse())
 }
@@ -6,7 +6,7 @@ import (
 
 var JsonPartial = "jsonpartial"
 
-func JsonPartialMatch(match interface{}, toMatch string) bool {
+func JsonPartialMatch(match interface{}, toMatch string, config map[string]interface{}) bool {
 	var expected, actual map[string]interface{}
 	matchString, ok := match.(string)
 	if !ok {
@@ -25,49 +25,49 @@ func Test_JsonPartialMatch_MatchesTrueWithEqualsJSON(t *testing.T) {
         "set": false,
         "age": 400
     }]
-}`)).To(BeTrue())
+}`, nil)).To(BeTrue())
 }
 
 func Test_JsonPartialMatch_MatchesTrueWithNotOrderedJSON(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.JsonPartialMatch(`{"test":{"minified":true,"json":true}}`, `{"test":{"json":true,"minified":true}}`)).To(BeTrue())
+	Expect(matchers.JsonPartialMatch(`{"test":{"minified":true,"json":true}}`, `{"test":{"json":true,"minified":true}}`, nil)).To(BeTrue())
 }
 
 func Test_JsonPartialMatch_MatchesTrueWithAbsentNode(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.JsonPartialMatch(`{"test":{"minified":true}}`, `{"test":{"json":true,"minified":true}}`)).To(BeTrue())
+	Expect(matchers.JsonPartialMatch(`{"test":{"minified":true}}`, `{"test":{"json":true,"minified":true}}`, nil)).To(BeTrue())
 }
 
 func Test_JsonPartialMatch_MatchesTrueWithAbsentObject(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.JsonPartialMatch(`{"test":{"minified":true}}`, `{"test":{"json":true,"minified":true,"someObject":{"fieldA":"valueA"}}}`)).To(BeTrue())
+	Expect(matchers.JsonPartialMatch(`{"test":{"minified":true}}`, `{"test":{"json":true,"minified":true,"someObject":{"fieldA":"valueA"}}}`, nil)).To(BeTrue())
 }
 
 func Test_JsonPartialMatch_MatchesFalseWithAbsentNode(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.JsonPartialMatch(`{"test":{"json":true,"minified":true}}`, `{"test":{"minified":true}}`)).To(BeFalse())
+	Expect(matchers.JsonPartialMatch(`{"test":{"json":true,"minified":true}}`, `{"test":{"minified":true}}`, nil)).To(BeFalse())
 }
 
 func Test_JsonPartialMatch_MatchesFalseWithAbsentObject(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.JsonPartialMatch(`{"test":{"json":true,"minified":true,"someObject":{"fieldA":"valueA"}}}`, `{"test":{"minified":true}}`)).To(BeFalse())
+	Expect(matchers.JsonPartialMatch(`{"test":{"json":true,"minified":true,"someObject":{"fieldA":"valueA"}}}`, `{"test":{"minified":true}}`, nil)).To(BeFalse())
 }
 
 func Test_JsonPartialMatch_MatchesTrueEmptyJson(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.JsonPartialMatch(`{}`, `{}`)).To(BeTrue())
+	Expect(matchers.JsonPartialMatch(`{}`, `{}`, nil)).To(BeTrue())
 }
 
 func Test_JsonPartialMatch_MatchesFalseInvalidJson(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.JsonPartialMatch(`{"test":{"json":true,"minified":true}}`, `{"test":{"json":true,"minified":}}`)).To(BeFalse())
+	Expect(matchers.JsonPartialMatch(`{"test":{"json":true,"minified":true}}`, `{"test":{"json":true,"minified":}}`, nil)).To(BeFalse())
 }
 
 func Test_JsonPartialMatch_MatchesTrueDeep(t *testing.T) {
@@ -84,7 +84,7 @@ func Test_JsonPartialMatch_MatchesTrueDeep(t *testing.T) {
 		"someObject": {
 			"fieldA": "valueA"
 		}
-}}`)).To(BeTrue())
+}}`, nil)).To(BeTrue())
 }
 
 func Test_JsonPartialMatch_MatchesTrueDeepArrayInside(t *testing.T) {
@@ -124,7 +124,7 @@ func Test_JsonPartialMatch_MatchesTrueDeepArrayInside(t *testing.T) {
       ]
     ]
   }
-}`)).To(BeTrue())
+}`, nil)).To(BeTrue())
 }
 
 func Test_JsonPartialMatch_MatchesTrueDeepComplexWithArray(t *testing.T) {
@@ -177,7 +177,7 @@ func Test_JsonPartialMatch_MatchesTrueDeepComplexWithArray(t *testing.T) {
       ]
     ]
   }
-}`)).To(BeTrue())
+}`, nil)).To(BeTrue())
 }
 
 func Test_JsonPartialMatch_MatchesFalseDeepComplexWithArray(t *testing.T) {
@@ -230,5 +230,5 @@ func Test_JsonPartialMatch_MatchesFalseDeepComplexWithArray(t *testing.T) {
       ]
     ]
   }
-}`)).To(BeFalse())
+}`, nil)).To(BeFalse())
 }
@@ -11,7 +11,7 @@ import (
 
 var JsonPath = "jsonpath"
 
-func JsonPathMatch(match interface{}, toMatch string) bool {
+func JsonPathMatch(match interface{}, toMatch string, config map[string]interface{}) bool {
 	matchString, ok := match.(string)
 	if !ok {
 		return false
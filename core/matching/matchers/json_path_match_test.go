@@ -10,48 +10,48 @@ import (
 func Test_JsonPathMatch_MatchesFalseWithIncorrectDataType(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.JsonPathMatch(1, "yes")).To(BeFalse())
+	Expect(matchers.JsonPathMatch(1, "yes", nil)).To(BeFalse())
 }
 func Test_JsonPathMatch_MatchesFalseWithInvalidJsonPath(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.JsonPathMatch("test", `{"test": "field"}`)).To(BeFalse())
+	Expect(matchers.JsonPathMatch("test", `{"test": "field"}`, nil)).To(BeFalse())
 }
 
 func Test_JsonPathMatch_MatchesTrueWithJsonMatch_GetSingleElement(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.JsonPathMatch("$.test", `{"test": "field"}`)).To(BeTrue())
+	Expect(matchers.JsonPathMatch("$.test", `{"test": "field"}`, nil)).To(BeTrue())
 }
 
 func Test_JsonPathMatch_MatchesFalseWithIncorrectJsonMatch_GetSingleElement(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.JsonPathMatch("$.notAField", `{"test": "field"}`)).To(BeFalse())
+	Expect(matchers.JsonPathMatch("$.notAField", `{"test": "field"}`, nil)).To(BeFalse())
 }
 
 func Test_JsonPathMatch_MatchesTrueWithJsonMatch_GetElementFromArray(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.JsonPathMatch("$.test[1]", `{"test": [{}, {}]}`)).To(BeTrue())
+	Expect(matchers.JsonPathMatch("$.test[1]", `{"test": [{}, {}]}`, nil)).To(BeTrue())
 }
 
 func Test_JsonPathMatch_MatchesFalseWithIncorrectJsonMatch_GetElementFromArray(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.JsonPathMatch("$.test[2]", `{"test": [{}, {}]}`)).To(BeFalse())
+	Expect(matchers.JsonPathMatch("$.test[2]", `{"test": [{}, {}]}`, nil)).To(BeFalse())
 }
 
 func Test_JsonPathMatch_MatchesTrueWithJsonMatch_WithExpression(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.JsonPathMatch("$.test[?(@.field == \"test\")]", `{"test": [{"field": "test"}]}`)).To(BeTrue())
+	Expect(matchers.JsonPathMatch("$.test[?(@.field == \"test\")]", `{"test": [{"field": "test"}]}`, nil)).To(BeTrue())
 }
 
 func Test_JsonPathMatch_MatchesFalseWithIncorrectJsonMatch_WithExpression(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.JsonPathMatch("$.test[*]?(@.field == \"test\")", `{"test": [{"field": "not-test"}]}`)).To(BeFalse())
+	Expect(matchers.JsonPathMatch("$.test[*]?(@.field == \"test\")", `{"test": [{"field": "not-test"}]}`, nil)).To(BeFalse())
 }
 
 // TODO the following JSONPath expressions are not supported at the moment
@@ -0,0 +1,93 @@
+package matchers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+var JsonSchema = "jsonschema"
+
+// SchemaReference is the value of a jsonschema matcher which refers to one of the
+// schemas shipped in the simulation. It is exported as the name of the schema only.
+type SchemaReference struct {
+	Name   string
+	Schema interface{}
+}
+
+func (this SchemaReference) MarshalJSON() ([]byte, error) {
+	return json.Marshal(this.Name)
+}
+
+// compiledSchemas caches schemas by their JSON, as they are expensive to compile
+var compiledSchemas = sync.Map{}
+
+func JsonSchemaMatch(match interface{}, toMatch string, config map[string]interface{}) bool {
+	validationErrors, err := JsonSchemaErrors(match, toMatch)
+	return err == nil && len(validationErrors) == 0
+}
+
+// JsonSchemaErrors returns the reasons why toMatch is not valid against the
+// schema of a jsonschema matcher. An error is returned if the schema is invalid.
+func JsonSchemaErrors(match interface{}, toMatch string) ([]string, error) {
+	schema, err := GetJsonSchema(match)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := schema.Validate(gojsonschema.NewStringLoader(toMatch))
+	if err != nil {
+		return []string{"Request body is not valid JSON: " + err.Error()}, nil
+	}
+
+	validationErrors := []string{}
+	for _, resultError := range result.Errors() {
+		validationErrors = append(validationErrors, fmt.Sprintf("Error for <%s>: %s", resultError.Field(), resultError.Description()))
+	}
+
+	return validationErrors, nil
+}
+
+// IsJsonSchemaReference returns true if the value of a jsonschema matcher is the
+// name of a schema, rather than an inline schema
+func IsJsonSchemaReference(match interface{}) bool {
+	matchString, ok := match.(string)
+	return ok && !strings.HasPrefix(strings.TrimSpace(matchString), "{")
+}
+
+// GetJsonSchema compiles the schema of a jsonschema matcher, which can be an
+// object, a JSON string or a resolved SchemaReference
+func GetJsonSchema(match interface{}) (*gojsonschema.Schema, error) {
+	if reference, ok := match.(SchemaReference); ok {
+		match = reference.Schema
+	}
+
+	if IsJsonSchemaReference(match) {
+		return nil, fmt.Errorf("JSON schema %v is not in the simulation", match)
+	}
+
+	schemaJson, ok := match.(string)
+	if !ok {
+		schemaBytes, err := json.Marshal(match)
+		if err != nil {
+			return nil, errors.New("JSON schema cannot be converted to JSON: " + err.Error())
+		}
+		schemaJson = string(schemaBytes)
+	}
+
+	if schema, ok := compiledSchemas.Load(schemaJson); ok {
+		return schema.(*gojsonschema.Schema), nil
+	}
+
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(schemaJson))
+	if err != nil {
+		return nil, errors.New("Invalid JSON schema: " + err.Error())
+	}
+	compiledSchemas.Store(schemaJson, schema)
+
+	return schema, nil
+}
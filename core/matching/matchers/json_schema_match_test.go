@@ -0,0 +1,94 @@
+package matchers_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+var userSchema = map[string]interface{}{
+	"type":     "object",
+	"required": []interface{}{"name"},
+	"properties": map[string]interface{}{
+		"name": map[string]interface{}{
+			"type": "string",
+		},
+		"age": map[string]interface{}{
+			"type":    "integer",
+			"minimum": 0,
+		},
+	},
+}
+
+func Test_JsonSchemaMatch_MatchesTrueWithValidBody(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.JsonSchemaMatch(userSchema, `{"name": "Kevin", "age": 30}`, nil)).To(BeTrue())
+}
+
+func Test_JsonSchemaMatch_MatchesFalseWithInvalidBody(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.JsonSchemaMatch(userSchema, `{"age": -1}`, nil)).To(BeFalse())
+}
+
+func Test_JsonSchemaMatch_MatchesFalseWithBodyWhichIsNotJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.JsonSchemaMatch(userSchema, `<xml>`, nil)).To(BeFalse())
+}
+
+func Test_JsonSchemaMatch_MatchesTrueWithSchemaAsJsonString(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.JsonSchemaMatch(`{"type": "object", "required": ["name"]}`, `{"name": "Kevin"}`, nil)).To(BeTrue())
+}
+
+func Test_JsonSchemaMatch_MatchesTrueWithResolvedSchemaReference(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.JsonSchemaMatch(matchers.SchemaReference{
+		Name:   "user",
+		Schema: userSchema,
+	}, `{"name": "Kevin"}`, nil)).To(BeTrue())
+}
+
+func Test_JsonSchemaMatch_MatchesFalseWithUnresolvedSchemaReference(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.JsonSchemaMatch("user", `{"name": "Kevin"}`, nil)).To(BeFalse())
+}
+
+func Test_JsonSchemaErrors_ReturnsValidationErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	validationErrors, err := matchers.JsonSchemaErrors(userSchema, `{"age": -1}`)
+
+	Expect(err).To(BeNil())
+	Expect(validationErrors).To(ConsistOf(
+		"Error for <name>: name is required",
+		"Error for <age>: Must be greater than or equal to 0",
+	))
+}
+
+func Test_JsonSchemaErrors_ReturnsErrorWithInvalidSchema(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := matchers.JsonSchemaErrors(map[string]interface{}{"type": 1}, `{}`)
+
+	Expect(err).ToNot(BeNil())
+}
+
+func Test_SchemaReference_MarshalsToName(t *testing.T) {
+	RegisterTestingT(t)
+
+	referenceBytes, err := json.Marshal(matchers.SchemaReference{
+		Name:   "user",
+		Schema: userSchema,
+	})
+
+	Expect(err).To(BeNil())
+	Expect(string(referenceBytes)).To(Equal(`"user"`))
+}
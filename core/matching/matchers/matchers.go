@@ -1,17 +1,26 @@
 package matchers
 
-type MatcherFunc func(data interface{}, toMatch string) bool
+type MatcherFunc func(data interface{}, toMatch string, config map[string]interface{}) bool
 
 var Matchers = map[string]MatcherFunc{
 	// Default matcher
 	"": ExactMatch,
 
-	Exact:       ExactMatch,
-	Glob:        GlobMatch,
-	Json:        JsonMatch,
-	JsonPath:    JsonPathMatch,
-	JsonPartial: JsonPartialMatch,
-	Regex:       RegexMatch,
-	Xml:         XmlMatch,
-	Xpath:       XpathMatch,
+	Exact:        ExactMatch,
+	Glob:         GlobMatch,
+	Json:         JsonMatch,
+	JsonPath:     JsonPathMatch,
+	JsonPartial:  JsonPartialMatch,
+	JsonSchema:   JsonSchemaMatch,
+	Regex:        RegexMatch,
+	PathTemplate: PathTemplateMatch,
+	Xml:          XmlMatch,
+	Xpath:        XpathMatch,
+}
+
+func init() {
+	// These match each field of the body with the other matchers, so
+	// they cannot be part of the declaration of Matchers
+	Matchers[Form] = FormMatch
+	Matchers[Multipart] = MultipartMatch
 }
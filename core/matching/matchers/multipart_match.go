@@ -0,0 +1,14 @@
+package matchers
+
+import "github.com/SpectoLabs/hoverfly/core/util"
+
+var Multipart = "multipart"
+
+func MultipartMatch(match interface{}, toMatch string, config map[string]interface{}) bool {
+	form, err := util.ParseMultipartForm(toMatch)
+	if err != nil {
+		return false
+	}
+
+	return fieldsMatch(match, form)
+}
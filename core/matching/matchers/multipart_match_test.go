@@ -0,0 +1,62 @@
+package matchers_test
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+var multipartMatcherValue = map[string]interface{}{
+	"name": []interface{}{
+		map[string]interface{}{
+			"matcher": "exact",
+			"value":   "Kevin",
+		},
+	},
+	"file": []interface{}{
+		map[string]interface{}{
+			"matcher": "json",
+			"value":   `{"id": 1}`,
+		},
+	},
+}
+
+func Test_MultipartMatch_MatchesTrueRegardlessOfBoundaryAndPartOrder(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.MultipartMatch(multipartMatcherValue, "--boundary-one\r\n"+
+		"Content-Disposition: form-data; name=\"name\"\r\n\r\n"+
+		"Kevin\r\n"+
+		"--boundary-one\r\n"+
+		"Content-Disposition: form-data; name=\"file\"; filename=\"test.json\"\r\n"+
+		"Content-Type: application/json\r\n\r\n"+
+		"{\"id\":1}\r\n"+
+		"--boundary-one--\r\n", nil)).To(BeTrue())
+
+	Expect(matchers.MultipartMatch(multipartMatcherValue, "--boundary-two\r\n"+
+		"Content-Disposition: form-data; name=\"file\"; filename=\"test.json\"\r\n\r\n"+
+		"{\"id\": 1}\r\n"+
+		"--boundary-two\r\n"+
+		"Content-Disposition: form-data; name=\"name\"\r\n\r\n"+
+		"Kevin\r\n"+
+		"--boundary-two--\r\n", nil)).To(BeTrue())
+}
+
+func Test_MultipartMatch_MatchesFalseWhenAPartDoesNotMatch(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.MultipartMatch(multipartMatcherValue, "--abc\r\n"+
+		"Content-Disposition: form-data; name=\"name\"\r\n\r\n"+
+		"Kevin\r\n"+
+		"--abc\r\n"+
+		"Content-Disposition: form-data; name=\"file\"\r\n\r\n"+
+		"{\"id\":2}\r\n"+
+		"--abc--\r\n", nil)).To(BeFalse())
+}
+
+func Test_MultipartMatch_MatchesFalseWithInvalidBody(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.MultipartMatch(multipartMatcherValue, "name=Kevin", nil)).To(BeFalse())
+}
@@ -0,0 +1,118 @@
+package matchers
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var PathTemplate = "pathtemplate"
+
+var pathTemplateParameter = regexp.MustCompile(`\{([^{}/]+)\}`)
+
+// pathTemplate is a compiled path template, such as /users/{id}, with
+// a group in the regular expression for each of the named parameters
+type pathTemplate struct {
+	regex      *regexp.Regexp
+	parameters []string
+}
+
+// compiledPathTemplates caches path templates, as they are compiled for every match
+var compiledPathTemplates = sync.Map{}
+
+func PathTemplateMatch(match interface{}, toMatch string, config map[string]interface{}) bool {
+	template, ok := compilePathTemplate(match, config)
+	if !ok {
+		return false
+	}
+
+	return template.regex.MatchString(toMatch)
+}
+
+func compilePathTemplate(match interface{}, config map[string]interface{}) (*pathTemplate, bool) {
+	matchString, ok := match.(string)
+	if !ok {
+		return nil, false
+	}
+
+	ignoreCase := IsConfigEnabled(config, IgnoreCase)
+	key := matchString
+	if ignoreCase {
+		key = "(?i)" + key
+	}
+
+	if template, ok := compiledPathTemplates.Load(key); ok {
+		return template.(*pathTemplate), true
+	}
+
+	template := &pathTemplate{}
+	pattern := "^"
+	if ignoreCase {
+		pattern = "(?i)^"
+	}
+
+	position := 0
+	for _, indexes := range pathTemplateParameter.FindAllStringSubmatchIndex(matchString, -1) {
+		pattern += regexp.QuoteMeta(matchString[position:indexes[0]]) + "([^/]+)"
+		template.parameters = append(template.parameters, strings.TrimSpace(matchString[indexes[2]:indexes[3]]))
+		position = indexes[1]
+	}
+	pattern += regexp.QuoteMeta(matchString[position:]) + "$"
+
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, false
+	}
+	template.regex = regex
+
+	compiledPathTemplates.Store(key, template)
+
+	return template, true
+}
+
+// NamedCaptures returns the values captured by the named parameters of a path
+// template or the named groups of a regular expression. Other matchers do not
+// capture anything, so nil is returned for them.
+func NamedCaptures(matcher string, match interface{}, toMatch string, config map[string]interface{}) map[string]string {
+	var regex *regexp.Regexp
+	var names []string
+
+	switch strings.ToLower(matcher) {
+	case PathTemplate:
+		template, ok := compilePathTemplate(match, config)
+		if !ok {
+			return nil
+		}
+		regex = template.regex
+		names = append([]string{""}, template.parameters...)
+	case Regex:
+		matchString, ok := match.(string)
+		if !ok {
+			return nil
+		}
+		if IsConfigEnabled(config, IgnoreCase) {
+			matchString = "(?i)" + matchString
+		}
+		var err error
+		if regex, err = regexp.Compile(matchString); err != nil {
+			return nil
+		}
+		names = regex.SubexpNames()
+	default:
+		return nil
+	}
+
+	submatches := regex.FindStringSubmatch(toMatch)
+	if submatches == nil {
+		return nil
+	}
+
+	captures := map[string]string{}
+	for i, name := range names {
+		if i > 0 && name != "" {
+			captures[name] = submatches[i]
+		}
+	}
+
+	return captures
+}
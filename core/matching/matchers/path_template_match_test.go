@@ -0,0 +1,76 @@
+package matchers_test
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func Test_PathTemplateMatch_MatchesFalseWithIncorrectDataType(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.PathTemplateMatch(1, "/users/1", nil)).To(BeFalse())
+}
+
+func Test_PathTemplateMatch_MatchesTrueWithParameters(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.PathTemplateMatch("/users/{id}/orders/{orderId}", "/users/123/orders/abc-456", nil)).To(BeTrue())
+}
+
+func Test_PathTemplateMatch_MatchesFalseWhenParameterWouldSpanSegments(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.PathTemplateMatch("/users/{id}", "/users/123/orders", nil)).To(BeFalse())
+}
+
+func Test_PathTemplateMatch_MatchesFalseWithEmptyParameter(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.PathTemplateMatch("/users/{id}/orders", "/users//orders", nil)).To(BeFalse())
+}
+
+func Test_PathTemplateMatch_TreatsLiteralPartsLiterally(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.PathTemplateMatch("/files/{name}.json", "/files/report.json", nil)).To(BeTrue())
+	Expect(matchers.PathTemplateMatch("/files/{name}.json", "/files/reportxjson", nil)).To(BeFalse())
+}
+
+func Test_PathTemplateMatch_MatchesTrueWithIgnoreCase(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.PathTemplateMatch("/users/{id}", "/USERS/1", map[string]interface{}{
+		"ignoreCase": true,
+	})).To(BeTrue())
+}
+
+func Test_NamedCaptures_ReturnsPathTemplateParameters(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.NamedCaptures("pathtemplate", "/users/{id}/orders/{orderId}", "/users/123/orders/456", nil)).To(Equal(map[string]string{
+		"id":      "123",
+		"orderId": "456",
+	}))
+}
+
+func Test_NamedCaptures_ReturnsNamedRegexGroups(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.NamedCaptures("regex", `^/users/(?P<id>\d+)/(\w+)$`, "/users/123/orders", nil)).To(Equal(map[string]string{
+		"id": "123",
+	}))
+}
+
+func Test_NamedCaptures_ReturnsNilForOtherMatchers(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.NamedCaptures("glob", "/users/*", "/users/123", nil)).To(BeNil())
+}
+
+func Test_NamedCaptures_ReturnsNilWhenNotMatched(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.NamedCaptures("pathtemplate", "/users/{id}", "/orders/123", nil)).To(BeNil())
+}
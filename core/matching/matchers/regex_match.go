@@ -1,15 +1,27 @@
 package matchers
 
-import "regexp"
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
 
 var Regex = "regex"
 
-func RegexMatch(match interface{}, toMatch string) bool {
+func RegexMatch(match interface{}, toMatch string, config map[string]interface{}) bool {
 	matchString, ok := match.(string)
 	if !ok {
 		return false
 	}
 
+	if IsConfigEnabled(config, IgnoreCase) {
+		matchString = "(?i)" + matchString
+	}
+
+	if IsConfigEnabled(config, IgnoreWhitespace) {
+		toMatch = strings.TrimFunc(toMatch, unicode.IsSpace)
+	}
+
 	result, err := regexp.MatchString(matchString, toMatch)
 	if err != nil {
 		return false
@@ -10,16 +10,32 @@ import (
 func Test_RegexMatch_MatchesFalseWithIncorrectDataType(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.RegexMatch(1, "yes")).To(BeFalse())
+	Expect(matchers.RegexMatch(1, "yes", nil)).To(BeFalse())
 }
 func Test_RegexMatch_MatchesTrueWithRegexMatch(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.RegexMatch("t[o|a|e]st", `test`)).To(BeTrue())
+	Expect(matchers.RegexMatch("t[o|a|e]st", `test`, nil)).To(BeTrue())
 }
 
 func Test_RegexMatch_MatchesFalseWithIncorrectRegexMatch(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.RegexMatch("t[o|a]st", `test`)).To(BeFalse())
+	Expect(matchers.RegexMatch("t[o|a]st", `test`, nil)).To(BeFalse())
+}
+
+func Test_RegexMatch_MatchesTrueWithIgnoreCase(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.RegexMatch("^application/json$", "Application/JSON", map[string]interface{}{
+		"ignoreCase": true,
+	})).To(BeTrue())
+}
+
+func Test_RegexMatch_MatchesTrueWithIgnoreWhitespace(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.RegexMatch("^test$", "  test  ", map[string]interface{}{
+		"ignoreWhitespace": true,
+	})).To(BeTrue())
 }
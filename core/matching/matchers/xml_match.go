@@ -8,7 +8,7 @@ import (
 
 var Xml = "xml"
 
-func XmlMatch(match interface{}, toMatch string) bool {
+func XmlMatch(match interface{}, toMatch string, config map[string]interface{}) bool {
 	matchString, ok := match.(string)
 	if !ok {
 		return false
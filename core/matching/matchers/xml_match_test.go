@@ -10,12 +10,12 @@ import (
 func Test_XmlMatch_MatchesFalseWithIncorrectDataType(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.XmlMatch(1, "yes")).To(BeFalse())
+	Expect(matchers.XmlMatch(1, "yes", nil)).To(BeFalse())
 }
 func Test_XmlMatch_MatchesTrueWithXML(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchers.XmlMatch(`<xml><document><test></document>`, `<xml><document><test></document>`)).To(BeTrue())
+	Expect(matchers.XmlMatch(`<xml><document><test></document>`, `<xml><document><test></document>`, nil)).To(BeTrue())
 }
 
 func Test_XmlMatch_MatchesTrueWithUnminifiedXml(t *testing.T) {
@@ -24,7 +24,7 @@ func Test_XmlMatch_MatchesTrueWithUnminifiedXml(t *testing.T) {
 	Expect(matchers.XmlMatch(`<xml>
 		<document>
 			<test key="value">cat</test>
-		</document>`, `<xml><document><test key="value">cat</test></document>`)).To(BeTrue())
+		</document>`, `<xml><document><test key="value">cat</test></document>`, nil)).To(BeTrue())
 }
 
 func Test_XmlMatch_MatchesFalseWithNotMatchingXml(t *testing.T) {
@@ -33,5 +33,5 @@ func Test_XmlMatch_MatchesFalseWithNotMatchingXml(t *testing.T) {
 	Expect(matchers.XmlMatch(`<xml>
 		<document>
 			<test key="value">cat</test>
-		</document>`, `<xml><document><test key="different">cat</test></document>`)).To(BeFalse())
+		</document>`, `<xml><document><test key="different">cat</test></document>`, nil)).To(BeFalse())
 }
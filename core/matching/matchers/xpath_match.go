@@ -11,7 +11,7 @@ import (
 
 var Xpath = "xpath"
 
-func XpathMatch(match interface{}, toMatch string) bool {
+func XpathMatch(match interface{}, toMatch string, config map[string]interface{}) bool {
 	matchString, ok := match.(string)
 	if !ok {
 		return false
@@ -3,7 +3,6 @@ package matching
 import (
 	"github.com/SpectoLabs/hoverfly/core/models"
 	"github.com/SpectoLabs/hoverfly/core/state"
-	"github.com/SpectoLabs/hoverfly/core/util"
 )
 
 type MatchingStrategy interface {
@@ -14,10 +13,22 @@ type MatchingStrategy interface {
 }
 
 func MatchingStrategyRunner(req models.RequestDetails, webserver bool, simulation *models.Simulation, state *state.State, strategy MatchingStrategy) *MatchingResult {
-	state.RWMutex.RLock()
-	copyState := util.CopyMap(state.State)
-	state.RWMutex.RUnlock()
-	for _, matchingPair := range simulation.GetMatchingPairs() {
+	copyState := state.Copy()
+
+	// Pairs left out of the candidates cannot match on method, destination or path, so
+	// they can neither be a hit nor affect whether the result is cachable
+	result := runMatchingStrategy(req, webserver, simulation.GetCandidatePairs(req, webserver), copyState, strategy)
+
+	// The closest miss could be any pair though, so a miss is worked out against all of them
+	if _, ok := strategy.(*StrongestMatchStrategy); ok && result.Pair == nil {
+		result = runMatchingStrategy(req, webserver, simulation.GetMatchingPairs(), copyState, &StrongestMatchStrategy{})
+	}
+
+	return result
+}
+
+func runMatchingStrategy(req models.RequestDetails, webserver bool, pairs []models.RequestMatcherResponsePair, copyState map[string]string, strategy MatchingStrategy) *MatchingResult {
+	for _, matchingPair := range pairs {
 		requestMatcher := matchingPair.RequestMatcher
 		strategy.PreMatching()
 
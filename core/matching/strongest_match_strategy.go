@@ -1,6 +1,9 @@
 package matching
 
 import (
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
 	"github.com/SpectoLabs/hoverfly/core/models"
 )
 
@@ -55,6 +58,7 @@ func (s *StrongestMatchStrategy) PostMatching(req models.RequestDetails, request
 		s.requestMatch = &models.RequestMatcherResponsePair{
 			RequestMatcher: requestMatcher,
 			Response:       matchingPair.Response,
+			Responses:      matchingPair.Responses,
 		}
 		s.strongestMatchScore = s.score
 		s.closestMiss = nil
@@ -66,6 +70,7 @@ func (s *StrongestMatchStrategy) PostMatching(req models.RequestDetails, request
 			RequestMatcher: view.RequestMatcher,
 			Response:       view.Response,
 			MissedFields:   s.missedFields,
+			SchemaErrors:   getSchemaErrors(requestMatcher.Body, req.Body),
 			State:          state,
 		}
 	}
@@ -73,6 +78,24 @@ func (s *StrongestMatchStrategy) PostMatching(req models.RequestDetails, request
 	return nil
 }
 
+// getSchemaErrors returns why the body did not match the jsonschema matchers
+func getSchemaErrors(fields []models.RequestFieldMatchers, body string) []string {
+	var schemaErrors []string
+	for _, field := range fields {
+		if strings.ToLower(field.Matcher) != matchers.JsonSchema || matchers.IsConfigEnabled(field.Config, matchers.Negate) {
+			continue
+		}
+
+		validationErrors, err := matchers.JsonSchemaErrors(field.Value, body)
+		if err != nil {
+			validationErrors = []string{err.Error()}
+		}
+		schemaErrors = append(schemaErrors, validationErrors...)
+	}
+
+	return schemaErrors
+}
+
 func (s *StrongestMatchStrategy) Result() *MatchingResult {
 	cachable := isCachable(s.requestMatch, s.matchedOnAllButHeadersAtLeastOnce, s.matchedOnAllButStateAtLeastOnce)
 	var err *models.MatchError
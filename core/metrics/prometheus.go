@@ -0,0 +1,188 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DurationBuckets are the upper bounds of the buckets used for duration histograms, in seconds
+var DurationBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// ProxyMetrics records how the proxy is used, so that it can be scraped by Prometheus
+type ProxyMetrics struct {
+	mu                 sync.Mutex
+	requests           map[requestKey]int64
+	matches            map[string]int64
+	requestDuration    map[string]*histogram
+	middlewareDuration *histogram
+}
+
+type requestKey struct {
+	mode        string
+	destination string
+	status      int
+}
+
+// NewProxyMetrics - returns new proxy metrics with nothing recorded
+func NewProxyMetrics() *ProxyMetrics {
+	return &ProxyMetrics{
+		requests:           map[requestKey]int64{},
+		matches:            map[string]int64{},
+		requestDuration:    map[string]*histogram{},
+		middlewareDuration: newHistogram(DurationBuckets),
+	}
+}
+
+// CountRequest records a request handled by the proxy, along with how long it took
+func (this *ProxyMetrics) CountRequest(mode, destination string, status int, latency time.Duration) {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	this.requests[requestKey{mode: mode, destination: destination, status: status}]++
+
+	if _, ok := this.requestDuration[mode]; !ok {
+		this.requestDuration[mode] = newHistogram(DurationBuckets)
+	}
+	this.requestDuration[mode].observe(latency.Seconds())
+}
+
+// CountMatch records whether a request matched a pair in the simulation
+func (this *ProxyMetrics) CountMatch(matched bool) {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	if matched {
+		this.matches["hit"]++
+	} else {
+		this.matches["miss"]++
+	}
+}
+
+// ObserveMiddleware records how long middleware took to execute
+func (this *ProxyMetrics) ObserveMiddleware(duration time.Duration) {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	this.middlewareDuration.observe(duration.Seconds())
+}
+
+// Write writes out the metrics in the Prometheus text exposition format. Cache hits and
+// misses are kept by the cache itself, so are passed in.
+func (this *ProxyMetrics) Write(w io.Writer, cacheHits, cacheMisses int64) {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	writeHeader(w, "hoverfly_requests_total", "counter", "Requests handled by the proxy, by mode, destination and response status.")
+	requestKeys := []requestKey{}
+	for key := range this.requests {
+		requestKeys = append(requestKeys, key)
+	}
+	sort.Slice(requestKeys, func(i, j int) bool {
+		if requestKeys[i].mode != requestKeys[j].mode {
+			return requestKeys[i].mode < requestKeys[j].mode
+		}
+		if requestKeys[i].destination != requestKeys[j].destination {
+			return requestKeys[i].destination < requestKeys[j].destination
+		}
+		return requestKeys[i].status < requestKeys[j].status
+	})
+	for _, key := range requestKeys {
+		labels := formatLabels("mode", key.mode, "destination", key.destination, "status", strconv.Itoa(key.status))
+		fmt.Fprintf(w, "hoverfly_requests_total%s %d\n", labels, this.requests[key])
+	}
+
+	writeHeader(w, "hoverfly_matches_total", "counter", "Requests which were matched against the simulation, by whether a pair was found.")
+	for _, result := range []string{"hit", "miss"} {
+		fmt.Fprintf(w, "hoverfly_matches_total%s %d\n", formatLabels("result", result), this.matches[result])
+	}
+
+	writeHeader(w, "hoverfly_cache_hits_total", "counter", "Lookups which found a response in the request cache.")
+	fmt.Fprintf(w, "hoverfly_cache_hits_total %d\n", cacheHits)
+
+	writeHeader(w, "hoverfly_cache_misses_total", "counter", "Lookups which did not find a response in the request cache.")
+	fmt.Fprintf(w, "hoverfly_cache_misses_total %d\n", cacheMisses)
+
+	cacheHitRatio := 0.0
+	if cacheHits+cacheMisses > 0 {
+		cacheHitRatio = float64(cacheHits) / float64(cacheHits+cacheMisses)
+	}
+	writeHeader(w, "hoverfly_cache_hit_ratio", "gauge", "Proportion of request cache lookups which found a response.")
+	fmt.Fprintf(w, "hoverfly_cache_hit_ratio %s\n", formatFloat(cacheHitRatio))
+
+	writeHeader(w, "hoverfly_request_duration_seconds", "histogram", "Time taken to handle a request, as recorded in the journal, by mode.")
+	modes := []string{}
+	for mode := range this.requestDuration {
+		modes = append(modes, mode)
+	}
+	sort.Strings(modes)
+	for _, mode := range modes {
+		this.requestDuration[mode].write(w, "hoverfly_request_duration_seconds", "mode", mode)
+	}
+
+	writeHeader(w, "hoverfly_middleware_duration_seconds", "histogram", "Time taken to execute middleware.")
+	this.middlewareDuration.write(w, "hoverfly_middleware_duration_seconds")
+}
+
+type histogram struct {
+	buckets []float64
+	counts  []int64
+	count   int64
+	sum     float64
+}
+
+func newHistogram(buckets []float64) *histogram {
+	return &histogram{
+		buckets: buckets,
+		counts:  make([]int64, len(buckets)),
+	}
+}
+
+func (this *histogram) observe(value float64) {
+	for i, bucket := range this.buckets {
+		if value <= bucket {
+			this.counts[i]++
+		}
+	}
+	this.count++
+	this.sum += value
+}
+
+func (this *histogram) write(w io.Writer, name string, labels ...string) {
+	for i, bucket := range this.buckets {
+		bucketLabels := formatLabels(append(labels, "le", formatFloat(bucket))...)
+		fmt.Fprintf(w, "%s_bucket%s %d\n", name, bucketLabels, this.counts[i])
+	}
+	fmt.Fprintf(w, "%s_bucket%s %d\n", name, formatLabels(append(labels, "le", "+Inf")...), this.count)
+	fmt.Fprintf(w, "%s_sum%s %s\n", name, formatLabels(labels...), formatFloat(this.sum))
+	fmt.Fprintf(w, "%s_count%s %d\n", name, formatLabels(labels...), this.count)
+}
+
+func writeHeader(w io.Writer, name, metricType, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, metricType)
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// formatLabels formats label names and values, given in pairs, as a Prometheus label set
+func formatLabels(namesAndValues ...string) string {
+	if len(namesAndValues) == 0 {
+		return ""
+	}
+
+	labels := []string{}
+	for i := 0; i+1 < len(namesAndValues); i += 2 {
+		labels = append(labels, fmt.Sprintf(`%s="%s"`, namesAndValues[i], labelValueEscaper.Replace(namesAndValues[i+1])))
+	}
+
+	return "{" + strings.Join(labels, ",") + "}"
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,121 @@
+package metrics_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/metrics"
+	. "github.com/onsi/gomega"
+)
+
+func Test_ProxyMetrics_Write_IncludesRequestCountsByModeDestinationAndStatus(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := metrics.NewProxyMetrics()
+	unit.CountRequest("simulate", "test-server.com", 200, time.Millisecond)
+	unit.CountRequest("simulate", "test-server.com", 200, time.Millisecond)
+	unit.CountRequest("simulate", "test-server.com", 502, time.Millisecond)
+	unit.CountRequest("capture", "other-server.com", 201, time.Millisecond)
+
+	var buffer bytes.Buffer
+	unit.Write(&buffer, 0, 0)
+
+	Expect(buffer.String()).To(ContainSubstring(`# TYPE hoverfly_requests_total counter
+hoverfly_requests_total{mode="capture",destination="other-server.com",status="201"} 1
+hoverfly_requests_total{mode="simulate",destination="test-server.com",status="200"} 2
+hoverfly_requests_total{mode="simulate",destination="test-server.com",status="502"} 1
+`))
+}
+
+func Test_ProxyMetrics_Write_IncludesMatchHitsAndMisses(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := metrics.NewProxyMetrics()
+	unit.CountMatch(true)
+	unit.CountMatch(false)
+	unit.CountMatch(true)
+
+	var buffer bytes.Buffer
+	unit.Write(&buffer, 0, 0)
+
+	Expect(buffer.String()).To(ContainSubstring(`hoverfly_matches_total{result="hit"} 2
+hoverfly_matches_total{result="miss"} 1
+`))
+}
+
+func Test_ProxyMetrics_Write_IncludesCacheHitRatio(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := metrics.NewProxyMetrics()
+
+	var buffer bytes.Buffer
+	unit.Write(&buffer, 3, 1)
+
+	Expect(buffer.String()).To(ContainSubstring("hoverfly_cache_hits_total 3\n"))
+	Expect(buffer.String()).To(ContainSubstring("hoverfly_cache_misses_total 1\n"))
+	Expect(buffer.String()).To(ContainSubstring("hoverfly_cache_hit_ratio 0.75\n"))
+}
+
+func Test_ProxyMetrics_Write_CacheHitRatioIsZeroWithoutLookups(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := metrics.NewProxyMetrics()
+
+	var buffer bytes.Buffer
+	unit.Write(&buffer, 0, 0)
+
+	Expect(buffer.String()).To(ContainSubstring("hoverfly_cache_hit_ratio 0\n"))
+}
+
+func Test_ProxyMetrics_Write_IncludesRequestDurationHistogramByMode(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := metrics.NewProxyMetrics()
+	unit.CountRequest("simulate", "test-server.com", 200, 20*time.Millisecond)
+	unit.CountRequest("simulate", "test-server.com", 200, 3*time.Second)
+
+	var buffer bytes.Buffer
+	unit.Write(&buffer, 0, 0)
+
+	Expect(buffer.String()).To(ContainSubstring(`# TYPE hoverfly_request_duration_seconds histogram
+hoverfly_request_duration_seconds_bucket{mode="simulate",le="0.001"} 0
+hoverfly_request_duration_seconds_bucket{mode="simulate",le="0.005"} 0
+hoverfly_request_duration_seconds_bucket{mode="simulate",le="0.01"} 0
+hoverfly_request_duration_seconds_bucket{mode="simulate",le="0.025"} 1
+`))
+	Expect(buffer.String()).To(ContainSubstring(`hoverfly_request_duration_seconds_bucket{mode="simulate",le="2.5"} 1
+hoverfly_request_duration_seconds_bucket{mode="simulate",le="5"} 2
+hoverfly_request_duration_seconds_bucket{mode="simulate",le="10"} 2
+hoverfly_request_duration_seconds_bucket{mode="simulate",le="+Inf"} 2
+hoverfly_request_duration_seconds_sum{mode="simulate"} 3.02
+hoverfly_request_duration_seconds_count{mode="simulate"} 2
+`))
+}
+
+func Test_ProxyMetrics_Write_IncludesMiddlewareDurationHistogram(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := metrics.NewProxyMetrics()
+	unit.ObserveMiddleware(200 * time.Millisecond)
+
+	var buffer bytes.Buffer
+	unit.Write(&buffer, 0, 0)
+
+	Expect(buffer.String()).To(ContainSubstring(`hoverfly_middleware_duration_seconds_bucket{le="0.1"} 0
+hoverfly_middleware_duration_seconds_bucket{le="0.25"} 1
+`))
+	Expect(buffer.String()).To(ContainSubstring("hoverfly_middleware_duration_seconds_count 1\n"))
+}
+
+func Test_ProxyMetrics_Write_EscapesLabelValues(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := metrics.NewProxyMetrics()
+	unit.CountRequest("simulate", `bad"host\`, 200, time.Millisecond)
+
+	var buffer bytes.Buffer
+	unit.Write(&buffer, 0, 0)
+
+	Expect(buffer.String()).To(ContainSubstring(`destination="bad\"host\\"`))
+}
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/state"
+)
+
+// The phases of a request in which middleware in a chain can be run
+const (
+	// Before the request is sent on, in capture and modify mode
+	PreRequest = "pre-request"
+	// Once there is a response, in every mode other than capture
+	PostResponse = "post-response"
+	// When a request does not match the simulation, in simulate and spy mode
+	OnMiss = "on-miss"
+)
+
+var phases = []string{PreRequest, PostResponse, OnMiss}
+
+// Stage is middleware in a chain, along with when it should be run. Empty modes
+// and destination mean the middleware is run in every mode and for every destination.
+type Stage struct {
+	Middleware  *Middleware
+	Phase       string
+	Modes       []string
+	Destination string
+
+	destination *regexp.Regexp
+}
+
+// NewStage checks the phase and compiles the destination, which is a regular
+// expression. The middleware of the stage is set once it has been created.
+func NewStage(phase string, modes []string, destination string) (*Stage, error) {
+	if !isPhase(phase) {
+		return nil, fmt.Errorf("Middleware phase must be one of %v, not %s", phases, phase)
+	}
+
+	stage := &Stage{
+		Phase:       phase,
+		Modes:       modes,
+		Destination: destination,
+	}
+
+	if destination != "" {
+		var err error
+		stage.destination, err = regexp.Compile(destination)
+		if err != nil {
+			return nil, fmt.Errorf("Middleware destination is not a valid regular expression: %s", err.Error())
+		}
+	}
+
+	return stage, nil
+}
+
+// Applies is true when the stage should be run for a pair in the phase and mode
+func (this Stage) Applies(phase, mode, destination string) bool {
+	if this.Phase != phase {
+		return false
+	}
+
+	if this.destination != nil && !this.destination.MatchString(destination) {
+		return false
+	}
+
+	if len(this.Modes) == 0 {
+		return true
+	}
+
+	for _, stageMode := range this.Modes {
+		if stageMode == mode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Chain is an ordered list of middleware, where each stage is given the pair
+// returned by the stage before it
+type Chain []*Stage
+
+// Execute runs every stage which applies to the phase, mode and destination of
+// the pair. It stops at the first stage to fail, returning the pair it was given
+// and a MiddlewareError saying which stage failed.
+func (this Chain) Execute(phase, mode string, pair models.RequestResponsePair, hoverflyState *state.State) (models.RequestResponsePair, error) {
+	originalPair := pair
+
+	for i, stage := range this {
+		if !stage.Applies(phase, mode, pair.Request.Destination) {
+			continue
+		}
+
+		newPair, err := stage.Middleware.ExecuteWithState(pair, hoverflyState)
+		if err != nil {
+			middlewareErr, ok := err.(*MiddlewareError)
+			if !ok {
+				middlewareErr = &MiddlewareError{
+					OriginalError: err,
+					Message:       "Middleware failed",
+				}
+			}
+			middlewareErr.Stage = i + 1
+			middlewareErr.Phase = phase
+
+			return originalPair, middlewareErr
+		}
+
+		pair = newPair
+	}
+
+	return pair, nil
+}
+
+// Close stops the middleware of every stage and deletes their scripts, once the
+// chain is no longer used
+func (this Chain) Close() {
+	for _, stage := range this {
+		stage.Middleware.Stop()
+		stage.Middleware.DeleteScript()
+	}
+}
+
+func isPhase(phase string) bool {
+	for _, validPhase := range phases {
+		if phase == validPhase {
+			return true
+		}
+	}
+
+	return false
+}
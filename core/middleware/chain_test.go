@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/models"
+	. "github.com/onsi/gomega"
+)
+
+func newJavascriptStage(phase string, modes []string, destination, script string) *Stage {
+	stage, err := NewStage(phase, modes, destination)
+	Expect(err).To(BeNil())
+
+	stage.Middleware = &Middleware{}
+	Expect(stage.Middleware.SetJavascript(script)).To(BeNil())
+
+	return stage
+}
+
+func Test_NewStage_ErrorsIfThePhaseIsNotValid(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := NewStage("after-response", nil, "")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Middleware phase must be one of [pre-request post-response on-miss], not after-response"))
+}
+
+func Test_NewStage_ErrorsIfTheDestinationIsNotAValidRegularExpression(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := NewStage(PreRequest, nil, "test.com(")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Middleware destination is not a valid regular expression"))
+}
+
+func Test_Stage_Applies_MatchesThePhaseModeAndDestination(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, err := NewStage(PostResponse, []string{"simulate", "spy"}, `^api\.test\.com$`)
+	Expect(err).To(BeNil())
+
+	Expect(unit.Applies(PostResponse, "spy", "api.test.com")).To(BeTrue())
+	Expect(unit.Applies(PreRequest, "spy", "api.test.com")).To(BeFalse())
+	Expect(unit.Applies(PostResponse, "modify", "api.test.com")).To(BeFalse())
+	Expect(unit.Applies(PostResponse, "spy", "other.test.com")).To(BeFalse())
+}
+
+func Test_Stage_Applies_ToEveryModeAndDestinationByDefault(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, err := NewStage(OnMiss, nil, "")
+	Expect(err).To(BeNil())
+
+	Expect(unit.Applies(OnMiss, "simulate", "test.com")).To(BeTrue())
+	Expect(unit.Applies(OnMiss, "spy", "other.com")).To(BeTrue())
+}
+
+func Test_Chain_Execute_PassesTheOutputOfEachStageToTheNext(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := Chain{
+		newJavascriptStage(PostResponse, nil, "", `pair.response.body += "first"`),
+		newJavascriptStage(PreRequest, nil, "", `pair.response.body += " pre-request"`),
+		newJavascriptStage(PostResponse, []string{"modify"}, "", `pair.response.body += " modify"`),
+		newJavascriptStage(PostResponse, nil, "other.com", `pair.response.body += " other.com"`),
+		newJavascriptStage(PostResponse, []string{"simulate"}, "test.com", `pair.response.body += " then second"`),
+	}
+
+	pair, err := unit.Execute(PostResponse, "simulate", models.RequestResponsePair{
+		Request: models.RequestDetails{Destination: "test.com"},
+	}, nil)
+	Expect(err).To(BeNil())
+
+	Expect(pair.Response.Body).To(Equal("first then second"))
+}
+
+func Test_Chain_Execute_ReturnsTheStageAndPhaseWhichFailed(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := Chain{
+		newJavascriptStage(PreRequest, nil, "", `pair.request.path = "/first"`),
+		newJavascriptStage(PreRequest, nil, "", `throw new Error("broken")`),
+		newJavascriptStage(PreRequest, nil, "", `pair.request.path = "/third"`),
+	}
+
+	originalPair := models.RequestResponsePair{
+		Request: models.RequestDetails{Path: "/"},
+	}
+
+	pair, err := unit.Execute(PreRequest, "modify", originalPair, nil)
+	Expect(err).ToNot(BeNil())
+	Expect(pair).To(Equal(originalPair))
+
+	middlewareErr, ok := err.(*MiddlewareError)
+	Expect(ok).To(BeTrue())
+	Expect(middlewareErr.Stage).To(Equal(2))
+	Expect(middlewareErr.Phase).To(Equal(PreRequest))
+	Expect(err.Error()).To(ContainSubstring("Stage: 2 (pre-request)"))
+}
@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/models"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// Used as the deadline of gRPC calls when no timeout has been set
+const defaultGrpcTimeout = 10 * time.Second
+
+// SetGrpc sets the address of gRPC middleware, either grpc://host:port or
+// grpcs://host:port when the middleware is served over TLS
+func (this *Middleware) SetGrpc(address string) error {
+	if this.grpc != nil {
+		this.grpc.close()
+	}
+	this.Grpc = ""
+	this.grpc = nil
+
+	if address == "" {
+		return nil
+	}
+
+	target, err := url.Parse(address)
+	if err != nil || (target.Scheme != "grpc" && target.Scheme != "grpcs") || target.Host == "" {
+		return fmt.Errorf("gRPC middleware must be set as grpc://host:port or grpcs://host:port")
+	}
+
+	client, err := newGrpcClient(target)
+	if err != nil {
+		return err
+	}
+
+	this.Grpc = address
+	this.grpc = client
+	return nil
+}
+
+// CheckHealth asks gRPC middleware whether it is serving with the standard
+// grpc.health.v1.Health service. Middleware that does not implement the health
+// service is assumed to be serving.
+func (this Middleware) CheckHealth() error {
+	if this.grpc == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), this.grpcTimeout())
+	defer cancel()
+
+	response, err := this.grpc.health.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		log.WithFields(log.Fields{
+			"grpc": this.Grpc,
+		}).Debug("gRPC middleware does not implement the health service")
+		return nil
+	}
+
+	if err != nil {
+		return &MiddlewareError{
+			OriginalError: err,
+			Message:       "gRPC middleware health check failed",
+			Url:           this.Grpc,
+		}
+	}
+
+	if response.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		return &MiddlewareError{
+			Message: "gRPC middleware is not serving, its health status is " + response.Status.String(),
+			Url:     this.Grpc,
+		}
+	}
+
+	return nil
+}
+
+func (this Middleware) executeMiddlewareGrpc(pair models.RequestResponsePair) (models.RequestResponsePair, error) {
+	pairView := pair.ConvertToRequestResponsePairView()
+	pairViewBytes, _ := json.Marshal(pairView)
+
+	ctx, cancel := context.WithTimeout(context.Background(), this.grpcTimeout())
+	defer cancel()
+
+	newPair, err := this.grpc.middleware.Process(ctx, newProtoRequestResponsePair(pairView))
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err.Error(),
+		}).Error("Error when communicating with gRPC middleware")
+		return pair, &MiddlewareError{
+			OriginalError: err,
+			Message:       "Error when communicating with gRPC middleware",
+			Url:           this.Grpc,
+			Stdin:         string(pairViewBytes),
+		}
+	}
+
+	return models.NewRequestResponsePairFromRequestResponsePairView(newRequestResponsePairViewFromProto(newPair)), nil
+}
+
+func (this Middleware) grpcTimeout() time.Duration {
+	if this.Timeout > 0 {
+		return this.Timeout
+	}
+	return defaultGrpcTimeout
+}
+
+// grpcClient makes calls to gRPC middleware over a single connection, which
+// is kept open and reused between calls
+type grpcClient struct {
+	conn       *grpc.ClientConn
+	middleware MiddlewareClient
+	health     grpc_health_v1.HealthClient
+}
+
+func newGrpcClient(target *url.URL) (*grpcClient, error) {
+	transportCredentials := grpc.WithInsecure()
+	if target.Scheme == "grpcs" {
+		transportCredentials = grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, ""))
+	}
+
+	conn, err := grpc.Dial(target.Host, transportCredentials, grpc.WithUserAgent("hoverfly"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &grpcClient{
+		conn:       conn,
+		middleware: NewMiddlewareClient(conn),
+		health:     grpc_health_v1.NewHealthClient(conn),
+	}, nil
+}
+
+func (this *grpcClient) close() {
+	this.conn.Close()
+}
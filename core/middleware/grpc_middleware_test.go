@@ -0,0 +1,283 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/models"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+type grpcTestMiddleware func(ctx context.Context, pair *RequestResponsePair) (*RequestResponsePair, error)
+
+func (this grpcTestMiddleware) Process(ctx context.Context, pair *RequestResponsePair) (*RequestResponsePair, error) {
+	return this(ctx, pair)
+}
+
+// countingListener counts the connections it accepts
+type countingListener struct {
+	net.Listener
+	connections int32
+}
+
+func (this *countingListener) Accept() (net.Conn, error) {
+	conn, err := this.Listener.Accept()
+	if err == nil {
+		atomic.AddInt32(&this.connections, 1)
+	}
+	return conn, err
+}
+
+type grpcTestServer struct {
+	server   *grpc.Server
+	listener *countingListener
+}
+
+// newGrpcTestServer starts a gRPC server with the middleware service. The health
+// service is only registered if a health server is given.
+func newGrpcTestServer(middleware grpcTestMiddleware, healthServer *health.Server) *grpcTestServer {
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+
+	server := grpc.NewServer()
+	RegisterMiddlewareServer(server, middleware)
+	if healthServer != nil {
+		grpc_health_v1.RegisterHealthServer(server, healthServer)
+	}
+
+	testServer := &grpcTestServer{
+		server:   server,
+		listener: &countingListener{Listener: listener},
+	}
+	go server.Serve(testServer.listener)
+
+	return testServer
+}
+
+func (this *grpcTestServer) address() string {
+	return "grpc://" + this.listener.Addr().String()
+}
+
+func (this *grpcTestServer) close() {
+	this.server.Stop()
+}
+
+func newHealthServer(servingStatus grpc_health_v1.HealthCheckResponse_ServingStatus) *health.Server {
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", servingStatus)
+	return healthServer
+}
+
+func replaceResponse(ctx context.Context, pair *RequestResponsePair) (*RequestResponsePair, error) {
+	pair.Response.Status = 201
+	pair.Response.Body = "body was replaced by grpc middleware"
+	return pair, nil
+}
+
+func Test_ConvertToNewMiddleware_WillCreateAMiddlewareObjectFromAGrpcAddress(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, err := ConvertToNewMiddleware("grpc://localhost:50051")
+	Expect(err).To(BeNil())
+	defer unit.Stop()
+
+	Expect(unit.Grpc).To(Equal("grpc://localhost:50051"))
+	Expect(unit.Binary).To(Equal(""))
+	Expect(unit.Remote).To(Equal(""))
+	Expect(unit.IsSet()).To(BeTrue())
+	Expect(unit.toString()).To(Equal("grpc://localhost:50051"))
+}
+
+func Test_Middleware_SetGrpc_ErrorsIfNotAGrpcAddress(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &Middleware{}
+
+	for _, address := range []string{"localhost:50051", "http://localhost:50051", "grpc://"} {
+		err := unit.SetGrpc(address)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("gRPC middleware must be set as grpc://host:port or grpcs://host:port"))
+		Expect(unit.Grpc).To(Equal(""))
+	}
+}
+
+func Test_Middleware_Execute_RunsGrpcMiddlewareCorrectly(t *testing.T) {
+	RegisterTestingT(t)
+
+	server := newGrpcTestServer(replaceResponse, nil)
+	defer server.close()
+
+	unit := &Middleware{}
+	err := unit.SetGrpc(server.address())
+	Expect(err).To(BeNil())
+	defer unit.Stop()
+
+	originalPair := models.RequestResponsePair{
+		Request:  models.RequestDetails{Path: "/", Method: "GET", Destination: "hostname-x", Headers: map[string][]string{"Test": {"one", "two"}}},
+		Response: models.ResponseDetails{Status: 200, Body: "original body"},
+	}
+
+	newPair, err := unit.Execute(originalPair)
+	Expect(err).To(BeNil())
+
+	Expect(newPair.Response.Status).To(Equal(201))
+	Expect(newPair.Response.Body).To(Equal("body was replaced by grpc middleware"))
+	Expect(newPair.Request.Destination).To(Equal("hostname-x"))
+	Expect(newPair.Request.Headers).To(Equal(map[string][]string{"Test": {"one", "two"}}))
+}
+
+func Test_Middleware_Execute_ReusesTheConnectionToGrpcMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	server := newGrpcTestServer(replaceResponse, nil)
+	defer server.close()
+
+	unit := &Middleware{}
+	unit.SetGrpc(server.address())
+	defer unit.Stop()
+
+	for i := 0; i < 3; i++ {
+		_, err := unit.Execute(models.RequestResponsePair{})
+		Expect(err).To(BeNil())
+	}
+
+	Expect(atomic.LoadInt32(&server.listener.connections)).To(Equal(int32(1)))
+}
+
+func Test_Middleware_Execute_KeepsTheConnectionToGrpcMiddlewareWhenTheTimeoutAndWorkersAreSet(t *testing.T) {
+	RegisterTestingT(t)
+
+	server := newGrpcTestServer(replaceResponse, nil)
+	defer server.close()
+
+	unit := &Middleware{}
+	unit.SetGrpc(server.address())
+	unit.SetTimeout(time.Second)
+	unit.SetWorkers(0)
+	defer unit.Stop()
+
+	newPair, err := unit.Execute(models.RequestResponsePair{})
+	Expect(err).To(BeNil())
+	Expect(newPair.Response.Status).To(Equal(201))
+}
+
+func Test_Middleware_Execute_ReturnsTheStatusFromGrpcMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	server := newGrpcTestServer(func(ctx context.Context, pair *RequestResponsePair) (*RequestResponsePair, error) {
+		return nil, status.Error(codes.Internal, "middleware broke")
+	}, nil)
+	defer server.close()
+
+	unit := &Middleware{}
+	unit.SetGrpc(server.address())
+	defer unit.Stop()
+
+	originalPair := models.RequestResponsePair{
+		Response: models.ResponseDetails{Status: 200, Body: "original body"},
+	}
+
+	untouchedPair, err := unit.Execute(originalPair)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Error when communicating with gRPC middleware"))
+	Expect(err.Error()).To(ContainSubstring("code = Internal desc = middleware broke"))
+
+	Expect(untouchedPair).To(Equal(originalPair))
+}
+
+func Test_Middleware_Execute_SendsTheTimeoutAsTheGrpcDeadline(t *testing.T) {
+	RegisterTestingT(t)
+
+	var deadline atomic.Value
+
+	server := newGrpcTestServer(func(ctx context.Context, pair *RequestResponsePair) (*RequestResponsePair, error) {
+		ctxDeadline, _ := ctx.Deadline()
+		deadline.Store(time.Until(ctxDeadline))
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}, nil)
+	defer server.close()
+
+	unit := &Middleware{}
+	unit.SetGrpc(server.address())
+	unit.SetTimeout(100 * time.Millisecond)
+	defer unit.Stop()
+
+	started := time.Now()
+	_, err := unit.Execute(models.RequestResponsePair{})
+
+	Expect(time.Since(started)).To(BeNumerically("<", 900*time.Millisecond))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("code = DeadlineExceeded"))
+	Expect(deadline.Load()).To(BeNumerically("~", 100*time.Millisecond, 50*time.Millisecond))
+}
+
+func Test_Middleware_CheckHealth_ReturnsNilWhenGrpcMiddlewareIsServing(t *testing.T) {
+	RegisterTestingT(t)
+
+	server := newGrpcTestServer(replaceResponse, newHealthServer(grpc_health_v1.HealthCheckResponse_SERVING))
+	defer server.close()
+
+	unit := &Middleware{}
+	unit.SetGrpc(server.address())
+	defer unit.Stop()
+
+	Expect(unit.CheckHealth()).To(BeNil())
+}
+
+func Test_Middleware_CheckHealth_ReturnsErrorWhenGrpcMiddlewareIsNotServing(t *testing.T) {
+	RegisterTestingT(t)
+
+	server := newGrpcTestServer(replaceResponse, newHealthServer(grpc_health_v1.HealthCheckResponse_NOT_SERVING))
+	defer server.close()
+
+	unit := &Middleware{}
+	unit.SetGrpc(server.address())
+	defer unit.Stop()
+
+	err := unit.CheckHealth()
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("gRPC middleware is not serving, its health status is NOT_SERVING"))
+}
+
+func Test_Middleware_CheckHealth_ReturnsNilWhenGrpcMiddlewareDoesNotImplementHealth(t *testing.T) {
+	RegisterTestingT(t)
+
+	server := newGrpcTestServer(replaceResponse, nil)
+	defer server.close()
+
+	unit := &Middleware{}
+	unit.SetGrpc(server.address())
+	defer unit.Stop()
+
+	Expect(unit.CheckHealth()).To(BeNil())
+}
+
+func Test_Middleware_CheckHealth_ReturnsErrorWhenGrpcMiddlewareCannotBeReached(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &Middleware{}
+	unit.SetGrpc("grpc://localhost:4321")
+	unit.SetTimeout(500 * time.Millisecond)
+	defer unit.Stop()
+
+	err := unit.CheckHealth()
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("gRPC middleware health check failed"))
+}
+
+func Test_Middleware_CheckHealth_DoesNothingForOtherMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &Middleware{}
+	unit.SetRemote("http://localhost:4321")
+
+	Expect(unit.CheckHealth()).To(BeNil())
+}
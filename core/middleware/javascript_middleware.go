@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/state"
+	"github.com/robertkrimen/otto"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// Used as the execution time limit of scripts when no timeout has been set
+	defaultJavascriptTimeout = time.Second
+
+	// Stops runaway recursion before it can exhaust the stack of Hoverfly itself
+	javascriptStackDepthLimit = 1000
+)
+
+var errJavascriptTimedOut = fmt.Errorf("JavaScript middleware timed out")
+
+// SetJavascript sets a script that is run by Hoverfly itself against every pair,
+// instead of middleware in another process. The script is only checked for
+// syntax errors here.
+func (this *Middleware) SetJavascript(script string) error {
+	if strings.TrimSpace(script) == "" {
+		this.Javascript = ""
+		return nil
+	}
+
+	if _, err := otto.New().Compile("", script); err != nil {
+		return fmt.Errorf("JavaScript middleware is not valid: %s", err.Error())
+	}
+
+	this.Javascript = script
+	return nil
+}
+
+// executeMiddlewareJavascript runs the script in a new VM, so scripts cannot
+// keep anything between pairs other than in the state. The pair is the same JSON
+// given to other middleware, available to the script as the global pair. Changes
+// made to pair, or a new object assigned to it, are what the middleware returns.
+func (this Middleware) executeMiddlewareJavascript(pair models.RequestResponsePair, hoverflyState *state.State) (newPair models.RequestResponsePair, err error) {
+	pairViewBytes, err := json.Marshal(pair.ConvertToRequestResponsePairView())
+	if err != nil {
+		return pair, &MiddlewareError{
+			OriginalError: err,
+			Message:       "Failed to marshal request to JSON",
+		}
+	}
+
+	// Without a state, such as when middleware is being tested, the script gets
+	// its own so it cannot change the state of Hoverfly
+	if hoverflyState == nil {
+		hoverflyState = state.NewState()
+	}
+
+	timeout := this.Timeout
+	if timeout == 0 {
+		timeout = defaultJavascriptTimeout
+	}
+
+	vm := otto.New()
+	vm.SetStackDepthLimit(javascriptStackDepthLimit)
+	vm.Set("state", newJavascriptState(hoverflyState))
+	vm.Set("console", map[string]interface{}{
+		"log": javascriptConsoleLog,
+	})
+
+	vm.Interrupt = make(chan func(), 1)
+	timer := time.AfterFunc(timeout, func() {
+		vm.Interrupt <- func() {
+			panic(errJavascriptTimedOut)
+		}
+	})
+	defer timer.Stop()
+
+	defer func() {
+		if caught := recover(); caught != nil {
+			if caught != errJavascriptTimedOut {
+				panic(caught)
+			}
+
+			message := fmt.Sprintf("Middleware timed out after %v", timeout)
+			log.WithFields(log.Fields{
+				"stdin": string(pairViewBytes),
+			}).Error(message)
+
+			newPair, err = pair, &MiddlewareError{
+				OriginalError: errJavascriptTimedOut,
+				Message:       message,
+				Command:       this.toString(),
+				Stdin:         string(pairViewBytes),
+			}
+		}
+	}()
+
+	pairValue, err := vm.Call("JSON.parse", nil, string(pairViewBytes))
+	if err == nil {
+		err = vm.Set("pair", pairValue)
+	}
+	if err == nil {
+		_, err = vm.Run(this.Javascript)
+	}
+	if err == nil {
+		pairValue, err = vm.Get("pair")
+	}
+	if err == nil {
+		pairValue, err = vm.Call("JSON.stringify", nil, pairValue)
+	}
+
+	if err != nil {
+		log.WithFields(log.Fields{
+			"stdin": string(pairViewBytes),
+			"error": err.Error(),
+		}).Error("JavaScript middleware failed")
+		return pair, &MiddlewareError{
+			OriginalError: err,
+			Message:       "JavaScript middleware failed",
+			Command:       this.toString(),
+			Stdin:         string(pairViewBytes),
+		}
+	}
+
+	var newPairView RequestResponsePairView
+	if err := json.Unmarshal([]byte(pairValue.String()), &newPairView); err != nil {
+		return pair, &MiddlewareError{
+			OriginalError: err,
+			Message:       "Failed to unmarshal JSON from middleware",
+			Command:       this.toString(),
+			Stdin:         string(pairViewBytes),
+			Stdout:        pairValue.String(),
+		}
+	}
+
+	return models.NewRequestResponsePairFromRequestResponsePairView(newPairView), nil
+}
+
+// newJavascriptState gives scripts read and write access to the state of Hoverfly,
+// with keys in the same format as the transitionsState of a response
+func newJavascriptState(hoverflyState *state.State) map[string]interface{} {
+	return map[string]interface{}{
+		"get": func(call otto.FunctionCall) otto.Value {
+			value, ok := hoverflyState.GetState(call.Argument(0).String())
+			if !ok {
+				return otto.UndefinedValue()
+			}
+			result, _ := otto.ToValue(value)
+			return result
+		},
+		"set": func(call otto.FunctionCall) otto.Value {
+			hoverflyState.PatchState(map[string]string{
+				call.Argument(0).String(): call.Argument(1).String(),
+			})
+			return otto.UndefinedValue()
+		},
+		"remove": func(call otto.FunctionCall) otto.Value {
+			hoverflyState.RemoveState([]string{call.Argument(0).String()})
+			return otto.UndefinedValue()
+		},
+		"all": func(call otto.FunctionCall) otto.Value {
+			result, _ := call.Otto.ToValue(hoverflyState.Copy())
+			return result
+		},
+	}
+}
+
+func javascriptConsoleLog(call otto.FunctionCall) otto.Value {
+	values := make([]string, len(call.ArgumentList))
+	for i, argument := range call.ArgumentList {
+		values[i] = argument.String()
+	}
+
+	log.WithFields(log.Fields{
+		"message": strings.Join(values, " "),
+	}).Info("Information from JavaScript middleware")
+
+	return otto.UndefinedValue()
+}
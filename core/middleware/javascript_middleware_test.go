@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/state"
+	. "github.com/onsi/gomega"
+)
+
+func javascriptPair() models.RequestResponsePair {
+	return models.RequestResponsePair{
+		Request:  models.RequestDetails{Path: "/", Method: "GET", Destination: "hostname-x", Headers: map[string][]string{"Test": {"one"}}},
+		Response: models.ResponseDetails{Status: 200, Body: "original body"},
+	}
+}
+
+func Test_ConvertToNewMiddleware_WillCreateAMiddlewareObjectFromAJavascriptFile(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, _ := ioutil.TempDir("", "hoverfly-javascript")
+	defer os.RemoveAll(dir)
+
+	scriptPath := filepath.Join(dir, "middleware.js")
+	ioutil.WriteFile(scriptPath, []byte(`pair.response.status = 201`), 0644)
+
+	unit, err := ConvertToNewMiddleware("javascript:" + scriptPath)
+	Expect(err).To(BeNil())
+
+	Expect(unit.Javascript).To(Equal(`pair.response.status = 201`))
+	Expect(unit.Binary).To(Equal(""))
+	Expect(unit.IsSet()).To(BeTrue())
+}
+
+func Test_ConvertToNewMiddleware_WillCreateAMiddlewareObjectFromAJavascriptFileWithoutThePrefixAsABinary(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, err := ConvertToNewMiddleware("./middleware.js")
+	Expect(err).To(BeNil())
+
+	Expect(unit.Binary).To(Equal("./middleware.js"))
+	Expect(unit.Javascript).To(Equal(""))
+}
+
+func Test_Middleware_SetJavascript_ErrorsOnSyntaxErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &Middleware{}
+
+	err := unit.SetJavascript("pair.response.status = ")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("JavaScript middleware is not valid"))
+	Expect(unit.Javascript).To(Equal(""))
+	Expect(unit.IsSet()).To(BeFalse())
+}
+
+func Test_Middleware_Execute_RunsJavascriptMiddlewareCorrectly(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &Middleware{}
+	err := unit.SetJavascript(`
+		pair.response.status = 201;
+		pair.response.body = pair.request.method + " " + pair.request.destination;
+		pair.response.headers = {"X-Script": ["true"]};
+	`)
+	Expect(err).To(BeNil())
+	Expect(unit.toString()).To(Equal("javascript"))
+
+	newPair, err := unit.Execute(javascriptPair())
+	Expect(err).To(BeNil())
+
+	Expect(newPair.Response.Status).To(Equal(201))
+	Expect(newPair.Response.Body).To(Equal("GET hostname-x"))
+	Expect(newPair.Response.Headers).To(Equal(map[string][]string{"X-Script": {"true"}}))
+	Expect(newPair.Request.Headers).To(Equal(map[string][]string{"Test": {"one"}}))
+}
+
+func Test_Middleware_Execute_UsesAPairAssignedByJavascriptMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &Middleware{}
+	unit.SetJavascript(`pair = {request: pair.request, response: {status: 404, body: "replaced"}}`)
+
+	newPair, err := unit.Execute(javascriptPair())
+	Expect(err).To(BeNil())
+
+	Expect(newPair.Response.Status).To(Equal(404))
+	Expect(newPair.Response.Body).To(Equal("replaced"))
+	Expect(newPair.Request.Destination).To(Equal("hostname-x"))
+}
+
+func Test_Middleware_ExecuteWithState_GivesJavascriptMiddlewareAccessToTheState(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyState := state.NewState()
+	hoverflyState.PatchState(map[string]string{"visited": "true", "removed": "true"})
+
+	unit := &Middleware{}
+	unit.SetJavascript(`
+		pair.response.body = state.get("visited") + " " + state.get("missing") + " " + Object.keys(state.all()).length;
+		state.set("page", 2);
+		state.remove("removed");
+	`)
+
+	newPair, err := unit.ExecuteWithState(javascriptPair(), hoverflyState)
+	Expect(err).To(BeNil())
+
+	Expect(newPair.Response.Body).To(Equal("true undefined 2"))
+	Expect(hoverflyState.Copy()).To(Equal(map[string]string{"visited": "true", "page": "2"}))
+}
+
+func Test_Middleware_Execute_ReturnsErrorsThrownByJavascriptMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &Middleware{}
+	unit.SetJavascript(`throw new Error("script broke")`)
+
+	untouchedPair, err := unit.Execute(javascriptPair())
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("JavaScript middleware failed"))
+	Expect(err.Error()).To(ContainSubstring("script broke"))
+
+	Expect(untouchedPair).To(Equal(javascriptPair()))
+}
+
+func Test_Middleware_Execute_StopsJavascriptMiddlewareAfterTheTimeout(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &Middleware{}
+	unit.SetJavascript(`while (true) { try {} catch (e) {} }`)
+	unit.SetTimeout(100 * time.Millisecond)
+
+	started := time.Now()
+	untouchedPair, err := unit.Execute(javascriptPair())
+
+	Expect(time.Since(started)).To(BeNumerically("<", time.Second))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Middleware timed out after 100ms"))
+
+	Expect(untouchedPair).To(Equal(javascriptPair()))
+}
+
+func Test_Middleware_Execute_StopsRunawayRecursionInJavascriptMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &Middleware{}
+	unit.SetJavascript(`function recurse() { return recurse() } recurse()`)
+
+	_, err := unit.Execute(javascriptPair())
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("JavaScript middleware failed"))
+}
+
+func Test_Middleware_Execute_DoesNotGiveJavascriptMiddlewareAccessToTheSystem(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &Middleware{}
+	unit.SetJavascript(`pair.response.body = [typeof require, typeof process, typeof setTimeout].join(" ")`)
+
+	newPair, err := unit.Execute(javascriptPair())
+	Expect(err).To(BeNil())
+
+	Expect(newPair.Response.Body).To(Equal("undefined undefined undefined"))
+}
@@ -3,7 +3,9 @@ package middleware
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"os/exec"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/SpectoLabs/hoverfly/core/models"
@@ -52,17 +54,33 @@ func (this Middleware) executeMiddlewareLocally(pair models.RequestResponsePair)
 		}
 	}
 
+	timedOut := make(chan struct{})
+	if this.Timeout > 0 {
+		timer := time.AfterFunc(this.Timeout, func() {
+			close(timedOut)
+			middlewareCommand.Process.Kill()
+		})
+		defer timer.Stop()
+	}
+
 	if err := middlewareCommand.Wait(); err != nil {
+		message := "Middleware failed"
+		select {
+		case <-timedOut:
+			message = fmt.Sprintf("Middleware timed out after %v", this.Timeout)
+		default:
+		}
+
 		log.WithFields(log.Fields{
 			"command": this.toString(),
 			"stdin":   string(pairViewBytes),
 			"sdtdout": string(stdout.Bytes()),
 			"sdtderr": string(stderr.Bytes()),
 			"error":   err.Error(),
-		}).Error("Middleware failed")
+		}).Error(message)
 		return pair, &MiddlewareError{
 			OriginalError: err,
-			Message:       "Middleware failed",
+			Message:       message,
 			Command:       this.toString(),
 			Stdin:         string(pairViewBytes),
 			Stdout:        string(stdout.Bytes()),
@@ -1,25 +1,59 @@
 package middleware
 
 import (
+	"fmt"
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"io/ioutil"
 
 	"github.com/SpectoLabs/hoverfly/core/errors"
 	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/state"
 )
 
 type Middleware struct {
-	Binary string
-	Script *os.File
-	Remote string
+	Binary     string
+	Script     *os.File
+	Remote     string
+	Grpc       string
+	Javascript string
+	Workers    int
+	Timeout    time.Duration
+
+	pool *workerPool
+	grpc *grpcClient
 }
 
+// A middleware string with this prefix is the path of a JavaScript file run by
+// Hoverfly itself, rather than a binary that is executed
+const javascriptPrefix = "javascript:"
+
 func ConvertToNewMiddleware(middleware string) (*Middleware, error) {
 	newMiddleware := &Middleware{}
-	if strings.HasPrefix(middleware, "http") {
+	if strings.HasPrefix(middleware, "grpc://") || strings.HasPrefix(middleware, "grpcs://") {
+
+		err := newMiddleware.SetGrpc(middleware)
+		if err != nil {
+			return nil, err
+		}
+
+		return newMiddleware, nil
+	} else if strings.HasPrefix(middleware, javascriptPrefix) {
+		fileContents, err := ioutil.ReadFile(strings.TrimPrefix(middleware, javascriptPrefix))
+		if err != nil {
+			return nil, err
+		}
+
+		err = newMiddleware.SetJavascript(string(fileContents))
+		if err != nil {
+			return nil, err
+		}
+
+		return newMiddleware, nil
+	} else if strings.HasPrefix(middleware, "http") {
 
 		err := newMiddleware.SetRemote(middleware)
 		if err != nil {
@@ -47,7 +81,10 @@ func ConvertToNewMiddleware(middleware string) (*Middleware, error) {
 
 func (this *Middleware) SetScript(scriptContent string) error {
 	tempDir := path.Join(os.TempDir(), "hoverfly")
-	this.DeleteScripts(tempDir)
+
+	// Only the previous script of this middleware is deleted, as the middleware
+	// in a chain all keep their scripts in the same directory
+	this.DeleteScript()
 
 	//We ignore the error it outputs as this directory may already exist
 	os.Mkdir(tempDir, 0777)
@@ -78,6 +115,18 @@ func (this Middleware) GetScript() (string, error) {
 	return string(contents), nil
 }
 
+// DeleteScript deletes the temporary file the script of the middleware was written to
+func (this *Middleware) DeleteScript() error {
+	if this.Script == nil {
+		return nil
+	}
+
+	err := os.Remove(this.Script.Name())
+	this.Script = nil
+
+	return err
+}
+
 func (this *Middleware) DeleteScripts(path string) error {
 	err := os.RemoveAll(path)
 	if err != nil {
@@ -102,12 +151,70 @@ func (this *Middleware) SetRemote(remoteUrl string) error {
 	return nil
 }
 
+// SetWorkers keeps that many middleware processes running and sends each of them
+// one pair per line, instead of starting a new process for every pair. Setting it
+// to 0 goes back to starting a process for every pair.
+func (this *Middleware) SetWorkers(workers int) error {
+	if workers < 0 {
+		return fmt.Errorf("Middleware workers cannot be negative")
+	}
+
+	if this.pool != nil {
+		this.pool.stop()
+		this.pool = nil
+	}
+	this.Workers = workers
+	if workers > 0 {
+		this.pool = newWorkerPool(workers)
+	}
+	return nil
+}
+
+// SetTimeout sets how long the middleware has to process a pair before it is
+// killed, 0 waits for as long as it takes
+func (this *Middleware) SetTimeout(timeout time.Duration) error {
+	if timeout < 0 {
+		return fmt.Errorf("Middleware timeout cannot be negative")
+	}
+
+	this.Timeout = timeout
+	return nil
+}
+
+// Stop kills any middleware workers that are still running and closes the
+// connection to gRPC middleware
+func (this *Middleware) Stop() {
+	if this.pool != nil {
+		this.pool.stop()
+	}
+	if this.grpc != nil {
+		this.grpc.close()
+	}
+}
+
 func (this *Middleware) Execute(pair models.RequestResponsePair) (models.RequestResponsePair, error) {
+	return this.ExecuteWithState(pair, nil)
+}
+
+// ExecuteWithState executes the middleware, giving JavaScript middleware access to
+// the state. Without a state, JavaScript middleware is given an empty one.
+func (this *Middleware) ExecuteWithState(pair models.RequestResponsePair, hoverflyState *state.State) (models.RequestResponsePair, error) {
 	if !this.IsSet() {
 		return pair, errors.MiddlewareNotSetError()
 	}
 
+	if this.Javascript != "" {
+		return this.executeMiddlewareJavascript(pair, hoverflyState)
+	}
+
+	if this.grpc != nil {
+		return this.executeMiddlewareGrpc(pair)
+	}
+
 	if this.Remote == "" {
+		if this.pool != nil {
+			return this.executeMiddlewareWorker(pair)
+		}
 		return this.executeMiddlewareLocally(pair)
 	} else {
 		return this.executeMiddlewareRemotely(pair)
@@ -115,11 +222,15 @@ func (this *Middleware) Execute(pair models.RequestResponsePair) (models.Request
 }
 
 func (this Middleware) IsSet() bool {
-	return this.Binary != "" || this.Remote != ""
+	return this.Binary != "" || this.Remote != "" || this.Grpc != "" || this.Javascript != ""
 }
 
 func (this Middleware) toString() string {
-	if this.Remote != "" {
+	if this.Javascript != "" {
+		return "javascript"
+	} else if this.Grpc != "" {
+		return this.Grpc
+	} else if this.Remote != "" {
 		return this.Remote
 	} else {
 		if this.Script != nil {
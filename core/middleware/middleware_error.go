@@ -4,9 +4,14 @@ import (
 	"fmt"
 )
 
+// MiddlewareError describes why middleware failed. Stage is the position of the
+// middleware in a chain, starting at 1, and Phase is the phase it was run in, both
+// are empty for middleware which is not in a chain.
 type MiddlewareError struct {
 	OriginalError error
 	Message       string
+	Stage         int
+	Phase         string
 	Command       string
 	Url           string
 	Stdin         string
@@ -16,6 +21,9 @@ type MiddlewareError struct {
 
 func (m *MiddlewareError) Error() string {
 	errorString := fmt.Sprintf("%s", m.Message)
+	if m.Stage != 0 {
+		errorString = fmt.Sprintf(errorString+"\nStage: %d (%s)", m.Stage, m.Phase)
+	}
 	if m.Command != "" {
 		errorString = fmt.Sprintf(errorString+"\nCommand: %s", m.Command)
 	}
@@ -29,3 +29,15 @@ func Test_MiddlewareError_ToString(t *testing.T) {
 	err.Stderr = "{stderr}"
 	Expect(err.Error()).To(Equal("Just a message\nCommand: just -a 'command'\nURL: http://justa.url\n\nSTDIN:\n{stdin}\n\nSTDOUT:\n{stdout}\n\nSTDERR:\n{stderr}"))
 }
+
+func Test_MiddlewareError_ToString_IncludesTheStageOfAChain(t *testing.T) {
+	RegisterTestingT(t)
+
+	err := MiddlewareError{
+		Message: "Just a message",
+		Stage:   2,
+		Phase:   PostResponse,
+		Command: "just -a 'command'",
+	}
+	Expect(err.Error()).To(Equal("Just a message\nStage: 2 (post-response)\nCommand: just -a 'command'"))
+}
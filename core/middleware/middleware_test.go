@@ -203,6 +203,22 @@ func Test_Middleware_SetScript_DeletesPreviousScript(t *testing.T) {
 	Expect(err).ToNot(BeNil())
 }
 
+func Test_Middleware_SetScript_DoesNotDeleteTheScriptsOfOtherMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	other := Middleware{}
+	err := other.SetScript("another test")
+	Expect(err).To(BeNil())
+	defer other.DeleteScript()
+
+	unit := Middleware{}
+	err = unit.SetScript("just a test")
+	Expect(err).To(BeNil())
+	defer unit.DeleteScript()
+
+	Expect(other.GetScript()).To(Equal("another test"))
+}
+
 func Test_Middleware_GetScript_GetsScript(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/util"
+)
+
+// The gRPC middleware messages in middleware.pb.go are generated from middleware.proto with
+// protoc-gen-go v1.2.0, using protoc --go_out=plugins=grpc:. middleware.proto
+
+func newProtoRequestResponsePair(pairView v2.RequestResponsePairViewV1) *RequestResponsePair {
+	return &RequestResponsePair{
+		Request: &Request{
+			RequestType: util.PointerToString(pairView.Request.RequestType),
+			Path:        util.PointerToString(pairView.Request.Path),
+			Method:      util.PointerToString(pairView.Request.Method),
+			Destination: util.PointerToString(pairView.Request.Destination),
+			Scheme:      util.PointerToString(pairView.Request.Scheme),
+			Query:       util.PointerToString(pairView.Request.Query),
+			Body:        util.PointerToString(pairView.Request.Body),
+			Headers:     newProtoHeaders(pairView.Request.Headers),
+		},
+		Response: &Response{
+			Status:      int32(pairView.Response.Status),
+			Body:        pairView.Response.Body,
+			EncodedBody: pairView.Response.EncodedBody,
+			Headers:     newProtoHeaders(pairView.Response.Headers),
+		},
+	}
+}
+
+func newRequestResponsePairViewFromProto(pair *RequestResponsePair) RequestResponsePairView {
+	request := pair.GetRequest()
+	requestType, path, method := request.GetRequestType(), request.GetPath(), request.GetMethod()
+	destination, scheme, query, body := request.GetDestination(), request.GetScheme(), request.GetQuery(), request.GetBody()
+
+	response := pair.GetResponse()
+
+	return RequestResponsePairView{
+		Request: RequestDetailsView{
+			RequestType: &requestType,
+			Path:        &path,
+			Method:      &method,
+			Destination: &destination,
+			Scheme:      &scheme,
+			Query:       &query,
+			Body:        &body,
+			Headers:     newHeadersFromProto(request.GetHeaders()),
+		},
+		Response: ResponseDetailsView{
+			Status:      int(response.GetStatus()),
+			Body:        response.GetBody(),
+			EncodedBody: response.GetEncodedBody(),
+			Headers:     newHeadersFromProto(response.GetHeaders()),
+		},
+	}
+}
+
+func newProtoHeaders(headers map[string][]string) map[string]*HeaderValues {
+	protoHeaders := map[string]*HeaderValues{}
+	for name, values := range headers {
+		protoHeaders[name] = &HeaderValues{Values: values}
+	}
+	return protoHeaders
+}
+
+func newHeadersFromProto(protoHeaders map[string]*HeaderValues) map[string][]string {
+	headers := map[string][]string{}
+	for name, values := range protoHeaders {
+		headers[name] = append([]string{}, values.GetValues()...)
+	}
+	return headers
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/golang/protobuf/proto"
+	. "github.com/onsi/gomega"
+)
+
+func Test_newProtoRequestResponsePair_EncodesFieldsAsInMiddlewareProto(t *testing.T) {
+	RegisterTestingT(t)
+
+	pair := models.RequestResponsePair{
+		Response: models.ResponseDetails{Status: 150},
+	}
+
+	data, err := proto.Marshal(newProtoRequestResponsePair(pair.ConvertToRequestResponsePairView()))
+	Expect(err).To(BeNil())
+
+	Expect(data).To(Equal([]byte{
+		0x0a, 0x03, 0x08, 0x96, 0x01,
+		0x12, 0x00,
+	}))
+}
+
+func Test_newRequestResponsePairViewFromProto_ReadsWhatNewProtoRequestResponsePairWrites(t *testing.T) {
+	RegisterTestingT(t)
+
+	pair := models.RequestResponsePair{
+		Request: models.RequestDetails{
+			Path:        "/path",
+			Method:      "POST",
+			Destination: "test.com",
+			Scheme:      "https",
+			Query:       map[string][]string{"one": {"1"}},
+			Body:        "request body",
+			Headers:     map[string][]string{"Accept": {"text/plain", "application/json"}, "Empty": {}},
+		},
+		Response: models.ResponseDetails{
+			Status:  -1,
+			Body:    "response body",
+			Headers: map[string][]string{"Content-Type": {"text/plain"}},
+		},
+	}
+
+	data, err := proto.Marshal(newProtoRequestResponsePair(pair.ConvertToRequestResponsePairView()))
+	Expect(err).To(BeNil())
+
+	protoPair := &RequestResponsePair{}
+	Expect(proto.Unmarshal(data, protoPair)).To(BeNil())
+
+	Expect(models.NewRequestResponsePairFromRequestResponsePairView(newRequestResponsePairViewFromProto(protoPair))).To(Equal(pair))
+}
+
+func Test_newRequestResponsePairViewFromProto_DefaultsMissingMessages(t *testing.T) {
+	RegisterTestingT(t)
+
+	pairView := newRequestResponsePairViewFromProto(&RequestResponsePair{})
+
+	Expect(*pairView.Request.Path).To(Equal(""))
+	Expect(pairView.Request.Headers).To(Equal(map[string][]string{}))
+	Expect(pairView.Response.Status).To(Equal(0))
+	Expect(pairView.Response.Headers).To(Equal(map[string][]string{}))
+}
@@ -36,7 +36,12 @@ func (this Middleware) executeMiddlewareRemotely(pair models.RequestResponsePair
 
 	req.Header.Add("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	client := http.DefaultClient
+	if this.Timeout > 0 {
+		client = &http.Client{Timeout: this.Timeout}
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"error": err.Error(),
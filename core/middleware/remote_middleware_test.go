@@ -1,8 +1,10 @@
 package middleware
 
 import (
+	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/models"
 	"github.com/gorilla/mux"
@@ -127,3 +129,28 @@ func Test_Middleware_executeMiddlewareRemotely_ReturnsError_WebsiteIsUnreachable
 
 	Expect(untouchedPair).To(Equal(originalPair))
 }
+
+func Test_Middleware_executeMiddlewareRemotely_ReturnsErrorIfRemoteTakesLongerThanTimeout(t *testing.T) {
+	RegisterTestingT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Second)
+	}))
+	defer server.Close()
+
+	originalPair := models.RequestResponsePair{
+		Response: models.ResponseDetails{
+			Body: "Normal body",
+		},
+	}
+
+	unit := &Middleware{}
+	unit.SetRemote(server.URL)
+	unit.SetTimeout(100 * time.Millisecond)
+
+	untouchedPair, err := unit.executeMiddlewareRemotely(originalPair)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Client.Timeout exceeded"))
+
+	Expect(untouchedPair).To(Equal(originalPair))
+}
@@ -0,0 +1,349 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// executeMiddlewareWorker hands the pair to one of the long lived middleware
+// processes. Pairs are written to the worker's stdin as a single line of JSON and
+// the worker is expected to answer with a single line of JSON on its stdout.
+// Workers that exit, fail or time out are killed and replaced on the next call.
+func (this Middleware) executeMiddlewareWorker(pair models.RequestResponsePair) (models.RequestResponsePair, error) {
+	pairViewBytes, err := json.Marshal(pair.ConvertToRequestResponsePairView())
+	if err != nil {
+		return pair, &MiddlewareError{
+			OriginalError: err,
+			Message:       "Failed to marshal request to JSON",
+		}
+	}
+
+	var output []byte
+	for attempt := 1; ; attempt++ {
+		worker, err := this.pool.acquire(this.startWorker)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"command": this.toString(),
+				"error":   err.Error(),
+			}).Error("Middleware worker failed to start")
+			return pair, &MiddlewareError{
+				OriginalError: err,
+				Message:       "Middleware worker failed to start",
+				Command:       this.toString(),
+				Stdin:         string(pairViewBytes),
+			}
+		}
+
+		output, err = worker.call(pairViewBytes, this.Timeout)
+		if err == nil {
+			this.pool.release(worker)
+			break
+		}
+
+		this.pool.discard(worker)
+
+		// A worker which could not be written to exited before it was given the pair,
+		// so the pair is given to a new worker instead
+		if _, ok := err.(*workerWriteError); ok && attempt == 1 {
+			log.WithFields(log.Fields{
+				"command": this.toString(),
+				"error":   err.Error(),
+			}).Warn("Middleware worker exited, retrying with a new worker")
+			continue
+		}
+
+		message := "Middleware worker failed"
+		if err == errWorkerTimedOut {
+			message = fmt.Sprintf("Middleware timed out after %v", this.Timeout)
+		}
+
+		log.WithFields(log.Fields{
+			"command": this.toString(),
+			"stdin":   string(pairViewBytes),
+			"error":   err.Error(),
+		}).Error(message)
+		return pair, &MiddlewareError{
+			OriginalError: err,
+			Message:       message,
+			Command:       this.toString(),
+			Stdin:         string(pairViewBytes),
+		}
+	}
+
+	if len(output) == 0 {
+		log.WithFields(log.Fields{
+			"command": this.toString(),
+		}).Warn("No response from middleware.")
+		return pair, nil
+	}
+
+	var newPairView RequestResponsePairView
+
+	err = json.Unmarshal(output, &newPairView)
+	if err != nil {
+		return pair, &MiddlewareError{
+			OriginalError: err,
+			Message:       "Failed to unmarshal JSON from middleware",
+			Command:       this.toString(),
+			Stdin:         string(pairViewBytes),
+			Stdout:        string(output),
+		}
+	}
+
+	if log.GetLevel() == log.DebugLevel {
+		log.WithFields(log.Fields{
+			"middleware": this.toString(),
+			"payload":    string(output),
+		}).Debug("payload after modifications")
+	}
+
+	return models.NewRequestResponsePairFromRequestResponsePairView(newPairView), nil
+}
+
+func (this Middleware) startWorker() (*middlewareWorker, error) {
+	var args []string
+	if this.Script != nil {
+		args = append(args, this.Script.Name())
+	}
+
+	command := exec.Command(this.Binary, args...)
+	command.Stderr = middlewareStderr{command: this.toString()}
+
+	stdin, err := command.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	// Stdout is an os.Pipe rather than StdoutPipe, as Wait closes the reading end of StdoutPipe
+	// as soon as the worker exits, which could lose its last line
+	stdout, stdoutWriter, err := os.Pipe()
+	if err != nil {
+		stdin.Close()
+		return nil, err
+	}
+	command.Stdout = stdoutWriter
+
+	err = command.Start()
+	stdoutWriter.Close()
+	if err != nil {
+		stdin.Close()
+		stdout.Close()
+		return nil, err
+	}
+
+	log.WithFields(log.Fields{
+		"command": this.toString(),
+		"pid":     command.Process.Pid,
+	}).Info("Started middleware worker")
+
+	worker := &middlewareWorker{
+		command:      command,
+		stdin:        stdin,
+		stdoutCloser: stdout,
+		stdout:       bufio.NewReader(stdout),
+		exited:       make(chan struct{}),
+	}
+
+	// Waiting for the worker as soon as it starts means a worker which exits while it is idle
+	// is noticed, and replaced, before it is given a pair
+	go func() {
+		command.Wait()
+		close(worker.exited)
+	}()
+
+	return worker, nil
+}
+
+var errWorkerTimedOut = fmt.Errorf("Middleware worker did not respond in time")
+
+// workerWriteError is returned when a pair could not be written to a worker, which means
+// the worker exited before it read the pair
+type workerWriteError struct {
+	err error
+}
+
+func (this *workerWriteError) Error() string {
+	return fmt.Sprintf("Failed to write to middleware worker: %s", this.err.Error())
+}
+
+type middlewareWorker struct {
+	command      *exec.Cmd
+	stdin        io.WriteCloser
+	stdoutCloser io.Closer
+	stdout       *bufio.Reader
+	exited       chan struct{}
+}
+
+func (this *middlewareWorker) call(payload []byte, timeout time.Duration) ([]byte, error) {
+	if timeout <= 0 {
+		return this.exchange(payload)
+	}
+
+	type result struct {
+		output []byte
+		err    error
+	}
+
+	results := make(chan result, 1)
+	go func() {
+		output, err := this.exchange(payload)
+		results <- result{output, err}
+	}()
+
+	select {
+	case result := <-results:
+		return result.output, result.err
+	case <-time.After(timeout):
+		return nil, errWorkerTimedOut
+	}
+}
+
+func (this *middlewareWorker) exchange(payload []byte) ([]byte, error) {
+	_, err := this.stdin.Write(append(payload, '\n'))
+	if err != nil {
+		return nil, &workerWriteError{err}
+	}
+
+	line, err := this.stdout.ReadBytes('\n')
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("Middleware worker exited")
+		}
+		return nil, err
+	}
+
+	return bytes.TrimSpace(line), nil
+}
+
+func (this *middlewareWorker) hasExited() bool {
+	select {
+	case <-this.exited:
+		return true
+	default:
+		return false
+	}
+}
+
+func (this *middlewareWorker) kill() {
+	this.stdin.Close()
+	this.command.Process.Kill()
+	<-this.exited
+	this.stdoutCloser.Close()
+}
+
+// workerPool holds one slot per worker. An empty slot is nil and gets a new
+// worker the next time it is acquired.
+type workerPool struct {
+	slots   chan *middlewareWorker
+	mutex   sync.Mutex
+	stopped bool
+}
+
+func newWorkerPool(size int) *workerPool {
+	pool := &workerPool{
+		slots: make(chan *middlewareWorker, size),
+	}
+	for i := 0; i < size; i++ {
+		pool.slots <- nil
+	}
+	return pool
+}
+
+func (this *workerPool) acquire(start func() (*middlewareWorker, error)) (*middlewareWorker, error) {
+	worker := <-this.slots
+
+	this.mutex.Lock()
+	stopped := this.stopped
+	this.mutex.Unlock()
+
+	if stopped {
+		if worker != nil {
+			worker.kill()
+		}
+		this.slots <- nil
+		return nil, fmt.Errorf("Middleware workers have been stopped")
+	}
+
+	if worker != nil && worker.hasExited() {
+		log.WithFields(log.Fields{
+			"pid": worker.command.Process.Pid,
+		}).Warn("Replacing middleware worker which exited while idle")
+		worker.kill()
+		worker = nil
+	}
+
+	if worker != nil {
+		return worker, nil
+	}
+
+	worker, err := start()
+	if err != nil {
+		this.slots <- nil
+		return nil, err
+	}
+	return worker, nil
+}
+
+func (this *workerPool) release(worker *middlewareWorker) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	if this.stopped {
+		worker.kill()
+		this.slots <- nil
+		return
+	}
+	this.slots <- worker
+}
+
+func (this *workerPool) discard(worker *middlewareWorker) {
+	worker.kill()
+	this.slots <- nil
+}
+
+func (this *workerPool) stop() {
+	this.mutex.Lock()
+	this.stopped = true
+	this.mutex.Unlock()
+
+	// Workers that are busy are killed when they are released, or when they are
+	// acquired, so the pool is drained without waiting for them
+	idle := 0
+drain:
+	for {
+		select {
+		case worker := <-this.slots:
+			if worker != nil {
+				worker.kill()
+			}
+			idle++
+		default:
+			break drain
+		}
+	}
+	for i := 0; i < idle; i++ {
+		this.slots <- nil
+	}
+}
+
+type middlewareStderr struct {
+	command string
+}
+
+func (this middlewareStderr) Write(p []byte) (int, error) {
+	log.WithFields(log.Fields{
+		"command": this.command,
+		"stderr":  string(p),
+	}).Info("Information from middleware")
+	return len(p), nil
+}
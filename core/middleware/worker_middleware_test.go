@@ -0,0 +1,268 @@
+package middleware
+
+import (
+	"io"
+	"io/ioutil"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/models"
+	. "github.com/onsi/gomega"
+)
+
+const pythonWorkerCounter = "import sys\n" +
+	"import json\n" +
+	"\n" +
+	"count = 0\n" +
+	"while True:\n" +
+	"	line = sys.stdin.readline()\n" +
+	"	if not line:\n" +
+	"		break\n" +
+	"	payload = json.loads(line)\n" +
+	"	if payload['request']['body'] == 'crash':\n" +
+	"		sys.exit(1)\n" +
+	"	if payload['request']['body'] == 'slow':\n" +
+	"		import time\n" +
+	"		time.sleep(5)\n" +
+	"	count += 1\n" +
+	"	payload['response']['body'] = str(count)\n" +
+	"	sys.stdout.write(json.dumps(payload) + '\\n')\n" +
+	"	sys.stdout.flush()\n" +
+	"	if payload['request']['body'] == 'exit':\n" +
+	"		sys.exit(0)\n"
+
+func newWorkerMiddleware(workers int) *Middleware {
+	unit := &Middleware{}
+	unit.SetBinary("python")
+	unit.SetScript(pythonWorkerCounter)
+	unit.SetWorkers(workers)
+	return unit
+}
+
+func workerPair(body string) models.RequestResponsePair {
+	return models.RequestResponsePair{
+		Request:  models.RequestDetails{Path: "/", Method: "GET", Destination: "hostname-x", Body: body},
+		Response: models.ResponseDetails{Status: 200, Body: "original body"},
+	}
+}
+
+func Test_Middleware_SetWorkers_ErrorsIfNegative(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &Middleware{}
+
+	err := unit.SetWorkers(-1)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Middleware workers cannot be negative"))
+	Expect(unit.Workers).To(Equal(0))
+}
+
+func Test_Middleware_Execute_ReusesTheSameWorker(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := newWorkerMiddleware(1)
+	defer unit.Stop()
+
+	for _, expected := range []string{"1", "2", "3"} {
+		pair, err := unit.Execute(workerPair(""))
+		Expect(err).To(BeNil())
+		Expect(pair.Response.Body).To(Equal(expected))
+	}
+}
+
+func Test_Middleware_Execute_ReplacesAWorkerThatExits(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := newWorkerMiddleware(1)
+	defer unit.Stop()
+
+	pair, err := unit.Execute(workerPair(""))
+	Expect(err).To(BeNil())
+	Expect(pair.Response.Body).To(Equal("1"))
+
+	pair, err = unit.Execute(workerPair("crash"))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Middleware worker failed"))
+	Expect(pair.Response.Body).To(Equal("original body"))
+
+	pair, err = unit.Execute(workerPair(""))
+	Expect(err).To(BeNil())
+	Expect(pair.Response.Body).To(Equal("1"))
+}
+
+func Test_Middleware_Execute_ReplacesAWorkerThatExitsWhileIdle(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := newWorkerMiddleware(1)
+	defer unit.Stop()
+
+	pair, err := unit.Execute(workerPair("exit"))
+	Expect(err).To(BeNil())
+	Expect(pair.Response.Body).To(Equal("1"))
+
+	worker := <-unit.pool.slots
+	Eventually(worker.exited).Should(BeClosed())
+	unit.pool.slots <- worker
+
+	pair, err = unit.Execute(workerPair(""))
+	Expect(err).To(BeNil())
+	Expect(pair.Response.Body).To(Equal("1"))
+}
+
+func Test_Middleware_Execute_RetriesOnANewWorkerIfTheWorkerCannotBeWrittenTo(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := newWorkerMiddleware(1)
+	defer unit.Stop()
+
+	pair, err := unit.Execute(workerPair(""))
+	Expect(err).To(BeNil())
+	Expect(pair.Response.Body).To(Equal("1"))
+
+	worker := <-unit.pool.slots
+	worker.stdin.Close()
+	unit.pool.slots <- worker
+
+	pair, err = unit.Execute(workerPair(""))
+	Expect(err).To(BeNil())
+	Expect(pair.Response.Body).To(Equal("1"))
+}
+
+func Test_Middleware_Execute_ReplacesAWorkerThatTimesOut(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := newWorkerMiddleware(1)
+	unit.SetTimeout(500 * time.Millisecond)
+	defer unit.Stop()
+
+	_, err := unit.Execute(workerPair(""))
+	Expect(err).To(BeNil())
+
+	_, err = unit.Execute(workerPair("slow"))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Middleware timed out after 500ms"))
+
+	pair, err := unit.Execute(workerPair(""))
+	Expect(err).To(BeNil())
+	Expect(pair.Response.Body).To(Equal("1"))
+}
+
+func Test_Middleware_Execute_SpreadsPairsAcrossWorkers(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := newWorkerMiddleware(2)
+	unit.SetTimeout(2 * time.Second)
+	defer unit.Stop()
+
+	slow := make(chan error)
+	go func() {
+		_, err := unit.Execute(workerPair("slow"))
+		slow <- err
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+
+	pair, err := unit.Execute(workerPair(""))
+	Expect(err).To(BeNil())
+	Expect(pair.Response.Body).To(Equal("1"))
+
+	Expect(<-slow).ToNot(BeNil())
+}
+
+func Test_Middleware_Execute_ErrorsOnceWorkersHaveBeenStopped(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := newWorkerMiddleware(1)
+
+	_, err := unit.Execute(workerPair(""))
+	Expect(err).To(BeNil())
+
+	unit.Stop()
+
+	_, err = unit.Execute(workerPair(""))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Middleware workers have been stopped"))
+}
+
+// exitOnClose stands in for the stdin of a worker, closing it makes the worker exit
+type exitOnClose struct {
+	io.Writer
+	exited chan struct{}
+}
+
+func (this exitOnClose) Close() error {
+	close(this.exited)
+	return nil
+}
+
+// startTestWorker gives a worker without a process of its own, which exits as soon
+// as it is killed
+func startTestWorker(command *exec.Cmd) func() (*middlewareWorker, error) {
+	return func() (*middlewareWorker, error) {
+		exited := make(chan struct{})
+		return &middlewareWorker{
+			command:      command,
+			stdin:        exitOnClose{ioutil.Discard, exited},
+			stdoutCloser: ioutil.NopCloser(nil),
+			exited:       exited,
+		}, nil
+	}
+}
+
+func Test_workerPool_stop_DoesNotBlockWhileWorkersAreBeingAcquired(t *testing.T) {
+	RegisterTestingT(t)
+
+	command := exec.Command("true")
+	Expect(command.Run()).To(Succeed())
+	start := startTestWorker(command)
+
+	for i := 0; i < 200; i++ {
+		unit := newWorkerPool(4)
+
+		finished := make(chan bool)
+		for j := 0; j < 3; j++ {
+			go func() {
+				for {
+					worker, err := unit.acquire(start)
+					if err != nil {
+						finished <- true
+						return
+					}
+					unit.release(worker)
+				}
+			}()
+		}
+
+		time.Sleep(time.Millisecond)
+
+		stopped := make(chan bool)
+		go func() {
+			unit.stop()
+			close(stopped)
+		}()
+
+		Eventually(stopped, 2*time.Second).Should(BeClosed())
+		for j := 0; j < 3; j++ {
+			Eventually(finished, 2*time.Second).Should(Receive())
+		}
+		Expect(unit.slots).To(HaveLen(4))
+	}
+}
+
+func Test_Middleware_executeMiddlewareLocally_KillsMiddlewareThatTimesOut(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &Middleware{}
+	unit.SetBinary("python")
+	unit.SetScript("import time\ntime.sleep(5)\n")
+	unit.SetTimeout(200 * time.Millisecond)
+
+	started := time.Now()
+	pair, err := unit.executeMiddlewareLocally(workerPair(""))
+
+	Expect(time.Since(started)).To(BeNumerically("<", 2*time.Second))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Middleware timed out after 200ms"))
+	Expect(pair.Response.Body).To(Equal("original body"))
+}
@@ -5,8 +5,10 @@ import (
 )
 
 type CachedResponse struct {
-	Request      RequestDetails
-	MatchingPair *RequestMatcherResponsePair
-	ClosestMiss  *ClosestMiss
-	ResponseTemplate *raymond.Template
+	Request                 RequestDetails
+	MatchingPair            *RequestMatcherResponsePair
+	ClosestMiss             *ClosestMiss
+	ResponseTemplate        *raymond.Template
+	ResponseHeaderTemplates map[string][]*raymond.Template
+	ResponseStatusTemplate  *raymond.Template
 }
@@ -13,6 +13,7 @@ type ClosestMiss struct {
 	Response       v2.ResponseDetailsViewV5
 	RequestMatcher v2.RequestMatcherViewV5
 	MissedFields   []string
+	SchemaErrors   []string
 	State          map[string]string
 }
 
@@ -23,7 +24,7 @@ func (this *ClosestMiss) GetMessage() string {
 	responseBytes, _ := json.MarshalIndent(this.Response, "", "    ")
 	currentState, _ := json.MarshalIndent(this.State, "", "    ")
 
-	return "\n\nThe following request was made, but was not matched by Hoverfly:\n\n" +
+	message := "\n\nThe following request was made, but was not matched by Hoverfly:\n\n" +
 		string(requestBytes) +
 		"\n\nWhilst Hoverfly has the following state:\n\n" +
 		string(currentState) +
@@ -33,6 +34,13 @@ func (this *ClosestMiss) GetMessage() string {
 		fmt.Sprint("["+strings.Join(this.MissedFields, ", ")+"]") +
 		"\n\nWhich if hit would have given the following response:\n\n" +
 		string(responseBytes)
+
+	if len(this.SchemaErrors) > 0 {
+		message += "\n\nThe request body was not valid against the JSON schema:\n\n" +
+			strings.Join(this.SchemaErrors, "\n")
+	}
+
+	return message
 }
 
 func (this *ClosestMiss) BuildView() *v2.ClosestMissView {
@@ -40,5 +48,6 @@ func (this *ClosestMiss) BuildView() *v2.ClosestMissView {
 		Response:       this.Response,
 		RequestMatcher: this.RequestMatcher,
 		MissedFields:   this.MissedFields,
+		SchemaErrors:   this.SchemaErrors,
 	}
 }
@@ -8,7 +8,9 @@ import (
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/SpectoLabs/hoverfly/core/delay"
 	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 )
 
 type ResponseDelayLogNormal struct {
@@ -23,6 +25,45 @@ type ResponseDelayLogNormal struct {
 
 type ResponseDelayLogNormalList []ResponseDelayLogNormal
 
+// LogNormalDelay is a delay declared on a single response, drawn from a log-normal distribution
+type LogNormalDelay struct {
+	Min    int
+	Max    int
+	Mean   int
+	Median int
+}
+
+func NewLogNormalDelayFromView(view *v2.LogNormalDelayView) *LogNormalDelay {
+	if view == nil {
+		return nil
+	}
+
+	return &LogNormalDelay{
+		Min:    view.Min,
+		Max:    view.Max,
+		Mean:   view.Mean,
+		Median: view.Median,
+	}
+}
+
+func (this *LogNormalDelay) BuildView() *v2.LogNormalDelayView {
+	if this == nil {
+		return nil
+	}
+
+	return &v2.LogNormalDelayView{
+		Min:    this.Min,
+		Max:    this.Max,
+		Mean:   this.Mean,
+		Median: this.Median,
+	}
+}
+
+// GenerateDelay returns a delay drawn from the distribution, in milliseconds
+func (this LogNormalDelay) GenerateDelay() int {
+	return delay.NewLogNormalGenerator(this.Min, this.Max, this.Mean, this.Median).GenerateDelay()
+}
+
 type ResponseDelaysLogNormal interface {
 	GetDelay(request RequestDetails) *ResponseDelayLogNormal
 	ConvertToResponseDelayLogNormalPayloadView() v1.ResponseDelayLogNormalPayloadView
@@ -41,39 +82,48 @@ func ValidateResponseDelayLogNormalPayload(j v1.ResponseDelayLogNormalPayloadVie
 			if _, err := regexp.Compile(delay.UrlPattern); err != nil {
 				return errors.New(fmt.Sprintf("Response delay entry skipped due to invalid pattern : %s", delay.UrlPattern))
 			}
-			if delay.Max < 0 || delay.Min < 0 {
-				return errors.New("Config error - delay min and max can't be less than 0")
-			}
-			if delay.Mean <= 0 || delay.Median <= 0 {
-				return errors.New("Config error - delay mean and median params can't be less or equals 0")
+			if err := ValidateLogNormalDelay(delay.Min, delay.Max, delay.Mean, delay.Median); err != nil {
+				return err
 			}
+		}
+	}
+	return nil
+}
 
-			if delay.Max != 0 {
-				if delay.Max < delay.Min {
-					return errors.New("Config error - min delay must be less than max one")
-				}
-				if delay.Mean > delay.Max {
-					return errors.New("Config error - mean delay can't be greather than max one")
-				}
-				if delay.Median > delay.Max {
-					return errors.New("Config error - median delay can't be and greather than max one")
-				}
-			}
+// ValidateLogNormalDelay checks that the parameters of a log-normal delay describe a valid distribution
+func ValidateLogNormalDelay(min, max, mean, median int) error {
+	if max < 0 || min < 0 {
+		return errors.New("Config error - delay min and max can't be less than 0")
+	}
+	if mean <= 0 || median <= 0 {
+		return errors.New("Config error - delay mean and median params can't be less or equals 0")
+	}
 
-			if delay.Min != 0 {
-				if delay.Mean < delay.Min {
-					return errors.New("Config error - mean delay can't be less than min one")
-				}
-				if delay.Median < delay.Min {
-					return errors.New("Config error - median delay can't be less than min one")
-				}
-			}
+	if max != 0 {
+		if max < min {
+			return errors.New("Config error - min delay must be less than max one")
+		}
+		if mean > max {
+			return errors.New("Config error - mean delay can't be greather than max one")
+		}
+		if median > max {
+			return errors.New("Config error - median delay can't be and greather than max one")
+		}
+	}
 
-			if delay.Median > delay.Mean {
-				return errors.New("Config error - mean delay can't be less than median one")
-			}
+	if min != 0 {
+		if mean < min {
+			return errors.New("Config error - mean delay can't be less than min one")
 		}
+		if median < min {
+			return errors.New("Config error - median delay can't be less than min one")
+		}
+	}
+
+	if median > mean {
+		return errors.New("Config error - mean delay can't be less than median one")
 	}
+
 	return nil
 }
 
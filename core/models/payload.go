@@ -191,8 +191,14 @@ type ResponseDetails struct {
 	Body             string
 	Headers          map[string][]string
 	Templated        bool
+	StatusTemplate   string
+	TemplateSeed     *int64
+	Javascript       string
 	TransitionsState map[string]string
 	RemovesState     []string
+	FixedDelay       int
+	LogNormalDelay   *LogNormalDelay
+	Fault            *ResponseFault
 }
 
 func NewResponseDetailsFromResponse(data interfaces.Response) ResponseDetails {
@@ -278,11 +284,28 @@ func (r *ResponseDetails) ConvertToResponseDetailsViewV5() v2.ResponseDetailsVie
 		Headers:          r.Headers,
 		EncodedBody:      needsEncoding,
 		Templated:        r.Templated,
+		StatusTemplate:   r.StatusTemplate,
+		TemplateSeed:     r.TemplateSeed,
+		Javascript:       r.Javascript,
 		RemovesState:     r.RemovesState,
 		TransitionsState: r.TransitionsState,
+		FixedDelay:       r.FixedDelay,
+		LogNormalDelay:   r.LogNormalDelay.BuildView(),
+		Fault:            r.Fault.BuildView(),
 	}
 }
 
+// GetDelay returns how long to wait before sending the response, in milliseconds.
+// A log-normal delay is added on top of any fixed delay.
+func (r ResponseDetails) GetDelay() int {
+	delay := r.FixedDelay
+	if r.LogNormalDelay != nil {
+		delay += r.LogNormalDelay.GenerateDelay()
+	}
+
+	return delay
+}
+
 func (this RequestDetails) GetRawQuery() string {
 	return this.rawQuery
 }
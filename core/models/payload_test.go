@@ -453,3 +453,45 @@ func Test_RequestDetails_QueryString_HandlesSpaces(t *testing.T) {
 
 	Expect(requestDetails.QueryString()).To(Equal("test=val ue"))
 }
+
+func Test_ResponseDetails_GetDelay_ReturnsFixedDelay(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.ResponseDetails{
+		FixedDelay: 150,
+	}
+
+	Expect(unit.GetDelay()).To(Equal(150))
+}
+
+func Test_ResponseDetails_GetDelay_AddsLogNormalDelayToFixedDelay(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.ResponseDetails{
+		FixedDelay: 100,
+		LogNormalDelay: &models.LogNormalDelay{
+			Min:    50,
+			Max:    60,
+			Mean:   55,
+			Median: 55,
+		},
+	}
+
+	Expect(unit.GetDelay()).To(BeNumerically(">=", 150))
+	Expect(unit.GetDelay()).To(BeNumerically("<=", 160))
+}
+
+func Test_ResponseFault_GetTruncateAt_DefaultsToHalfOfTheBody(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(models.ResponseFault{}.GetTruncateAt(10)).To(Equal(5))
+	Expect(models.ResponseFault{TruncateAt: 20}.GetTruncateAt(10)).To(Equal(5))
+	Expect(models.ResponseFault{TruncateAt: 3}.GetTruncateAt(10)).To(Equal(3))
+}
+
+func Test_ResponseFault_GetByteDelay_HasADefault(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(models.ResponseFault{}.GetByteDelay()).To(Equal(models.DefaultFaultByteDelay))
+	Expect(models.ResponseFault{ByteDelay: 5}.GetByteDelay()).To(Equal(5))
+}
@@ -1,7 +1,9 @@
 package models
 
 import (
+	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
@@ -11,6 +13,7 @@ import (
 type RequestFieldMatchers struct {
 	Matcher string
 	Value   interface{}
+	Config  map[string]interface{}
 }
 
 func NewRequestFieldMatchersFromView(matchers []v2.MatcherViewV5) []RequestFieldMatchers {
@@ -22,6 +25,7 @@ func NewRequestFieldMatchersFromView(matchers []v2.MatcherViewV5) []RequestField
 		convertedMatchers = append(convertedMatchers, RequestFieldMatchers{
 			Matcher: matcher.Matcher,
 			Value:   matcher.Value,
+			Config:  matcher.Config,
 		})
 	}
 	return convertedMatchers
@@ -57,12 +61,14 @@ func (this RequestFieldMatchers) BuildView() v2.MatcherViewV5 {
 	return v2.MatcherViewV5{
 		Matcher: this.Matcher,
 		Value:   this.Value,
+		Config:  this.Config,
 	}
 }
 
 type RequestMatcherResponsePair struct {
 	RequestMatcher RequestMatcher
 	Response       ResponseDetails
+	Responses      *ResponseSequence
 }
 
 func NewRequestMatcherResponsePairFromView(view *v2.RequestMatcherResponsePairViewV5) *RequestMatcherResponsePair {
@@ -73,6 +79,13 @@ func NewRequestMatcherResponsePairFromView(view *v2.RequestMatcherResponsePairVi
 		}
 	}
 
+	// A pair with a sequence of responses also has the first of them as its response
+	responses := NewResponseSequenceFromView(view.Responses, view.ResponsesMode)
+	response := NewResponseDetailsFromView(view.Response)
+	if responses != nil {
+		response = responses.Responses[0]
+	}
+
 	return &RequestMatcherResponsePair{
 		RequestMatcher: RequestMatcher{
 			Path:            NewRequestFieldMatchersFromView(view.RequestMatcher.Path),
@@ -85,10 +98,34 @@ func NewRequestMatcherResponsePairFromView(view *v2.RequestMatcherResponsePairVi
 			Query:           NewQueryRequestFieldMatchersFromMapView(view.RequestMatcher.Query),
 			RequiresState:   view.RequestMatcher.RequiresState,
 		},
-		Response: NewResponseDetailsFromResponse(view.Response),
+		Response:  response,
+		Responses: responses,
 	}
 }
 
+func NewResponseDetailsFromView(view v2.ResponseDetailsViewV5) ResponseDetails {
+	// Status templates, template seeds, JavaScript, delays and faults are only part of the v5 response view, so are not covered by interfaces.Response
+	response := NewResponseDetailsFromResponse(view)
+	response.StatusTemplate = view.StatusTemplate
+	response.TemplateSeed = view.TemplateSeed
+	response.Javascript = view.Javascript
+	response.FixedDelay = view.FixedDelay
+	response.LogNormalDelay = NewLogNormalDelayFromView(view.LogNormalDelay)
+	response.Fault = NewResponseFaultFromView(view.Fault)
+
+	return response
+}
+
+// NextResponse returns the response of the pair, or the next one from its sequence of responses.
+// It is not ok when the sequence has been exhausted.
+func (this RequestMatcherResponsePair) NextResponse() (ResponseDetails, bool) {
+	if this.Responses == nil {
+		return this.Response, true
+	}
+
+	return this.Responses.Next()
+}
+
 func (this *RequestMatcherResponsePair) BuildView() v2.RequestMatcherResponsePairViewV5 {
 
 	var path, method, destination, scheme, query, body []v2.MatcherViewV5
@@ -162,7 +199,7 @@ func (this *RequestMatcherResponsePair) BuildView() v2.RequestMatcherResponsePai
 		}
 	}
 
-	return v2.RequestMatcherResponsePairViewV5{
+	view := v2.RequestMatcherResponsePairViewV5{
 		RequestMatcher: v2.RequestMatcherViewV5{
 			Path:            path,
 			Method:          method,
@@ -176,6 +213,13 @@ func (this *RequestMatcherResponsePair) BuildView() v2.RequestMatcherResponsePai
 		},
 		Response: this.Response.ConvertToResponseDetailsViewV5(),
 	}
+
+	if this.Responses != nil {
+		view.Responses = this.Responses.BuildViews()
+		view.ResponsesMode = this.Responses.Mode
+	}
+
+	return view
 }
 
 type RequestMatcher struct {
@@ -214,6 +258,12 @@ func (this RequestMatcher) ToEagerlyCachable() *RequestDetails {
 		return nil
 	}
 
+	for _, field := range [][]RequestFieldMatchers{this.Body, this.Destination, this.Method, this.Path, this.DeprecatedQuery, this.Scheme} {
+		if len(field[0].Config) > 0 {
+			return nil
+		}
+	}
+
 	if this.Headers != nil && len(this.Headers) > 0 {
 		return nil
 	}
@@ -234,6 +284,60 @@ func (this RequestMatcher) ToEagerlyCachable() *RequestDetails {
 	}
 }
 
+// GetPathParams returns the values captured from the path by the named parameters
+// of path template matchers and the named groups of regex matchers
+func (this RequestMatcher) GetPathParams(path string) map[string]string {
+	pathParams := map[string]string{}
+	for _, fieldMatcher := range this.Path {
+		for name, value := range matchers.NamedCaptures(fieldMatcher.Matcher, fieldMatcher.Value, path, fieldMatcher.Config) {
+			pathParams[name] = value
+		}
+	}
+
+	return pathParams
+}
+
+// ResolveSchemaReferences replaces the names of schemas in jsonschema matchers with
+// the schemas themselves, so they can be matched without the simulation. It returns
+// an error if a schema is not in the given schemas or is not a valid JSON schema.
+func (this *RequestMatcher) ResolveSchemaReferences(schemas map[string]interface{}) error {
+	fields := [][]RequestFieldMatchers{this.Path, this.Method, this.Destination, this.Scheme, this.DeprecatedQuery, this.Body}
+	for _, headerMatchers := range this.Headers {
+		fields = append(fields, headerMatchers)
+	}
+	if this.Query != nil {
+		for _, queryMatchers := range *this.Query {
+			fields = append(fields, queryMatchers)
+		}
+	}
+
+	for _, fieldMatchers := range fields {
+		for i, fieldMatcher := range fieldMatchers {
+			if strings.ToLower(fieldMatcher.Matcher) != matchers.JsonSchema {
+				continue
+			}
+
+			if matchers.IsJsonSchemaReference(fieldMatcher.Value) {
+				name := fieldMatcher.Value.(string)
+				schema, ok := schemas[name]
+				if !ok {
+					return fmt.Errorf("JSON schema %s is not in the simulation", name)
+				}
+				fieldMatchers[i].Value = matchers.SchemaReference{
+					Name:   name,
+					Schema: schema,
+				}
+			}
+
+			if _, err := matchers.GetJsonSchema(fieldMatchers[i].Value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 type MatchError struct {
 	ClosestMiss *ClosestMiss
 	error       string
@@ -1,6 +1,7 @@
 package models_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
@@ -16,6 +17,9 @@ func Test_NewRequestFieldMatchersFromView_ReturnsNewStruct(t *testing.T) {
 		{
 			Matcher: matchers.Exact,
 			Value:   "exactly",
+			Config: map[string]interface{}{
+				"negate": true,
+			},
 		},
 	})
 
@@ -23,6 +27,9 @@ func Test_NewRequestFieldMatchersFromView_ReturnsNewStruct(t *testing.T) {
 	Expect(unit).To(HaveLen(1))
 	Expect(unit[0].Matcher).To(Equal("exact"))
 	Expect(unit[0].Value).To(Equal("exactly"))
+	Expect(unit[0].Config).To(Equal(map[string]interface{}{
+		"negate": true,
+	}))
 }
 
 func Test_NewRequestFieldMatchersFromView_WillReturnNilIfGivenNil(t *testing.T) {
@@ -39,11 +46,17 @@ func Test_NewRequestFieldMatchers_BuildView(t *testing.T) {
 	unit := models.RequestFieldMatchers{
 		Matcher: matchers.Exact,
 		Value:   "exactly",
+		Config: map[string]interface{}{
+			"ignoreCase": true,
+		},
 	}
 
 	view := unit.BuildView()
 	Expect(view.Matcher).To(Equal("exact"))
 	Expect(view.Value).To(Equal("exactly"))
+	Expect(view.Config).To(Equal(map[string]interface{}{
+		"ignoreCase": true,
+	}))
 }
 
 func Test_NewRequestMatcherResponsePairFromView_BuildsPair(t *testing.T) {
@@ -168,6 +181,160 @@ func Test_NewRequestMatcherResponsePairFromView_StoresTemplated(t *testing.T) {
 	Expect(unit.Response.Templated).To(BeTrue())
 }
 
+func Test_NewRequestMatcherResponsePairFromView_StoresStatusTemplate(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewRequestMatcherResponsePairFromView(&v2.RequestMatcherResponsePairViewV5{
+		Response: v2.ResponseDetailsViewV5{
+			Status:         200,
+			StatusTemplate: "{{ Request.QueryParam.status }}",
+			Templated:      true,
+		},
+	})
+
+	Expect(unit.Response.StatusTemplate).To(Equal("{{ Request.QueryParam.status }}"))
+	Expect(unit.BuildView().Response.StatusTemplate).To(Equal("{{ Request.QueryParam.status }}"))
+}
+
+func Test_NewRequestMatcherResponsePairFromView_StoresTemplateSeed(t *testing.T) {
+	RegisterTestingT(t)
+
+	seed := int64(42)
+
+	unit := models.NewRequestMatcherResponsePairFromView(&v2.RequestMatcherResponsePairViewV5{
+		Response: v2.ResponseDetailsViewV5{
+			Status:       200,
+			Templated:    true,
+			TemplateSeed: &seed,
+		},
+	})
+
+	Expect(*unit.Response.TemplateSeed).To(Equal(int64(42)))
+	Expect(*unit.BuildView().Response.TemplateSeed).To(Equal(int64(42)))
+}
+
+func Test_NewRequestMatcherResponsePairFromView_StoresResponses(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewRequestMatcherResponsePairFromView(&v2.RequestMatcherResponsePairViewV5{
+		Responses: []v2.ResponseDetailsViewV5{
+			{
+				Status: 503,
+			},
+			{
+				Status: 200,
+				Body:   "ok",
+			},
+		},
+		ResponsesMode: models.ResponsesModeCycle,
+	})
+
+	Expect(unit.Response.Status).To(Equal(503))
+	Expect(unit.Responses.Mode).To(Equal(models.ResponsesModeCycle))
+	Expect(unit.Responses.Responses).To(HaveLen(2))
+
+	view := unit.BuildView()
+	Expect(view.Response.Status).To(Equal(503))
+	Expect(view.ResponsesMode).To(Equal(models.ResponsesModeCycle))
+	Expect(view.Responses).To(HaveLen(2))
+	Expect(view.Responses[1].Status).To(Equal(200))
+	Expect(view.Responses[1].Body).To(Equal("ok"))
+}
+
+func Test_RequestMatcherResponsePair_BuildView_ExportsOnlyTheResponsesOfASequence(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewRequestMatcherResponsePairFromView(&v2.RequestMatcherResponsePairViewV5{
+		Responses: []v2.ResponseDetailsViewV5{
+			{
+				Status: 503,
+			},
+			{
+				Status: 200,
+				Body:   "ok",
+			},
+		},
+	})
+
+	exported, err := json.Marshal(unit.BuildView())
+	Expect(err).To(BeNil())
+
+	var pair map[string]interface{}
+	Expect(json.Unmarshal(exported, &pair)).To(Succeed())
+	Expect(pair).ToNot(HaveKey("response"))
+	Expect(pair["responses"]).To(HaveLen(2))
+
+	var view v2.RequestMatcherResponsePairViewV5
+	Expect(json.Unmarshal(exported, &view)).To(Succeed())
+	Expect(models.NewRequestMatcherResponsePairFromView(&view)).To(Equal(unit))
+
+	exported, err = json.Marshal(models.NewRequestMatcherResponsePairFromView(&v2.RequestMatcherResponsePairViewV5{
+		Response: v2.ResponseDetailsViewV5{
+			Status: 200,
+		},
+	}).BuildView())
+	Expect(err).To(BeNil())
+
+	pair = map[string]interface{}{}
+	Expect(json.Unmarshal(exported, &pair)).To(Succeed())
+	Expect(pair).To(HaveKey("response"))
+	Expect(pair).ToNot(HaveKey("responses"))
+}
+
+func Test_RequestMatcherResponsePair_NextResponse_ReturnsTheResponseWithoutASequence(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.RequestMatcherResponsePair{
+		Response: models.ResponseDetails{
+			Status: 200,
+		},
+	}
+
+	response, ok := unit.NextResponse()
+	Expect(ok).To(BeTrue())
+	Expect(response.Status).To(Equal(200))
+}
+
+func Test_NewRequestMatcherResponsePairFromView_StoresDelaysAndFault(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewRequestMatcherResponsePairFromView(&v2.RequestMatcherResponsePairViewV5{
+		Response: v2.ResponseDetailsViewV5{
+			Body:       "body",
+			FixedDelay: 100,
+			LogNormalDelay: &v2.LogNormalDelayView{
+				Mean:   50,
+				Median: 40,
+			},
+			Fault: &v2.ResponseFaultView{
+				Type:      "slowBody",
+				ByteDelay: 10,
+			},
+		},
+	})
+
+	Expect(unit.Response.FixedDelay).To(Equal(100))
+	Expect(unit.Response.LogNormalDelay).To(Equal(&models.LogNormalDelay{
+		Mean:   50,
+		Median: 40,
+	}))
+	Expect(unit.Response.Fault).To(Equal(&models.ResponseFault{
+		Type:      models.FaultSlowBody,
+		ByteDelay: 10,
+	}))
+
+	view := unit.BuildView()
+	Expect(view.Response.FixedDelay).To(Equal(100))
+	Expect(view.Response.LogNormalDelay).To(Equal(&v2.LogNormalDelayView{
+		Mean:   50,
+		Median: 40,
+	}))
+	Expect(view.Response.Fault).To(Equal(&v2.ResponseFaultView{
+		Type:      "slowBody",
+		ByteDelay: 10,
+	}))
+}
+
 func Test_RequestMatcher_BuildRequestDetailsFromExactMatches_GeneratesARequestDetails(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -221,6 +388,54 @@ func Test_RequestMatcher_BuildRequestDetailsFromExactMatches_GeneratesARequestDe
 	}))
 }
 
+func Test_RequestMatcher_BuildRequestDetailsFromExactMatches_ReturnsNilIfAnExactMatchHasConfig(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.RequestMatcher{
+		Body: []models.RequestFieldMatchers{
+			{
+				Matcher: matchers.Exact,
+				Value:   "body",
+			},
+		},
+		Destination: []models.RequestFieldMatchers{
+			{
+				Matcher: matchers.Exact,
+				Value:   "destination",
+			},
+		},
+		Method: []models.RequestFieldMatchers{
+			{
+				Matcher: matchers.Exact,
+				Value:   "method",
+				Config: map[string]interface{}{
+					"ignoreCase": true,
+				},
+			},
+		},
+		Path: []models.RequestFieldMatchers{
+			{
+				Matcher: matchers.Exact,
+				Value:   "path",
+			},
+		},
+		DeprecatedQuery: []models.RequestFieldMatchers{
+			{
+				Matcher: matchers.Exact,
+				Value:   "query=two",
+			},
+		},
+		Scheme: []models.RequestFieldMatchers{
+			{
+				Matcher: matchers.Exact,
+				Value:   "scheme",
+			},
+		},
+	}
+
+	Expect(unit.ToEagerlyCachable()).To(BeNil())
+}
+
 func Test_RequestMatcher_BuildRequestDetailsFromExactMatches_ReturnsNilIfEmpty(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+)
+
+const (
+	FaultConnectionReset = "connectionReset"
+	FaultEmptyResponse   = "emptyResponse"
+	FaultTruncatedBody   = "truncatedBody"
+	FaultSlowBody        = "slowBody"
+)
+
+// Default byte delay for a slow body fault, in milliseconds
+const DefaultFaultByteDelay = 100
+
+// ResponseFault describes how a response misbehaves when it is sent to the client
+type ResponseFault struct {
+	Type string
+	// Number of bytes of the body to send before the connection is closed, for a truncated body.
+	// Defaults to half of the body when it is not set or is not shorter than the body.
+	TruncateAt int
+	// Time to wait before sending each byte of the body, in milliseconds, for a slow body
+	ByteDelay int
+}
+
+func NewResponseFaultFromView(view *v2.ResponseFaultView) *ResponseFault {
+	if view == nil {
+		return nil
+	}
+
+	return &ResponseFault{
+		Type:       view.Type,
+		TruncateAt: view.TruncateAt,
+		ByteDelay:  view.ByteDelay,
+	}
+}
+
+func (this *ResponseFault) BuildView() *v2.ResponseFaultView {
+	if this == nil {
+		return nil
+	}
+
+	return &v2.ResponseFaultView{
+		Type:       this.Type,
+		TruncateAt: this.TruncateAt,
+		ByteDelay:  this.ByteDelay,
+	}
+}
+
+// GetTruncateAt returns the number of bytes of a body of the given length to send before
+// the connection is closed
+func (this ResponseFault) GetTruncateAt(bodyLength int) int {
+	if this.TruncateAt == 0 || this.TruncateAt >= bodyLength {
+		return bodyLength / 2
+	}
+
+	return this.TruncateAt
+}
+
+// GetByteDelay returns the time to wait before sending each byte of the body, in milliseconds
+func (this ResponseFault) GetByteDelay() int {
+	if this.ByteDelay == 0 {
+		return DefaultFaultByteDelay
+	}
+
+	return this.ByteDelay
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"sync"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+)
+
+// What a response sequence does once each of its responses has been returned
+const (
+	ResponsesModeCycle              = "cycle"
+	ResponsesModeStickAtLast        = "stickAtLast"
+	ResponsesModeFailAfterExhausted = "failAfterExhausted"
+)
+
+var ResponsesModes = []string{ResponsesModeCycle, ResponsesModeStickAtLast, ResponsesModeFailAfterExhausted}
+
+// ResponseSequence is a list of responses which are returned in turn each time a pair is matched.
+// The sequence is shared by every copy of the pair, so it keeps one count of the matches.
+type ResponseSequence struct {
+	Responses []ResponseDetails
+	Mode      string
+	next      int
+	mutex     sync.Mutex
+}
+
+func NewResponseSequenceFromView(views []v2.ResponseDetailsViewV5, mode string) *ResponseSequence {
+	if len(views) == 0 {
+		return nil
+	}
+
+	if mode == "" {
+		mode = ResponsesModeStickAtLast
+	}
+
+	sequence := &ResponseSequence{
+		Mode: mode,
+	}
+	for _, view := range views {
+		sequence.Responses = append(sequence.Responses, NewResponseDetailsFromView(view))
+	}
+
+	return sequence
+}
+
+// Next returns the response for this match of the pair. It is not ok once every
+// response has been returned from a sequence which fails after being exhausted.
+func (this *ResponseSequence) Next() (ResponseDetails, bool) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	if this.next >= len(this.Responses) {
+		if this.Mode == ResponsesModeFailAfterExhausted {
+			return ResponseDetails{}, false
+		}
+		return this.Responses[len(this.Responses)-1], true
+	}
+
+	response := this.Responses[this.next]
+
+	this.next = this.next + 1
+	if this.Mode == ResponsesModeCycle {
+		this.next = this.next % len(this.Responses)
+	}
+
+	return response, true
+}
+
+// Reset starts the sequence again from its first response
+func (this *ResponseSequence) Reset() {
+	this.mutex.Lock()
+	this.next = 0
+	this.mutex.Unlock()
+}
+
+func (this *ResponseSequence) BuildViews() []v2.ResponseDetailsViewV5 {
+	views := []v2.ResponseDetailsViewV5{}
+	for _, response := range this.Responses {
+		views = append(views, response.ConvertToResponseDetailsViewV5())
+	}
+
+	return views
+}
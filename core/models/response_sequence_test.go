@@ -0,0 +1,85 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	. "github.com/onsi/gomega"
+)
+
+var sequenceResponseViews = []v2.ResponseDetailsViewV5{
+	{
+		Status: 503,
+		Body:   "unavailable",
+	},
+	{
+		Status: 200,
+		Body:   "ok",
+	},
+}
+
+func nextStatuses(unit *models.ResponseSequence, count int) []int {
+	statuses := []int{}
+	for i := 0; i < count; i++ {
+		response, ok := unit.Next()
+		if !ok {
+			statuses = append(statuses, 0)
+		} else {
+			statuses = append(statuses, response.Status)
+		}
+	}
+	return statuses
+}
+
+func Test_NewResponseSequenceFromView_ReturnsNilWithoutResponses(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(models.NewResponseSequenceFromView(nil, models.ResponsesModeCycle)).To(BeNil())
+}
+
+func Test_NewResponseSequenceFromView_SticksAtLastByDefault(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewResponseSequenceFromView(sequenceResponseViews, "")
+
+	Expect(unit.Mode).To(Equal(models.ResponsesModeStickAtLast))
+	Expect(unit.Responses).To(HaveLen(2))
+	Expect(unit.Responses[0].Body).To(Equal("unavailable"))
+}
+
+func Test_ResponseSequence_Next_CanCycle(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewResponseSequenceFromView(sequenceResponseViews, models.ResponsesModeCycle)
+
+	Expect(nextStatuses(unit, 5)).To(Equal([]int{503, 200, 503, 200, 503}))
+}
+
+func Test_ResponseSequence_Next_CanStickAtLast(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewResponseSequenceFromView(sequenceResponseViews, models.ResponsesModeStickAtLast)
+
+	Expect(nextStatuses(unit, 4)).To(Equal([]int{503, 200, 200, 200}))
+}
+
+func Test_ResponseSequence_Next_CanFailAfterExhausted(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewResponseSequenceFromView(sequenceResponseViews, models.ResponsesModeFailAfterExhausted)
+
+	Expect(nextStatuses(unit, 4)).To(Equal([]int{503, 200, 0, 0}))
+}
+
+func Test_ResponseSequence_Reset_StartsFromTheFirstResponse(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewResponseSequenceFromView(sequenceResponseViews, models.ResponsesModeFailAfterExhausted)
+
+	Expect(nextStatuses(unit, 3)).To(Equal([]int{503, 200, 0}))
+
+	unit.Reset()
+
+	Expect(nextStatuses(unit, 3)).To(Equal([]int{503, 200, 0}))
+}
@@ -11,6 +11,8 @@ import (
 
 type Simulation struct {
 	matchingPairs           []RequestMatcherResponsePair
+	index                   *pairIndex
+	schemas                 map[string]interface{}
 	ResponseDelays          ResponseDelays
 	ResponseDelaysLogNormal ResponseDelaysLogNormal
 	RWMutex                 sync.RWMutex
@@ -20,6 +22,8 @@ func NewSimulation() *Simulation {
 
 	return &Simulation{
 		matchingPairs:           []RequestMatcherResponsePair{},
+		index:                   newPairIndex(),
+		schemas:                 map[string]interface{}{},
 		ResponseDelays:          &ResponseDelayList{},
 		ResponseDelaysLogNormal: &ResponseDelayLogNormalList{},
 	}
@@ -36,7 +40,7 @@ func (this *Simulation) AddPair(pair *RequestMatcherResponsePair) bool {
 		}
 	}
 	if !duplicate {
-		this.matchingPairs = append(this.matchingPairs, *pair)
+		this.appendPair(pair)
 	}
 	this.RWMutex.Unlock()
 	return !duplicate
@@ -44,7 +48,7 @@ func (this *Simulation) AddPair(pair *RequestMatcherResponsePair) bool {
 
 func (this *Simulation) AddPairWithoutCheck(pair *RequestMatcherResponsePair) {
 	this.RWMutex.Lock()
-	this.matchingPairs = append(this.matchingPairs, *pair)
+	this.appendPair(pair)
 	this.RWMutex.Unlock()
 }
 
@@ -113,10 +117,23 @@ func (this *Simulation) AddPairInSequence(pair *RequestMatcherResponsePair, stat
 		pair.RequestMatcher.RequiresState[sequenceKey] = strconv.Itoa(counter + 1)
 	}
 
-	this.matchingPairs = append(this.matchingPairs, *pair)
+	this.appendPair(pair)
 	this.RWMutex.Unlock()
 }
 
+// appendPair must be called whilst holding the write lock
+func (this *Simulation) appendPair(pair *RequestMatcherResponsePair) {
+	if this.index == nil {
+		this.index = newPairIndex()
+		for i, savedPair := range this.matchingPairs {
+			this.index.add(i, savedPair.RequestMatcher)
+		}
+	}
+
+	this.matchingPairs = append(this.matchingPairs, *pair)
+	this.index.add(len(this.matchingPairs)-1, pair.RequestMatcher)
+}
+
 func (this *Simulation) GetMatchingPairs() []RequestMatcherResponsePair {
 	this.RWMutex.RLock()
 	pairs := this.matchingPairs
@@ -128,5 +145,89 @@ func (this *Simulation) DeleteMatchingPairs() {
 	var pairs []RequestMatcherResponsePair
 	this.RWMutex.Lock()
 	this.matchingPairs = pairs
+	this.index = newPairIndex()
+	this.RWMutex.Unlock()
+}
+
+// ReplaceMatchingPairs replaces every pair in the simulation, rebuilding the index
+func (this *Simulation) ReplaceMatchingPairs(pairs []RequestMatcherResponsePair) {
+	this.RWMutex.Lock()
+	this.matchingPairs = []RequestMatcherResponsePair{}
+	this.index = newPairIndex()
+	for i := range pairs {
+		this.appendPair(&pairs[i])
+	}
+	this.RWMutex.Unlock()
+}
+
+// Replace swaps the pairs, schemas and delays of the simulation for those of another one,
+// so that requests never see a simulation which has only been partly loaded
+func (this *Simulation) Replace(simulation *Simulation) {
+	simulation.RWMutex.RLock()
+	defer simulation.RWMutex.RUnlock()
+
+	this.RWMutex.Lock()
+	this.matchingPairs = simulation.matchingPairs
+	this.index = simulation.index
+	this.schemas = simulation.schemas
+	this.ResponseDelays = simulation.ResponseDelays
+	this.ResponseDelaysLogNormal = simulation.ResponseDelaysLogNormal
+	this.RWMutex.Unlock()
+}
+
+// ResetResponseSequences starts every sequence of responses again from its first response
+func (this *Simulation) ResetResponseSequences() {
+	this.RWMutex.RLock()
+	for _, pair := range this.matchingPairs {
+		if pair.Responses != nil {
+			pair.Responses.Reset()
+		}
+	}
+	this.RWMutex.RUnlock()
+}
+
+// GetCandidatePairs returns the pairs which could match the request, based on their
+// exact method, destination and path matchers. Pairs are returned in the same order
+// as GetMatchingPairs, so matching strategies give the same results on either.
+func (this *Simulation) GetCandidatePairs(req RequestDetails, webserver bool) []RequestMatcherResponsePair {
+	this.RWMutex.RLock()
+	defer this.RWMutex.RUnlock()
+
+	if this.index == nil {
+		return this.matchingPairs
+	}
+
+	positions := this.index.candidates(req, webserver)
+	pairs := make([]RequestMatcherResponsePair, 0, len(positions))
+	for _, position := range positions {
+		pairs = append(pairs, this.matchingPairs[position])
+	}
+
+	return pairs
+}
+
+// AddSchemas adds JSON schemas which can be referred to by name from jsonschema
+// matchers, replacing any existing schemas with the same names
+func (this *Simulation) AddSchemas(schemas map[string]interface{}) {
+	this.RWMutex.Lock()
+	if this.schemas == nil {
+		this.schemas = map[string]interface{}{}
+	}
+	for name, schema := range schemas {
+		this.schemas[name] = schema
+	}
+	this.RWMutex.Unlock()
+}
+
+func (this *Simulation) GetSchemas() map[string]interface{} {
+	this.RWMutex.RLock()
+	schemas := this.schemas
+	this.RWMutex.RUnlock()
+	return schemas
+}
+
+func (this *Simulation) DeleteSchemas() {
+	this.RWMutex.Lock()
+	this.schemas = map[string]interface{}{}
 	this.RWMutex.Unlock()
 }
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+)
+
+// indexedField is the value a pair was indexed on for a single request field.
+// A wildcard field is one that cannot be narrowed down by an exact value, such
+// as a missing matcher or a glob/regex matcher.
+type indexedField struct {
+	value    string
+	wildcard bool
+}
+
+var wildcardField = indexedField{wildcard: true}
+
+type pairIndexKey struct {
+	method      indexedField
+	destination indexedField
+	path        indexedField
+}
+
+// pairIndex narrows down the pairs which could possibly match a request by using the
+// exact method, destination and path matchers of each pair. Pairs without an exact
+// matcher on a field go into the wildcard bucket for that field. Each bucket holds
+// positions in the simulation, in the order in which the pairs were added.
+type pairIndex struct {
+	buckets            map[pairIndexKey][]int
+	bucketsWithoutHost map[pairIndexKey][]int
+}
+
+func newPairIndex() *pairIndex {
+	return &pairIndex{
+		buckets:            map[pairIndexKey][]int{},
+		bucketsWithoutHost: map[pairIndexKey][]int{},
+	}
+}
+
+func (this *pairIndex) add(position int, requestMatcher RequestMatcher) {
+	key := pairIndexKey{
+		method:      getIndexedField(requestMatcher.Method),
+		destination: getIndexedField(requestMatcher.Destination),
+		path:        getIndexedField(requestMatcher.Path),
+	}
+	this.buckets[key] = append(this.buckets[key], position)
+
+	key.destination = wildcardField
+	this.bucketsWithoutHost[key] = append(this.bucketsWithoutHost[key], position)
+}
+
+// candidates returns the positions of the pairs which could match the request, in
+// ascending order. When webserver is true, the destination is not used, as it is not
+// used by matching either.
+func (this *pairIndex) candidates(req RequestDetails, webserver bool) []int {
+	buckets := this.buckets
+	destinations := []indexedField{{value: req.Destination}, wildcardField}
+	if webserver {
+		buckets = this.bucketsWithoutHost
+		destinations = []indexedField{wildcardField}
+	}
+
+	positions := []int{}
+	for _, method := range []indexedField{{value: req.Method}, wildcardField} {
+		for _, destination := range destinations {
+			for _, path := range []indexedField{{value: req.Path}, wildcardField} {
+				positions = append(positions, buckets[pairIndexKey{method, destination, path}]...)
+			}
+		}
+	}
+
+	// Every pair lives in exactly one bucket, so sorting is enough to restore the
+	// original order without duplicates
+	sort.Ints(positions)
+
+	return positions
+}
+
+// getIndexedField returns the value of the first exact matcher on the field. As all
+// matchers on a field must match, a request can only match the pair if it has that value.
+// Exact matchers with a config are skipped, as they can match other values.
+func getIndexedField(fieldMatchers []RequestFieldMatchers) indexedField {
+	for _, fieldMatcher := range fieldMatchers {
+		matcher := strings.ToLower(fieldMatcher.Matcher)
+		if (matcher != matchers.Exact && matcher != "") || len(fieldMatcher.Config) > 0 {
+			continue
+		}
+
+		if value, ok := fieldMatcher.Value.(string); ok {
+			return indexedField{value: value}
+		}
+	}
+
+	return wildcardField
+}
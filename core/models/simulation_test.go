@@ -15,7 +15,7 @@ func Test_Simulation_AddPair_CanAddAPairToTheArray(t *testing.T) {
 	unit := models.NewSimulation()
 
 	unit.AddPair(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -23,7 +23,7 @@ func Test_Simulation_AddPair_CanAddAPairToTheArray(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{},
+		Response: models.ResponseDetails{},
 	})
 
 	Expect(unit.GetMatchingPairs()).To(HaveLen(1))
@@ -37,7 +37,7 @@ func Test_Simulation_AddPair_CanAddAFullPairToTheArray(t *testing.T) {
 	unit := models.NewSimulation()
 
 	unit.AddPair(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Body: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -81,7 +81,7 @@ func Test_Simulation_AddPair_CanAddAFullPairToTheArray(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "testresponsebody",
 			Headers: map[string][]string{"testheader": {"testvalue"}},
 			Status:  200,
@@ -120,7 +120,7 @@ func Test_Simulation_AddPairInSequence_CanAddAFullPairToTheArray(t *testing.T) {
 	unit := models.NewSimulation()
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Body: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -164,7 +164,7 @@ func Test_Simulation_AddPairInSequence_CanAddAFullPairToTheArray(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "testresponsebody",
 			Headers: map[string][]string{"testheader": {"testvalue"}},
 			Status:  200,
@@ -203,7 +203,7 @@ func Test_Simulation_AddPairInSequence_CanSequence(t *testing.T) {
 	unit := models.NewSimulation()
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -211,7 +211,7 @@ func Test_Simulation_AddPairInSequence_CanSequence(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "1",
 			Headers: map[string][]string{"testheader": {"testvalue"}},
 			Status:  200,
@@ -219,7 +219,7 @@ func Test_Simulation_AddPairInSequence_CanSequence(t *testing.T) {
 	}, &state.State{State: map[string]string{}})
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -227,7 +227,7 @@ func Test_Simulation_AddPairInSequence_CanSequence(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "2",
 			Headers: map[string][]string{"testheader": {"testvalue"}},
 			Status:  200,
@@ -235,7 +235,7 @@ func Test_Simulation_AddPairInSequence_CanSequence(t *testing.T) {
 	}, &state.State{State: map[string]string{}})
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -243,7 +243,7 @@ func Test_Simulation_AddPairInSequence_CanSequence(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "3",
 			Headers: map[string][]string{"testheader": {"testvalue"}},
 			Status:  200,
@@ -280,7 +280,7 @@ func Test_Simulation_AddPairInSequence_CanBeUsedWithAddPair(t *testing.T) {
 	unit := models.NewSimulation()
 
 	unit.AddPair(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -288,7 +288,7 @@ func Test_Simulation_AddPairInSequence_CanBeUsedWithAddPair(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "1",
 			Headers: map[string][]string{"testheader": {"testvalue"}},
 			Status:  200,
@@ -296,7 +296,7 @@ func Test_Simulation_AddPairInSequence_CanBeUsedWithAddPair(t *testing.T) {
 	})
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -304,7 +304,7 @@ func Test_Simulation_AddPairInSequence_CanBeUsedWithAddPair(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "2",
 			Headers: map[string][]string{"testheader": {"testvalue"}},
 			Status:  200,
@@ -335,7 +335,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceTwoDifferentSequences(t *testi
 	state := state.NewState()
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -343,7 +343,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceTwoDifferentSequences(t *testi
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "1",
 			Headers: map[string][]string{"testheader": {"testvalue"}},
 			Status:  200,
@@ -351,7 +351,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceTwoDifferentSequences(t *testi
 	}, state)
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -359,7 +359,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceTwoDifferentSequences(t *testi
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "2",
 			Headers: map[string][]string{"testheader": {"testvalue"}},
 			Status:  200,
@@ -367,7 +367,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceTwoDifferentSequences(t *testi
 	}, state)
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -375,7 +375,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceTwoDifferentSequences(t *testi
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "different1",
 			Headers: map[string][]string{"testheader": {"testvalue"}},
 			Status:  200,
@@ -383,7 +383,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceTwoDifferentSequences(t *testi
 	}, state)
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -391,7 +391,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceTwoDifferentSequences(t *testi
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "different2",
 			Headers: map[string][]string{"testheader": {"testvalue"}},
 			Status:  200,
@@ -435,7 +435,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 	state := state.NewState()
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -443,7 +443,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "1",
 			Headers: map[string][]string{"testheader": {"testvalue"}},
 			Status:  200,
@@ -451,7 +451,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 	}, state)
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -459,7 +459,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "2",
 			Headers: map[string][]string{"testheader": {"testvalue"}},
 			Status:  200,
@@ -467,7 +467,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 	}, state)
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -475,7 +475,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "different1",
 			Headers: map[string][]string{"testheader": {"testvalue"}},
 			Status:  200,
@@ -483,7 +483,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 	}, state)
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -491,7 +491,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "different2",
 			Headers: map[string][]string{"testheader": {"testvalue"}},
 			Status:  200,
@@ -499,7 +499,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 	}, state)
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -507,7 +507,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "third1",
 			Headers: map[string][]string{"testheader": {"testvalue"}},
 			Status:  200,
@@ -515,7 +515,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 	}, state)
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -523,7 +523,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "third2",
 			Headers: map[string][]string{"testheader": {"testvalue"}},
 			Status:  200,
@@ -578,7 +578,7 @@ func Test_Simulation_AddPair_WillNotSaveDuplicates(t *testing.T) {
 	unit := models.NewSimulation()
 
 	isAdded := unit.AddPair(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -586,13 +586,13 @@ func Test_Simulation_AddPair_WillNotSaveDuplicates(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{},
+		Response: models.ResponseDetails{},
 	})
 
 	Expect(isAdded).To(BeTrue())
 
 	isAdded = unit.AddPair(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -600,7 +600,7 @@ func Test_Simulation_AddPair_WillNotSaveDuplicates(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{},
+		Response: models.ResponseDetails{},
 	})
 
 	Expect(isAdded).To(BeFalse())
@@ -613,7 +613,7 @@ func Test_Simulation_AddPair_WillSaveTwoWhenNotDuplicates(t *testing.T) {
 	unit := models.NewSimulation()
 
 	isAdded := unit.AddPair(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -621,12 +621,12 @@ func Test_Simulation_AddPair_WillSaveTwoWhenNotDuplicates(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{},
+		Response: models.ResponseDetails{},
 	})
 	Expect(isAdded).To(BeTrue())
 
 	isAdded = unit.AddPair(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -634,7 +634,7 @@ func Test_Simulation_AddPair_WillSaveTwoWhenNotDuplicates(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{},
+		Response: models.ResponseDetails{},
 	})
 	Expect(isAdded).To(BeTrue())
 
@@ -649,7 +649,7 @@ func Test_Simulation_GetMatchingPairs(t *testing.T) {
 	unit := models.NewSimulation()
 
 	unit.AddPair(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -657,7 +657,7 @@ func Test_Simulation_GetMatchingPairs(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{},
+		Response: models.ResponseDetails{},
 	})
 
 	Expect(unit.GetMatchingPairs()).To(HaveLen(1))
@@ -670,7 +670,7 @@ func Test_Simulation_DeleteMatchingPairs(t *testing.T) {
 	unit := models.NewSimulation()
 
 	unit.AddPair(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -678,10 +678,237 @@ func Test_Simulation_DeleteMatchingPairs(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{},
+		Response: models.ResponseDetails{},
 	})
 
 	unit.DeleteMatchingPairs()
 
 	Expect(unit.GetMatchingPairs()).To(HaveLen(0))
 }
+
+func Test_Simulation_ReplaceMatchingPairs(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+
+	unit.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "/old",
+				},
+			},
+		},
+		Response: models.ResponseDetails{},
+	})
+
+	unit.ReplaceMatchingPairs([]models.RequestMatcherResponsePair{
+		{
+			RequestMatcher: models.RequestMatcher{
+				Path: []models.RequestFieldMatchers{
+					{
+						Matcher: matchers.Exact,
+						Value:   "/new",
+					},
+				},
+			},
+		},
+	})
+
+	Expect(unit.GetMatchingPairs()).To(HaveLen(1))
+	Expect(unit.GetMatchingPairs()[0].RequestMatcher.Path[0].Value).To(Equal("/new"))
+
+	Expect(unit.GetCandidatePairs(models.RequestDetails{Path: "/old"}, false)).To(HaveLen(0))
+	Expect(unit.GetCandidatePairs(models.RequestDetails{Path: "/new"}, false)).To(HaveLen(1))
+}
+
+func Test_Simulation_Replace_TakesThePairsSchemasAndDelaysOfAnotherSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+	unit.AddSchemas(map[string]interface{}{"old": map[string]interface{}{}})
+	unit.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "/old",
+				},
+			},
+		},
+	})
+
+	simulation := models.NewSimulation()
+	simulation.AddSchemas(map[string]interface{}{"new": map[string]interface{}{}})
+	simulation.ResponseDelays = &models.ResponseDelayList{
+		{
+			UrlPattern: "new",
+			Delay:      100,
+		},
+	}
+	simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "/new",
+				},
+			},
+		},
+	})
+
+	unit.Replace(simulation)
+
+	Expect(unit.GetMatchingPairs()).To(HaveLen(1))
+	Expect(unit.GetMatchingPairs()[0].RequestMatcher.Path[0].Value).To(Equal("/new"))
+	Expect(unit.GetCandidatePairs(models.RequestDetails{Path: "/new"}, false)).To(HaveLen(1))
+	Expect(unit.GetSchemas()).To(HaveKey("new"))
+	Expect(unit.GetSchemas()).ToNot(HaveKey("old"))
+	Expect(unit.ResponseDelays.GetDelay(models.RequestDetails{Destination: "new"})).ToNot(BeNil())
+}
+
+func Test_Simulation_GetCandidatePairs_ReturnsPairsWithMatchingExactFieldsOrWildcards(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+
+	unit.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "/other",
+				},
+			},
+		},
+		Response: models.ResponseDetails{Body: "other path"},
+	})
+
+	unit.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Glob,
+					Value:   "/api/*",
+				},
+			},
+		},
+		Response: models.ResponseDetails{Body: "glob path"},
+	})
+
+	unit.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Method: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "GET",
+				},
+			},
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "/api/users",
+				},
+			},
+		},
+		Response: models.ResponseDetails{Body: "exact path"},
+	})
+
+	unit.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Method: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "POST",
+				},
+			},
+		},
+		Response: models.ResponseDetails{Body: "other method"},
+	})
+
+	unit.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{},
+		Response:       models.ResponseDetails{Body: "no matchers"},
+	})
+
+	candidates := unit.GetCandidatePairs(models.RequestDetails{
+		Method:      "GET",
+		Destination: "test.com",
+		Path:        "/api/users",
+	}, false)
+
+	Expect(candidates).To(HaveLen(3))
+	Expect(candidates[0].Response.Body).To(Equal("glob path"))
+	Expect(candidates[1].Response.Body).To(Equal("exact path"))
+	Expect(candidates[2].Response.Body).To(Equal("no matchers"))
+}
+
+func Test_Simulation_GetCandidatePairs_IgnoresDestinationForWebserver(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+
+	unit.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Destination: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "other.com",
+				},
+			},
+		},
+		Response: models.ResponseDetails{Body: "other destination"},
+	})
+
+	request := models.RequestDetails{
+		Method:      "GET",
+		Destination: "test.com",
+		Path:        "/",
+	}
+
+	Expect(unit.GetCandidatePairs(request, false)).To(HaveLen(0))
+	Expect(unit.GetCandidatePairs(request, true)).To(HaveLen(1))
+}
+
+func Test_Simulation_GetCandidatePairs_DoesNotIndexExactMatchersWithConfig(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+
+	unit.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "/health",
+					Config: map[string]interface{}{
+						"negate": true,
+					},
+				},
+			},
+		},
+		Response: models.ResponseDetails{Body: "not health"},
+	})
+
+	Expect(unit.GetCandidatePairs(models.RequestDetails{
+		Method:      "GET",
+		Destination: "test.com",
+		Path:        "/users",
+	}, false)).To(HaveLen(1))
+}
+
+func Test_Simulation_GetCandidatePairs_ReturnsNothingAfterDeleteMatchingPairs(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+
+	unit.AddPairWithoutCheck(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{},
+		Response:       models.ResponseDetails{},
+	})
+
+	unit.DeleteMatchingPairs()
+
+	Expect(unit.GetCandidatePairs(models.RequestDetails{}, false)).To(HaveLen(0))
+}
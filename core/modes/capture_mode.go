@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"net/http"
 
+	"github.com/SpectoLabs/hoverfly/core/middleware"
 	"github.com/SpectoLabs/hoverfly/core/models"
 	"github.com/SpectoLabs/hoverfly/core/util"
 
@@ -13,9 +14,9 @@ import (
 )
 
 type HoverflyCapture interface {
-	ApplyMiddleware(models.RequestResponsePair) (models.RequestResponsePair, error)
+	ApplyMiddleware(models.RequestResponsePair, string) (models.RequestResponsePair, error)
 	DoRequest(*http.Request) (*http.Response, error)
-	Save(*models.RequestDetails, *models.ResponseDetails, []string, bool) error
+	Save(*models.RequestDetails, *models.ResponseDetails, *ModeArguments) error
 }
 
 type CaptureMode struct {
@@ -30,6 +31,10 @@ func (this *CaptureMode) View() v2.ModeView {
 			Headers:          this.Arguments.Headers,
 			MatchingStrategy: this.Arguments.MatchingStrategy,
 			Stateful:         this.Arguments.Stateful,
+			PathMatcher:      this.Arguments.PathMatcher,
+			BodyMatcher:      this.Arguments.BodyMatcher,
+			IgnoreJsonPaths:  this.Arguments.IgnoreJsonPaths,
+			IgnoreQueryKeys:  this.Arguments.IgnoreQueryKeys,
 		},
 	}
 }
@@ -48,7 +53,7 @@ func (this CaptureMode) Process(request *http.Request, details models.RequestDet
 		request.Body = ioutil.NopCloser(bytes.NewBuffer([]byte("")))
 	}
 
-	pair, err := this.Hoverfly.ApplyMiddleware(models.RequestResponsePair{Request: details})
+	pair, err := this.Hoverfly.ApplyMiddleware(models.RequestResponsePair{Request: details}, middleware.PreRequest)
 	if err != nil {
 		return ReturnErrorAndLog(request, err, &pair, "There was an error when applying middleware to http request", Capture)
 	}
@@ -77,7 +82,7 @@ func (this CaptureMode) Process(request *http.Request, details models.RequestDet
 	}
 
 	// saving response body with request/response meta to cache
-	err = this.Hoverfly.Save(&pair.Request, responseObj, this.Arguments.Headers, this.Arguments.Stateful)
+	err = this.Hoverfly.Save(&pair.Request, responseObj, &this.Arguments)
 	if err != nil {
 		return ReturnErrorAndLog(request, err, &pair, "There was an error when saving request and response", Capture)
 	}
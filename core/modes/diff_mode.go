@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"reflect"
 	"time"
 
@@ -33,6 +34,8 @@ type DiffMode struct {
 	Hoverfly   HoverflyDiff
 	DiffReport v2.DiffReport
 	Arguments  ModeArguments
+
+	rules []diffRule
 }
 
 func (this *DiffMode) View() v2.ModeView {
@@ -42,12 +45,21 @@ func (this *DiffMode) View() v2.ModeView {
 			Headers:          this.Arguments.Headers,
 			MatchingStrategy: this.Arguments.MatchingStrategy,
 			Stateful:         this.Arguments.Stateful,
+			DiffRules:        this.Arguments.DiffRules,
 		},
 	}
 }
 
 func (this *DiffMode) SetArguments(arguments ModeArguments) {
+	rules, err := newDiffRules(arguments.DiffRules)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err.Error(),
+		}).Warn("Diff rules have not been set")
+	}
+
 	this.Arguments = arguments
+	this.rules = rules
 }
 
 //TODO: We should only need one of these two parameters
@@ -151,20 +163,20 @@ func (this *DiffMode) headerDiff(expected map[string][]string, actual map[string
 	return same
 }
 
+// bodyDiff compares JSON bodies value by value and XML bodies node by node when there
+// are rules with an XPath. Any other body is compared as a whole.
 func (this *DiffMode) bodyDiff(expected *models.ResponseDetails, actual *models.ResponseDetails) bool {
 	var expectedJson, actualJson interface{}
 
-	err := unmarshalResponseToInterface(expected, &expectedJson)
-	if err != nil {
-		return this.doDeepEqual(expected.Body, actual.Body)
+	if unmarshalResponseToInterface(expected, &expectedJson) == nil && unmarshalResponseToInterface(actual, &actualJson) == nil {
+		return this.jsonDiff("body", []string{}, expectedJson, actualJson)
 	}
 
-	err = unmarshalResponseToInterface(actual, &actualJson)
-	if err != nil {
-		return this.doDeepEqual(expected.Body, actual.Body)
+	if same, isXml := this.xmlBodyDiff(expected, actual); isXml {
+		return same
 	}
 
-	return this.JsonDiff("body", expectedJson.(map[string]interface{}), actualJson.(map[string]interface{}))
+	return this.doDeepEqual(expected.Body, actual.Body)
 }
 
 func (this *DiffMode) doDeepEqual(expected string, actual string) bool {
@@ -245,34 +257,133 @@ func decompress(body []byte, encodings []string) ([]byte, error) {
 }
 
 func (this *DiffMode) JsonDiff(prefix string, expected map[string]interface{}, actual map[string]interface{}) bool {
+	return this.jsonObjectDiff(prefix, []string{}, expected, actual)
+}
+
+// jsonDiff compares the values at the path, which is the keys and indexes used to get
+// to them, applying the first rule for that path
+func (this *DiffMode) jsonDiff(field string, path []string, expected, actual interface{}) bool {
+	rule := this.jsonRule(path)
+	if rule != nil && rule.Ignore {
+		return true
+	}
+
+	if rule != nil && rule.regex != nil {
+		return this.regexDiff(field, rule, jsonString(actual))
+	}
+
+	if reflect.TypeOf(expected) != reflect.TypeOf(actual) {
+		this.addEntry(field, expected, actual)
+		return false
+	}
+
+	switch expectedValue := expected.(type) {
+	case map[string]interface{}:
+		return this.jsonObjectDiff(field, path, expectedValue, actual.(map[string]interface{}))
+	case []interface{}:
+		return this.jsonArrayDiff(field, path, rule, expectedValue, actual.([]interface{}))
+	case float64:
+		if rule != nil && rule.Tolerance != 0 {
+			return this.toleranceDiff(field, rule, expectedValue, actual.(float64))
+		}
+	}
+
+	if expected != actual {
+		this.addEntry(field, expected, actual)
+		return false
+	}
+
+	return true
+}
+
+func (this *DiffMode) jsonObjectDiff(prefix string, path []string, expected, actual map[string]interface{}) bool {
 	same := true
 	for k := range expected {
 		param := prefix + "/" + k
-		if _, ok := actual[k]; !ok {
-			this.addEntry(param, expected[k], nil)
-			same = false
-		} else if reflect.TypeOf(expected[k]) != reflect.TypeOf(actual[k]) {
-			this.addEntry(param, expected[k], actual[k])
+		keyPath := append(path[:len(path):len(path)], k)
+
+		actualValue, ok := actual[k]
+		if !ok {
+			if rule := this.jsonRule(keyPath); rule == nil || !rule.Ignore {
+				this.addEntry(param, expected[k], nil)
+				same = false
+			}
+		} else if !this.jsonDiff(param, keyPath, expected[k], actualValue) {
 			same = false
-		} else {
-			switch expected[k].(type) {
-			default:
-				if expected[k] != actual[k] {
-					this.addEntry(param, expected[k], actual[k])
-					same = false
-				}
-			case map[string]interface{}:
-				if !this.JsonDiff(param, expected[k].(map[string]interface{}), actual[k].(map[string]interface{})) {
-					same = false
-				}
-			case []interface{}:
-				if !reflect.DeepEqual(expected[k], actual[k]) {
-					this.addEntry(param, expected[k], actual[k])
-					same = false
-				}
+		}
+	}
+
+	return same
+}
+
+// jsonArrayDiff reports the whole array when any of its items differ. Items of an
+// unordered array can be matched by any item of the other array.
+func (this *DiffMode) jsonArrayDiff(field string, path []string, rule *diffRule, expected, actual []interface{}) bool {
+	unordered := rule != nil && rule.Unordered
+
+	same := len(expected) == len(actual)
+	matched := make([]bool, len(actual))
+	for i := 0; same && i < len(expected); i++ {
+		itemPath := append(path[:len(path):len(path)], fmt.Sprintf("[%d]", i))
+
+		found := false
+		for j := range actual {
+			if matched[j] || (!unordered && i != j) {
+				continue
+			}
+
+			comparison := &DiffMode{rules: this.rules}
+			if comparison.jsonDiff(field, itemPath, expected[i], actual[j]) {
+				matched[j] = true
+				found = true
+				break
 			}
 		}
+		same = found
+	}
+
+	if !same {
+		this.addEntry(field, expected, actual)
 	}
 
 	return same
 }
+
+func (this *DiffMode) jsonRule(path []string) *diffRule {
+	for i := range this.rules {
+		if this.rules[i].matchesJsonPath(path) {
+			return &this.rules[i]
+		}
+	}
+
+	return nil
+}
+
+func (this *DiffMode) regexDiff(field string, rule *diffRule, actual string) bool {
+	if !rule.regex.MatchString(actual) {
+		this.addEntry(field, "matching "+rule.Regex, actual)
+		return false
+	}
+
+	return true
+}
+
+func (this *DiffMode) toleranceDiff(field string, rule *diffRule, expected, actual float64) bool {
+	if math.Abs(expected-actual) > rule.Tolerance {
+		this.addEntry(field, fmt.Sprintf("%v (within %v)", expected, rule.Tolerance), actual)
+		return false
+	}
+
+	return true
+}
+
+// jsonString is the value as it would be written in JSON, other than strings which
+// are left unquoted
+func jsonString(value interface{}) string {
+	if stringValue, ok := value.(string); ok {
+		return stringValue
+	}
+
+	bytes, _ := json.Marshal(value)
+	return string(bytes)
+}
@@ -420,3 +420,129 @@ func Test_JsonDiff_WhenSameThenReturnsTrue(t *testing.T) {
 	Expect(result).To(Equal(true))
 	Expect(len(diffMode.DiffReport.DiffEntries)).To(Equal(0))
 }
+
+func newDiffModeWithRules(rules ...v2.DiffRuleView) *DiffMode {
+	unit := &DiffMode{}
+	unit.SetArguments(ModeArguments{DiffRules: rules})
+	Expect(unit.rules).To(HaveLen(len(rules)))
+	return unit
+}
+
+func Test_DiffMode_DiffRules_IgnoreJsonPaths(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := newDiffModeWithRules(
+		v2.DiffRuleView{Path: "$.timestamp", Ignore: true},
+		v2.DiffRuleView{Path: "$.items[*].id", Ignore: true},
+	)
+
+	unit.diffResponse(
+		&models.ResponseDetails{Body: `{"timestamp": "2018-01-01", "items": [{"id": 1, "name": "one"}]}`},
+		&models.ResponseDetails{Body: `{"items": [{"id": 2, "name": "one"}]}`},
+		[]string{"*"})
+
+	Expect(unit.DiffReport.DiffEntries).To(BeEmpty())
+}
+
+func Test_DiffMode_DiffRules_CompareNumbersWithinATolerance(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := newDiffModeWithRules(v2.DiffRuleView{Path: "$.prices[*]", Tolerance: 0.5})
+
+	unit.diffResponse(
+		&models.ResponseDetails{Body: `{"prices": [10, 20]}`},
+		&models.ResponseDetails{Body: `{"prices": [10.4, 21]}`},
+		[]string{"*"})
+
+	Expect(unit.DiffReport.DiffEntries).To(ConsistOf(
+		v2.DiffReportEntry{"body/prices", "[10 20]", "[10.4 21]"}))
+
+	unit = newDiffModeWithRules(v2.DiffRuleView{Path: "$.total", Tolerance: 0.5})
+
+	unit.diffResponse(
+		&models.ResponseDetails{Body: `{"total": 10}`},
+		&models.ResponseDetails{Body: `{"total": 11}`},
+		[]string{"*"})
+
+	Expect(unit.DiffReport.DiffEntries).To(ConsistOf(
+		v2.DiffReportEntry{"body/total", "10 (within 0.5)", "11"}))
+}
+
+func Test_DiffMode_DiffRules_CompareArraysIgnoringOrder(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := newDiffModeWithRules(v2.DiffRuleView{Path: "$.tags", Unordered: true})
+
+	unit.diffResponse(
+		&models.ResponseDetails{Body: `{"tags": ["a", "b", "c"], "ordered": [1, 2]}`},
+		&models.ResponseDetails{Body: `{"tags": ["c", "a", "b"], "ordered": [2, 1]}`},
+		[]string{"*"})
+
+	Expect(unit.DiffReport.DiffEntries).To(ConsistOf(
+		v2.DiffReportEntry{"body/ordered", "[1 2]", "[2 1]"}))
+}
+
+func Test_DiffMode_DiffRules_TreatFieldsAsRegexAssertions(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := newDiffModeWithRules(
+		v2.DiffRuleView{Path: "$.id", Regex: "^[0-9a-f]{8}$"},
+		v2.DiffRuleView{Path: "$.created", Regex: `^\d{4}-\d{2}-\d{2}$`},
+	)
+
+	unit.diffResponse(
+		&models.ResponseDetails{Body: `{"id": "00000000", "created": "2018-01-01"}`},
+		&models.ResponseDetails{Body: `{"id": "3fa85f64", "created": "yesterday"}`},
+		[]string{"*"})
+
+	Expect(unit.DiffReport.DiffEntries).To(ConsistOf(
+		v2.DiffReportEntry{"body/created", `matching ^\d{4}-\d{2}-\d{2}$`, "yesterday"}))
+}
+
+func Test_DiffMode_DiffRules_ApplyXPathsToXmlBodies(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := newDiffModeWithRules(
+		v2.DiffRuleView{Path: "/order/@id", Ignore: true},
+		v2.DiffRuleView{Path: "/order/created", Regex: `^\d{4}$`},
+		v2.DiffRuleView{Path: "/order/total", Tolerance: 0.1},
+		v2.DiffRuleView{Path: "/order/items", Unordered: true},
+	)
+
+	unit.diffResponse(
+		&models.ResponseDetails{Body: `<order id="1"><created>2018</created><total>9.99</total><items><item>a</item><item>b</item></items><status>open</status></order>`},
+		&models.ResponseDetails{Body: `<order id="2"><created>2019</created><total>10.05</total><items><item>b</item><item>a</item></items><status>closed</status></order>`},
+		[]string{"*"})
+
+	Expect(unit.DiffReport.DiffEntries).To(ConsistOf(
+		v2.DiffReportEntry{"body/order/status", "open", "closed"}))
+}
+
+func Test_DiffMode_DiffRules_ReportsXmlElementsWhichDiffer(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := newDiffModeWithRules(v2.DiffRuleView{Path: "/order/total", Tolerance: 0.1})
+
+	unit.diffResponse(
+		&models.ResponseDetails{Body: `<order><item>a</item><item>b</item><total>9.99</total></order>`},
+		&models.ResponseDetails{Body: `<order><item>a</item><item>c</item><total>12</total></order>`},
+		[]string{"*"})
+
+	Expect(unit.DiffReport.DiffEntries).To(ConsistOf(
+		v2.DiffReportEntry{"body/order/item[2]", "b", "c"},
+		v2.DiffReportEntry{"body/order/total", "9.99 (within 0.1)", "12"}))
+}
+
+func Test_DiffMode_ComparesXmlBodiesAsAWholeWithoutXPathRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := newDiffModeWithRules(v2.DiffRuleView{Path: "$.id", Ignore: true})
+
+	unit.diffResponse(
+		&models.ResponseDetails{Body: `<order id="1"/>`},
+		&models.ResponseDetails{Body: `<order id="2"/>`},
+		[]string{"*"})
+
+	Expect(unit.DiffReport.DiffEntries).To(ConsistOf(
+		v2.DiffReportEntry{"body", `<order id="1"/>`, `<order id="2"/>`}))
+}
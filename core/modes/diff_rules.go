@@ -0,0 +1,95 @@
+package modes
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ChrisTrenkamp/goxpath"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/util"
+)
+
+// diffRule is a diff rule with its path and regex parsed. Rules with a JSON path are
+// applied to JSON bodies and rules with an XPath to XML bodies.
+type diffRule struct {
+	v2.DiffRuleView
+
+	jsonPath []string
+	xpath    *goxpath.XPathExec
+	regex    *regexp.Regexp
+}
+
+// ValidateDiffRules checks that every rule has a JSON path or an XPath, does exactly
+// one thing and has a valid regex
+func ValidateDiffRules(ruleViews []v2.DiffRuleView) error {
+	_, err := newDiffRules(ruleViews)
+	return err
+}
+
+func newDiffRules(ruleViews []v2.DiffRuleView) ([]diffRule, error) {
+	rules := []diffRule{}
+
+	for _, ruleView := range ruleViews {
+		rule := diffRule{DiffRuleView: ruleView}
+
+		if strings.HasPrefix(ruleView.Path, "$") {
+			rule.jsonPath = util.ParseJsonPath(ruleView.Path)
+			if len(rule.jsonPath) == 0 {
+				return nil, fmt.Errorf("Diff rule path '%s' should be in the form $.field.list[0].field", ruleView.Path)
+			}
+		} else if strings.HasPrefix(ruleView.Path, "/") {
+			xpath, err := goxpath.Parse(ruleView.Path)
+			if err != nil {
+				return nil, fmt.Errorf("Diff rule path '%s' is not a valid XPath: %s", ruleView.Path, err.Error())
+			}
+			rule.xpath = &xpath
+		} else {
+			return nil, fmt.Errorf("Diff rule path '%s' should be a JSON path starting with $ or an XPath starting with /", ruleView.Path)
+		}
+
+		comparisons := 0
+		for _, isSet := range []bool{ruleView.Ignore, ruleView.Tolerance != 0, ruleView.Unordered, ruleView.Regex != ""} {
+			if isSet {
+				comparisons++
+			}
+		}
+		if comparisons != 1 {
+			return nil, fmt.Errorf("Diff rule for '%s' should do one of ignore, tolerance, unordered or regex", ruleView.Path)
+		}
+
+		if ruleView.Tolerance < 0 {
+			return nil, fmt.Errorf("Diff rule for '%s' cannot have a negative tolerance", ruleView.Path)
+		}
+
+		if ruleView.Regex != "" {
+			var err error
+			rule.regex, err = regexp.Compile(ruleView.Regex)
+			if err != nil {
+				return nil, fmt.Errorf("Diff rule regex '%s' is not valid: %s", ruleView.Regex, err.Error())
+			}
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// matchesJsonPath is true when the rule has a JSON path which selects the value at
+// the keys and indexes given. Both [*] and * are wildcards.
+func (this diffRule) matchesJsonPath(path []string) bool {
+	if len(this.jsonPath) == 0 || len(this.jsonPath) != len(path) {
+		return false
+	}
+
+	for i, token := range this.jsonPath {
+		isIndex := strings.HasPrefix(path[i], "[")
+		if token == path[i] || (token == "[*]" && isIndex) || (token == "*" && !isIndex) {
+			continue
+		}
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,55 @@
+package modes
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	. "github.com/onsi/gomega"
+)
+
+func Test_ValidateDiffRules_AcceptsJsonPathsAndXPaths(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(ValidateDiffRules([]v2.DiffRuleView{
+		{Path: "$.items[*].id", Ignore: true},
+		{Path: "$.total", Tolerance: 0.01},
+		{Path: "/order/items", Unordered: true},
+		{Path: "/order/@id", Regex: "^[0-9]+$"},
+	})).To(Succeed())
+}
+
+func Test_ValidateDiffRules_RejectsInvalidRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(ValidateDiffRules([]v2.DiffRuleView{{Path: "body.id", Ignore: true}})).To(
+		MatchError("Diff rule path 'body.id' should be a JSON path starting with $ or an XPath starting with /"))
+
+	Expect(ValidateDiffRules([]v2.DiffRuleView{{Path: "$", Ignore: true}})).To(
+		MatchError("Diff rule path '$' should be in the form $.field.list[0].field"))
+
+	Expect(ValidateDiffRules([]v2.DiffRuleView{{Path: "/order[", Ignore: true}})).To(
+		MatchError(ContainSubstring("Diff rule path '/order[' is not a valid XPath")))
+
+	Expect(ValidateDiffRules([]v2.DiffRuleView{{Path: "$.id"}})).To(
+		MatchError("Diff rule for '$.id' should do one of ignore, tolerance, unordered or regex"))
+
+	Expect(ValidateDiffRules([]v2.DiffRuleView{{Path: "$.id", Ignore: true, Unordered: true}})).To(
+		MatchError("Diff rule for '$.id' should do one of ignore, tolerance, unordered or regex"))
+
+	Expect(ValidateDiffRules([]v2.DiffRuleView{{Path: "$.id", Tolerance: -1}})).To(
+		MatchError("Diff rule for '$.id' cannot have a negative tolerance"))
+
+	Expect(ValidateDiffRules([]v2.DiffRuleView{{Path: "$.id", Regex: "("}})).To(
+		MatchError(ContainSubstring("Diff rule regex '(' is not valid")))
+}
+
+func Test_diffRule_matchesJsonPath_SupportsWildcards(t *testing.T) {
+	RegisterTestingT(t)
+
+	rules, err := newDiffRules([]v2.DiffRuleView{{Path: "$.items[*].*", Ignore: true}})
+	Expect(err).To(BeNil())
+
+	Expect(rules[0].matchesJsonPath([]string{"items", "[3]", "id"})).To(BeTrue())
+	Expect(rules[0].matchesJsonPath([]string{"items", "id", "id"})).To(BeFalse())
+	Expect(rules[0].matchesJsonPath([]string{"items", "[3]"})).To(BeFalse())
+}
@@ -0,0 +1,260 @@
+package modes
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ChrisTrenkamp/goxpath"
+	"github.com/ChrisTrenkamp/goxpath/tree"
+	"github.com/ChrisTrenkamp/goxpath/tree/xmltree"
+	"github.com/SpectoLabs/hoverfly/core/models"
+)
+
+// xmlBodyDiff compares XML bodies node by node, so that rules with an XPath can be
+// applied to the nodes they select in the expected body. It is only used when there
+// are such rules, and isXml is false when either body is not XML.
+func (this *DiffMode) xmlBodyDiff(expected, actual *models.ResponseDetails) (same bool, isXml bool) {
+	rules := []*diffRule{}
+	for i := range this.rules {
+		if this.rules[i].xpath != nil {
+			rules = append(rules, &this.rules[i])
+		}
+	}
+
+	if len(rules) == 0 {
+		return false, false
+	}
+
+	expectedRoot, err := parseXmlBody(expected)
+	if err != nil {
+		return false, false
+	}
+
+	actualRoot, err := parseXmlBody(actual)
+	if err != nil {
+		return false, false
+	}
+
+	// Nodes are known by their position in the document, the first rule for a node is used
+	rulesByNode := map[int]*diffRule{}
+	for _, rule := range rules {
+		nodes, err := rule.xpath.ExecNode(expectedRoot)
+		if err != nil {
+			continue
+		}
+		for _, node := range nodes {
+			if _, ok := rulesByNode[node.Pos()]; !ok {
+				rulesByNode[node.Pos()] = rule
+			}
+		}
+	}
+
+	return this.xmlChildrenDiff("body", rulesByNode, expectedRoot, actualRoot), true
+}
+
+func parseXmlBody(response *models.ResponseDetails) (tree.Elem, error) {
+	body, err := decompress([]byte(response.Body), response.Headers["Content-Encoding"])
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := xmltree.ParseXML(bytes.NewReader(body), func(s *xmltree.ParseOptions) {
+		s.Strict = false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rootElem, ok := root.(tree.Elem)
+	if !ok || len(xmlChildElements(rootElem)) == 0 {
+		return nil, fmt.Errorf("Body is not XML")
+	}
+
+	return rootElem, nil
+}
+
+func (this *DiffMode) xmlNodeDiff(field string, rulesByNode map[int]*diffRule, expected, actual tree.Node) bool {
+	rule := rulesByNode[expected.Pos()]
+	if rule != nil && rule.Ignore {
+		return true
+	}
+
+	if rule != nil && rule.regex != nil {
+		return this.regexDiff(field, rule, strings.TrimSpace(actual.ResValue()))
+	}
+
+	if rule != nil && rule.Tolerance != 0 {
+		expectedNumber, expectedErr := strconv.ParseFloat(strings.TrimSpace(expected.ResValue()), 64)
+		actualNumber, actualErr := strconv.ParseFloat(strings.TrimSpace(actual.ResValue()), 64)
+		if expectedErr == nil && actualErr == nil {
+			return this.toleranceDiff(field, rule, expectedNumber, actualNumber)
+		}
+	}
+
+	expectedElem, isElem := expected.(tree.Elem)
+	actualElem, _ := actual.(tree.Elem)
+	if !isElem || actualElem == nil {
+		return this.xmlValueDiff(field, expected, actual)
+	}
+
+	same := true
+	for _, expectedAttr := range expectedElem.GetAttrs() {
+		name := expectedAttr.GetToken().(xml.Attr).Name
+		param := field + "/@" + name.Local
+
+		actualAttr := xmlAttr(actualElem, name)
+		if actualAttr == nil {
+			if attrRule := rulesByNode[expectedAttr.Pos()]; attrRule == nil || !attrRule.Ignore {
+				this.addEntry(param, expectedAttr.ResValue(), nil)
+				same = false
+			}
+		} else if !this.xmlNodeDiff(param, rulesByNode, expectedAttr, actualAttr) {
+			same = false
+		}
+	}
+
+	if len(xmlChildElements(expectedElem)) == 0 && len(xmlChildElements(actualElem)) == 0 {
+		return this.xmlValueDiff(field, expected, actual) && same
+	}
+
+	if rule != nil && rule.Unordered {
+		return this.xmlUnorderedChildrenDiff(field, rulesByNode, expectedElem, actualElem) && same
+	}
+
+	return this.xmlChildrenDiff(field, rulesByNode, expectedElem, actualElem) && same
+}
+
+// xmlChildrenDiff compares child elements in order, reporting each child which differs
+func (this *DiffMode) xmlChildrenDiff(field string, rulesByNode map[int]*diffRule, expected, actual tree.Elem) bool {
+	expectedChildren := xmlChildElements(expected)
+	actualChildren := xmlChildElements(actual)
+	names := xmlChildFieldNames(expectedChildren)
+
+	same := true
+	for i, expectedChild := range expectedChildren {
+		param := field + "/" + names[i]
+
+		if i >= len(actualChildren) {
+			if childRule := rulesByNode[expectedChild.Pos()]; childRule == nil || !childRule.Ignore {
+				this.addEntry(param, xmlString(expectedChild), nil)
+				same = false
+			}
+		} else if xmlName(expectedChild) != xmlName(actualChildren[i]) {
+			this.addEntry(param, xmlString(expectedChild), xmlString(actualChildren[i]))
+			same = false
+		} else if !this.xmlNodeDiff(param, rulesByNode, expectedChild, actualChildren[i]) {
+			same = false
+		}
+	}
+
+	if len(actualChildren) > len(expectedChildren) {
+		this.addEntry(field, xmlString(expected), xmlString(actual))
+		same = false
+	}
+
+	return same
+}
+
+// xmlUnorderedChildrenDiff reports the whole element when its children cannot each
+// be matched by a different child of the other element
+func (this *DiffMode) xmlUnorderedChildrenDiff(field string, rulesByNode map[int]*diffRule, expected, actual tree.Elem) bool {
+	expectedChildren := xmlChildElements(expected)
+	actualChildren := xmlChildElements(actual)
+
+	same := len(expectedChildren) == len(actualChildren)
+	matched := make([]bool, len(actualChildren))
+	for i := 0; same && i < len(expectedChildren); i++ {
+		found := false
+		for j, actualChild := range actualChildren {
+			if matched[j] || xmlName(expectedChildren[i]) != xmlName(actualChild) {
+				continue
+			}
+
+			comparison := &DiffMode{rules: this.rules}
+			if comparison.xmlNodeDiff(field, rulesByNode, expectedChildren[i], actualChild) {
+				matched[j] = true
+				found = true
+				break
+			}
+		}
+		same = found
+	}
+
+	if !same {
+		this.addEntry(field, xmlString(expected), xmlString(actual))
+	}
+
+	return same
+}
+
+func (this *DiffMode) xmlValueDiff(field string, expected, actual tree.Node) bool {
+	expectedValue := strings.TrimSpace(expected.ResValue())
+	actualValue := strings.TrimSpace(actual.ResValue())
+
+	if expectedValue != actualValue {
+		this.addEntry(field, expectedValue, actualValue)
+		return false
+	}
+
+	return true
+}
+
+func xmlChildElements(elem tree.Elem) []tree.Elem {
+	children := []tree.Elem{}
+	for _, child := range elem.GetChildren() {
+		if childElem, ok := child.(tree.Elem); ok && child.GetNodeType() == tree.NtElem {
+			children = append(children, childElem)
+		}
+	}
+
+	return children
+}
+
+// xmlChildFieldNames names each child by its element name, along with its position
+// when there is more than one child with that name
+func xmlChildFieldNames(children []tree.Elem) []string {
+	counts := map[string]int{}
+	for _, child := range children {
+		counts[xmlName(child)]++
+	}
+
+	positions := map[string]int{}
+	names := make([]string, len(children))
+	for i, child := range children {
+		name := xmlName(child)
+		positions[name]++
+		if counts[name] > 1 {
+			name = fmt.Sprintf("%s[%d]", name, positions[name])
+		}
+		names[i] = name
+	}
+
+	return names
+}
+
+func xmlName(elem tree.Elem) string {
+	if start, ok := elem.GetToken().(xml.StartElement); ok {
+		return start.Name.Local
+	}
+	return ""
+}
+
+func xmlAttr(elem tree.Elem, name xml.Name) tree.Node {
+	for _, attr := range elem.GetAttrs() {
+		if attr.GetToken().(xml.Attr).Name == name {
+			return attr
+		}
+	}
+	return nil
+}
+
+func xmlString(node tree.Node) string {
+	value, err := goxpath.MarshalStr(node)
+	if err != nil {
+		return strings.TrimSpace(node.ResValue())
+	}
+	return value
+}
@@ -3,10 +3,12 @@ package modes
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -44,6 +46,11 @@ type ModeArguments struct {
 	Headers          []string
 	MatchingStrategy *string
 	Stateful         bool
+	PathMatcher      string
+	BodyMatcher      string
+	IgnoreJsonPaths  []string
+	IgnoreQueryKeys  []string
+	DiffRules        []v2.DiffRuleView
 }
 
 // ReconstructRequest replaces original request with details provided in Constructor Payload.RequestMatcher
@@ -107,9 +114,48 @@ func ReconstructResponse(request *http.Request, pair models.RequestResponsePair)
 		response.Header.Set("Content-Length", fmt.Sprintf("%v", response.ContentLength))
 	}
 
+	if pair.Response.Fault != nil {
+		response.Body = &FaultyResponseBody{ReadCloser: response.Body, Fault: *pair.Response.Fault}
+	}
+
 	return response
 }
 
+// FaultyResponseBody marks the body of a response which should misbehave when it is sent to the client.
+// Reading it behaves as normal, as the fault is only applied by the proxy once the response is journaled.
+type FaultyResponseBody struct {
+	io.ReadCloser
+	Fault models.ResponseFault
+}
+
+// GetResponseFault returns the fault to apply when sending the response, or nil if it should be sent as normal
+func GetResponseFault(response *http.Response) *models.ResponseFault {
+	if response == nil {
+		return nil
+	}
+
+	if body, ok := response.Body.(*FaultyResponseBody); ok {
+		return &body.Fault
+	}
+
+	return nil
+}
+
+// reconstructMatchedResponse waits for any delay declared on the response which was matched in the
+// simulation, then reconstructs the response. Delays and faults are not passed to middleware, so
+// they are always taken from the matched response.
+func reconstructMatchedResponse(request *http.Request, pair models.RequestResponsePair, matched *models.ResponseDetails) *http.Response {
+	if delay := matched.GetDelay(); delay > 0 {
+		log.Info("Pausing before sending the response to simulate delays")
+		time.Sleep(time.Duration(delay) * time.Millisecond)
+		log.Info("Response delay completed")
+	}
+
+	pair.Response.Fault = matched.Fault
+
+	return ReconstructResponse(request, pair)
+}
+
 func GetRequestLogFields(request *models.RequestDetails) *logrus.Fields {
 	if request == nil {
 		return &log.Fields{
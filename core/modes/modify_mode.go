@@ -5,11 +5,12 @@ import (
 	"net/http"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/middleware"
 	"github.com/SpectoLabs/hoverfly/core/models"
 )
 
 type HoverflyModify interface {
-	ApplyMiddleware(models.RequestResponsePair) (models.RequestResponsePair, error)
+	ApplyMiddleware(models.RequestResponsePair, string) (models.RequestResponsePair, error)
 	DoRequest(*http.Request) (*http.Response, error)
 }
 
@@ -26,7 +27,7 @@ func (this *ModifyMode) View() v2.ModeView {
 func (this *ModifyMode) SetArguments(arguments ModeArguments) {}
 
 func (this ModifyMode) Process(request *http.Request, details models.RequestDetails) (*http.Response, error) {
-	pair, err := this.Hoverfly.ApplyMiddleware(models.RequestResponsePair{Request: details})
+	pair, err := this.Hoverfly.ApplyMiddleware(models.RequestResponsePair{Request: details}, middleware.PreRequest)
 	if err != nil {
 		return ReturnErrorAndLog(request, err, &pair, "There was an error when executing middleware", Modify)
 	}
@@ -52,7 +53,7 @@ func (this ModifyMode) Process(request *http.Request, details models.RequestDeta
 		Headers: resp.Header,
 	}
 
-	pair, err = this.Hoverfly.ApplyMiddleware(pair)
+	pair, err = this.Hoverfly.ApplyMiddleware(pair, middleware.PostResponse)
 	if err != nil {
 		return ReturnErrorAndLog(request, err, &pair, "There was an error when executing middleware", Modify)
 	}
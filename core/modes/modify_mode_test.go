@@ -28,7 +28,7 @@ func (this hoverflyModifyStub) DoRequest(request *http.Request) (*http.Response,
 	return response, nil
 }
 
-func (this hoverflyModifyStub) ApplyMiddleware(pair models.RequestResponsePair) (models.RequestResponsePair, error) {
+func (this hoverflyModifyStub) ApplyMiddleware(pair models.RequestResponsePair, phase string) (models.RequestResponsePair, error) {
 	if pair.Request.Path == "/middleware-error" {
 		return pair, errors.New("middleware-error")
 	}
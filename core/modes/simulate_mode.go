@@ -5,12 +5,13 @@ import (
 
 	"github.com/SpectoLabs/hoverfly/core/errors"
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/middleware"
 	"github.com/SpectoLabs/hoverfly/core/models"
 )
 
 type HoverflySimulate interface {
 	GetResponse(models.RequestDetails) (*models.ResponseDetails, *errors.HoverflyError)
-	ApplyMiddleware(models.RequestResponsePair) (models.RequestResponsePair, error)
+	ApplyMiddleware(models.RequestResponsePair, string) (models.RequestResponsePair, error)
 }
 
 type SimulateMode struct {
@@ -44,13 +45,23 @@ func (this SimulateMode) Process(request *http.Request, details models.RequestDe
 	response, matchingErr := this.Hoverfly.GetResponse(details)
 
 	if matchingErr != nil {
+		pair, err := this.Hoverfly.ApplyMiddleware(pair, middleware.OnMiss)
+		if err != nil {
+			return ReturnErrorAndLog(request, err, &pair, "There was an error when executing middleware", Simulate)
+		}
+
+		// Middleware run on a miss can respond in place of the simulation
+		if pair.Response.Status != 0 {
+			return ReconstructResponse(request, pair), nil
+		}
+
 		return ReturnErrorAndLog(request, matchingErr, &pair, "There was an error when matching", Simulate)
 	}
 
 	pair.Response = *response
 
-	if pair, err := this.Hoverfly.ApplyMiddleware(pair); err == nil {
-		return ReconstructResponse(request, pair), nil
+	if pair, err := this.Hoverfly.ApplyMiddleware(pair, middleware.PostResponse); err == nil {
+		return reconstructMatchedResponse(request, pair, response), nil
 	} else {
 		return ReturnErrorAndLog(request, err, &pair, "There was an error when executing middleware", Simulate)
 	}
@@ -5,8 +5,10 @@ import (
 	"io/ioutil"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/errors"
+	"github.com/SpectoLabs/hoverfly/core/middleware"
 	"github.com/SpectoLabs/hoverfly/core/models"
 	"github.com/SpectoLabs/hoverfly/core/modes"
 	. "github.com/onsi/gomega"
@@ -19,6 +21,15 @@ func (this hoverflySimulateStub) GetResponse(requestDetails models.RequestDetail
 		return &models.ResponseDetails{
 			Status: 200,
 		}, nil
+	} else if requestDetails.Destination == "faulty-match.com" {
+		return &models.ResponseDetails{
+			Status:     200,
+			Body:       "faulty",
+			FixedDelay: 50,
+			Fault: &models.ResponseFault{
+				Type: models.FaultEmptyResponse,
+			},
+		}, nil
 	} else {
 		return nil, &errors.HoverflyError{
 			Message: "matching-error",
@@ -26,10 +37,13 @@ func (this hoverflySimulateStub) GetResponse(requestDetails models.RequestDetail
 	}
 }
 
-func (this hoverflySimulateStub) ApplyMiddleware(pair models.RequestResponsePair) (models.RequestResponsePair, error) {
+func (this hoverflySimulateStub) ApplyMiddleware(pair models.RequestResponsePair, phase string) (models.RequestResponsePair, error) {
 	if pair.Request.Path == "middleware-error" {
 		return pair, fmt.Errorf("middleware-error")
 	}
+	if pair.Request.Path == "on-miss" && phase == middleware.OnMiss {
+		pair.Response = models.ResponseDetails{Status: 200, Body: "on-miss"}
+	}
 	return pair, nil
 }
 
@@ -96,3 +110,91 @@ func Test_SimulateMode_WhenGivenAMatchingRequesAndMiddlewareFaislItReturnsAnErro
 	Expect(string(responseBody)).To(ContainSubstring("There was an error when executing middleware"))
 	Expect(string(responseBody)).To(ContainSubstring("middleware-error"))
 }
+
+func Test_SimulateMode_WhenGivenAMatchingRequestItAppliesTheDelayAndKeepsTheFault(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &modes.SimulateMode{
+		Hoverfly: hoverflySimulateStub{},
+	}
+
+	request := models.RequestDetails{
+		Destination: "faulty-match.com",
+	}
+
+	start := time.Now()
+	response, err := unit.Process(nil, request)
+	Expect(err).To(BeNil())
+	Expect(time.Since(start)).To(BeNumerically(">=", 50*time.Millisecond))
+
+	Expect(modes.GetResponseFault(response)).To(Equal(&models.ResponseFault{
+		Type: models.FaultEmptyResponse,
+	}))
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(BeNil())
+	Expect(string(responseBody)).To(Equal("faulty"))
+}
+
+func Test_SimulateMode_WhenGivenAMatchingRequestWithoutAFaultItReturnsNoFault(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &modes.SimulateMode{
+		Hoverfly: hoverflySimulateStub{},
+	}
+
+	request := models.RequestDetails{
+		Destination: "positive-match.com",
+	}
+
+	response, err := unit.Process(nil, request)
+	Expect(err).To(BeNil())
+
+	Expect(modes.GetResponseFault(response)).To(BeNil())
+}
+
+func Test_SimulateMode_WhenGivenANonMatchingRequestItReturnsTheResponseOfOnMissMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &modes.SimulateMode{
+		Hoverfly: hoverflySimulateStub{},
+	}
+
+	request := models.RequestDetails{
+		Destination: "negative-match.com",
+		Path:        "on-miss",
+	}
+
+	response, err := unit.Process(&http.Request{}, request)
+	Expect(err).To(BeNil())
+
+	Expect(response.StatusCode).To(Equal(200))
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(string(responseBody)).To(Equal("on-miss"))
+}
+
+func Test_SimulateMode_WhenGivenANonMatchingRequestAndOnMissMiddlewareFailsItReturnsAnError(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &modes.SimulateMode{
+		Hoverfly: hoverflySimulateStub{},
+	}
+
+	request := models.RequestDetails{
+		Destination: "negative-match.com",
+		Path:        "middleware-error",
+	}
+
+	response, err := unit.Process(&http.Request{}, request)
+	Expect(err).ToNot(BeNil())
+
+	Expect(response.StatusCode).To(Equal(http.StatusBadGateway))
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(string(responseBody)).To(ContainSubstring("There was an error when executing middleware"))
+}
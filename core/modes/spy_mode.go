@@ -8,12 +8,13 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/middleware"
 	"github.com/SpectoLabs/hoverfly/core/models"
 )
 
 type HoverflySpy interface {
 	GetResponse(models.RequestDetails) (*models.ResponseDetails, *errors.HoverflyError)
-	ApplyMiddleware(models.RequestResponsePair) (models.RequestResponsePair, error)
+	ApplyMiddleware(models.RequestResponsePair, string) (models.RequestResponsePair, error)
 	DoRequest(*http.Request) (*http.Response, error)
 }
 
@@ -48,6 +49,16 @@ func (this SpyMode) Process(request *http.Request, details models.RequestDetails
 	response, matchingErr := this.Hoverfly.GetResponse(details)
 
 	if matchingErr != nil {
+		pair, err := this.Hoverfly.ApplyMiddleware(pair, middleware.OnMiss)
+		if err != nil {
+			return ReturnErrorAndLog(request, err, &pair, "There was an error when executing middleware", Spy)
+		}
+
+		// Middleware run on a miss can respond in place of the real server
+		if pair.Response.Status != 0 {
+			return ReconstructResponse(request, pair), nil
+		}
+
 		log.Info("Going to call real server")
 		modifiedRequest, err := ReconstructRequest(pair)
 		if err != nil {
@@ -64,8 +75,8 @@ func (this SpyMode) Process(request *http.Request, details models.RequestDetails
 
 	pair.Response = *response
 
-	if pair, err := this.Hoverfly.ApplyMiddleware(pair); err == nil {
-		return ReconstructResponse(request, pair), nil
+	if pair, err := this.Hoverfly.ApplyMiddleware(pair, middleware.PostResponse); err == nil {
+		return reconstructMatchedResponse(request, pair, response), nil
 	} else {
 		return ReturnErrorAndLog(request, err, &pair, "There was an error when executing middleware", Spy)
 	}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/SpectoLabs/hoverfly/core/errors"
+	"github.com/SpectoLabs/hoverfly/core/middleware"
 	"github.com/SpectoLabs/hoverfly/core/models"
 	"github.com/SpectoLabs/hoverfly/core/modes"
 	. "github.com/onsi/gomega"
@@ -40,10 +41,13 @@ func (this hoverflySpyStub) GetResponse(requestDetails models.RequestDetails) (*
 	}
 }
 
-func (this hoverflySpyStub) ApplyMiddleware(pair models.RequestResponsePair) (models.RequestResponsePair, error) {
+func (this hoverflySpyStub) ApplyMiddleware(pair models.RequestResponsePair, phase string) (models.RequestResponsePair, error) {
 	if pair.Request.Path == "middleware-error" {
 		return pair, fmt.Errorf("middleware-error")
 	}
+	if pair.Request.Path == "on-miss" && phase == middleware.OnMiss {
+		pair.Response = models.ResponseDetails{Status: 200, Body: "on-miss"}
+	}
 	return pair, nil
 }
 
@@ -141,3 +145,49 @@ func Test_SpyMode_ShouldReturnErrorOnRemoteServiceCall(t *testing.T) {
 	Expect(string(responseBody)).To(ContainSubstring("Could not reach error.com"))
 
 }
+
+func Test_SpyMode_WhenGivenANonMatchingRequestItReturnsTheResponseOfOnMissMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &modes.SpyMode{
+		Hoverfly: hoverflySpyStub{},
+	}
+
+	request := models.RequestDetails{
+		Destination: "negative-match.com",
+		Path:        "on-miss",
+	}
+
+	response, err := unit.Process(&http.Request{}, request)
+	Expect(err).To(BeNil())
+
+	Expect(response.StatusCode).To(Equal(200))
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(string(responseBody)).To(Equal("on-miss"))
+}
+
+func Test_SpyMode_WhenGivenANonMatchingRequestAndOnMissMiddlewareFailsItReturnsAnError(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &modes.SpyMode{
+		Hoverfly: hoverflySpyStub{},
+	}
+
+	request := models.RequestDetails{
+		Destination: "negative-match.com",
+		Path:        "middleware-error",
+	}
+
+	response, err := unit.Process(&http.Request{}, request)
+	Expect(err).ToNot(BeNil())
+
+	Expect(response.StatusCode).To(Equal(http.StatusBadGateway))
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(string(responseBody)).To(ContainSubstring("There was an error when executing middleware"))
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/SpectoLabs/hoverfly/core/middleware"
 	"github.com/SpectoLabs/hoverfly/core/models"
 
 	log "github.com/sirupsen/logrus"
@@ -11,7 +12,7 @@ import (
 )
 
 type HoverflySynthesize interface {
-	ApplyMiddleware(models.RequestResponsePair) (models.RequestResponsePair, error)
+	ApplyMiddleware(models.RequestResponsePair, string) (models.RequestResponsePair, error)
 	IsMiddlewareSet() bool
 }
 
@@ -41,7 +42,7 @@ func (this SynthesizeMode) Process(request *http.Request, details models.Request
 		return ReturnErrorAndLog(request, err, &pair, "There was an error when creating a synthetic response", Synthesize)
 	}
 
-	pair, err := this.Hoverfly.ApplyMiddleware(pair)
+	pair, err := this.Hoverfly.ApplyMiddleware(pair, middleware.PostResponse)
 	if err != nil {
 		return ReturnErrorAndLog(request, err, &pair, "There was an error when executing middleware", Synthesize)
 	}
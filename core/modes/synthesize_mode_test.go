@@ -15,7 +15,7 @@ type hoverflySynthesizeStub struct {
 	MiddlewareSet bool
 }
 
-func (this hoverflySynthesizeStub) ApplyMiddleware(pair models.RequestResponsePair) (models.RequestResponsePair, error) {
+func (this hoverflySynthesizeStub) ApplyMiddleware(pair models.RequestResponsePair, phase string) (models.RequestResponsePair, error) {
 	if pair.Request.Destination == "error.com" {
 		return pair, errors.New("Middleware failed")
 	}
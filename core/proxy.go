@@ -3,6 +3,7 @@ package hoverfly
 import (
 	"bytes"
 	"encoding/base64"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -17,6 +18,7 @@ import (
 	"github.com/SpectoLabs/goproxy/ext/auth"
 	"github.com/SpectoLabs/hoverfly/core/authentication"
 	"github.com/SpectoLabs/hoverfly/core/authentication/backends"
+	"github.com/SpectoLabs/hoverfly/core/modes"
 	"github.com/SpectoLabs/hoverfly/core/util"
 )
 
@@ -64,9 +66,12 @@ func NewProxy(hoverfly *Hoverfly) *goproxy.ProxyHttpServer {
 	proxy.OnRequest(matchesFilter(hoverfly.Cfg.Destination)).DoFunc(
 		func(r *http.Request, ctx *goproxy.ProxyCtx) (*http.Request, *http.Response) {
 			startTime := time.Now()
-			resp := hoverfly.processRequest(r)
-			hoverfly.Journal.NewEntry(r, resp, hoverfly.Cfg.Mode, startTime)
-			return r, resp
+			proxyUser, _ := ctx.UserData.(string)
+			resp, session := hoverfly.processRequestInSession(r, proxyUser)
+			fault := modes.GetResponseFault(resp)
+			session.Journal.NewEntry(r, resp, hoverfly.Cfg.Mode, startTime)
+			hoverfly.Metrics.CountRequest(hoverfly.Cfg.Mode, r.Host, resp.StatusCode, time.Since(startTime))
+			return r, applyResponseFault(r, resp, fault)
 		})
 
 	if hoverfly.Cfg.Verbose {
@@ -109,9 +114,11 @@ func NewWebserverProxy(hoverfly *Hoverfly) *goproxy.ProxyHttpServer {
 	proxy.NonproxyHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		startTime := time.Now()
 		r.URL.Scheme = "http"
-		resp := hoverfly.processRequest(r)
-		hoverfly.Journal.NewEntry(r, resp, hoverfly.Cfg.Mode, startTime)
-		body, err := util.GetResponseBody(resp)
+		resp, session := hoverfly.processRequestInSession(r, "")
+		fault := modes.GetResponseFault(resp)
+		session.Journal.NewEntry(r, resp, hoverfly.Cfg.Mode, startTime)
+		hoverfly.Metrics.CountRequest(hoverfly.Cfg.Mode, r.Host, resp.StatusCode, time.Since(startTime))
+		_, err := util.GetResponseBody(resp)
 
 		if err != nil {
 			log.Error("Error reading response body")
@@ -119,6 +126,8 @@ func NewWebserverProxy(hoverfly *Hoverfly) *goproxy.ProxyHttpServer {
 			return
 		}
 
+		resp = applyResponseFault(r, resp, fault)
+
 		for name, values := range resp.Header {
 			name = strings.ToLower(name)
 
@@ -130,7 +139,7 @@ func NewWebserverProxy(hoverfly *Hoverfly) *goproxy.ProxyHttpServer {
 		w.Header().Set("Req", r.RequestURI)
 		w.Header().Set("Resp", resp.Header.Get("Content-Length"))
 		w.WriteHeader(resp.StatusCode)
-		w.Write([]byte(body))
+		io.Copy(w, resp.Body)
 
 		hoverfly.Counter.Count(hoverfly.Cfg.GetMode())
 	})
@@ -169,15 +178,17 @@ func unauthorizedError(request *http.Request, realm, message string) *http.Respo
 func proxyBasicAndBearer(proxy *goproxy.ProxyHttpServer, realm string, basicFunc func(user, passwd string) bool, bearerFunc func(token string) bool) {
 
 	proxy.OnRequest().Do(goproxy.FuncReqHandler(func(req *http.Request, ctx *goproxy.ProxyCtx) (*http.Request, *http.Response) {
-		err := authFromHeader(req, basicFunc, bearerFunc)
+		user, err := authFromHeader(req, basicFunc, bearerFunc)
 		if err != nil {
 			return nil, unauthorizedError(req, realm, err.Error())
 		}
+		// Kept so that the request can be given the session of the proxy user
+		ctx.UserData = user
 		return req, nil
 	}))
 
 	proxy.OnRequest().HandleConnect(goproxy.FuncHttpsHandler(func(host string, ctx *goproxy.ProxyCtx) (*goproxy.ConnectAction, string) {
-		err := authFromHeader(ctx.Req, basicFunc, bearerFunc)
+		_, err := authFromHeader(ctx.Req, basicFunc, bearerFunc)
 		if err != nil {
 			ctx.Resp = unauthorizedError(ctx.Req, realm, err.Error())
 			return goproxy.RejectConnect, host
@@ -186,41 +197,43 @@ func proxyBasicAndBearer(proxy *goproxy.ProxyHttpServer, realm string, basicFunc
 	}))
 }
 
-func authFromHeader(req *http.Request, basicFunc func(user, passwd string) bool, bearerFunc func(token string) bool) error {
+// authFromHeader checks the proxy credentials of a request, returning the user for basic authentication
+func authFromHeader(req *http.Request, basicFunc func(user, passwd string) bool, bearerFunc func(token string) bool) (string, error) {
 	headerValue := req.Header.Get(ProxyAuthorizationHeader)
 
 	if ProxyAuthorizationHeader != "Proxy-Authorization" && req.Header.Get("Proxy-Authorization") != "" {
-		return fmt.Errorf("407 `Proxy-Authorization` header is disabled, use `X-HOVERFLY-AUTHORIZATION` instead")
+		return "", fmt.Errorf("407 `Proxy-Authorization` header is disabled, use `X-HOVERFLY-AUTHORIZATION` instead")
 	}
 
 	authheader := strings.SplitN(headerValue, " ", 2)
 	req.Header.Del(ProxyAuthorizationHeader)
 	if len(authheader) != 2 {
-		return fmt.Errorf("407 Proxy authentication required")
+		return "", fmt.Errorf("407 Proxy authentication required")
 	}
 	if authheader[0] == "Basic" {
 		userpassraw, err := base64.StdEncoding.DecodeString(authheader[1])
 		if err != nil {
-			return fmt.Errorf("407 Proxy authentication required")
+			return "", fmt.Errorf("407 Proxy authentication required")
 		}
 		userpass := strings.SplitN(string(userpassraw), ":", 2)
 		if len(userpass) != 2 {
-			return fmt.Errorf("407 Proxy authentication required")
+			return "", fmt.Errorf("407 Proxy authentication required")
 		}
 		result := basicFunc(userpass[0], userpass[1])
 		if result == false {
-			return fmt.Errorf("407 Proxy authentication required")
+			return "", fmt.Errorf("407 Proxy authentication required")
 		}
+		return userpass[0], nil
 	} else if authheader[0] == "Bearer" {
 		result := bearerFunc(authheader[1])
 		if result == false {
-			return fmt.Errorf("407 Proxy authentication required")
+			return "", fmt.Errorf("407 Proxy authentication required")
 		}
 	} else {
-		return fmt.Errorf("407 Unknown authentication type `%v`, only `Basic` or `Bearer` are supported", authheader[0])
+		return "", fmt.Errorf("407 Unknown authentication type `%v`, only `Basic` or `Bearer` are supported", authheader[0])
 	}
 
-	return nil
+	return "", nil
 }
 
 func matchesFilter(filter string) goproxy.ReqConditionFunc {
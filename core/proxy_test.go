@@ -36,7 +36,7 @@ func Test_authFromHeader_ShouldReturnErrorIfNotBasicOrBearer(t *testing.T) {
 	req, _ := http.NewRequest(http.MethodGet, "localhost:8888", nil)
 	req.Header.Add("Proxy-Authorization", "Something YmVuamloOlBhc3N3b3JkMTIz")
 
-	err := authFromHeader(req, nil, nil)
+	_, err := authFromHeader(req, nil, nil)
 
 	Expect(err).ToNot(BeNil())
 	Expect(err.Error()).To(Equal("407 Unknown authentication type `Something`, only `Basic` or `Bearer` are supported"))
@@ -49,11 +49,13 @@ func Test_authFromHeader_Basic_ShouldBase64DecodeUsernameAndPassword(t *testing.
 
 	var basicUsername, basicPassword string
 
-	Expect(authFromHeader(req, func(username, password string) bool {
+	user, err := authFromHeader(req, func(username, password string) bool {
 		basicUsername = username
 		basicPassword = password
 		return true
-	}, nil)).To(BeNil())
+	}, nil)
+	Expect(err).To(BeNil())
+	Expect(user).To(Equal("benjih"))
 
 	Expect(basicUsername).To(Equal("benjih"))
 	Expect(basicPassword).To(Equal("Password123"))
@@ -64,7 +66,8 @@ func Test_authFromHeader_Basic_ShouldReturnFalseIfNotBase64Encoded(t *testing.T)
 	req, _ := http.NewRequest(http.MethodGet, "localhost:8888", nil)
 	req.Header.Add("Proxy-Authorization", "Basic benjih:Password123")
 
-	Expect(authFromHeader(req, nil, nil)).ToNot(BeNil())
+	_, err := authFromHeader(req, nil, nil)
+	Expect(err).ToNot(BeNil())
 }
 
 func Test_authFromHeader_Basic_ShouldReturnFalseIfDecodedBasicCredentialsArentFormattedCorrectly(t *testing.T) {
@@ -72,7 +75,8 @@ func Test_authFromHeader_Basic_ShouldReturnFalseIfDecodedBasicCredentialsArentFo
 	req, _ := http.NewRequest(http.MethodGet, "localhost:8888", nil)
 	req.Header.Add("Proxy-Authorization", "Basic YmVuamlo")
 
-	Expect(authFromHeader(req, nil, nil)).ToNot(BeNil())
+	_, err := authFromHeader(req, nil, nil)
+	Expect(err).ToNot(BeNil())
 }
 
 func Test_authFromHeader_Bearer_ShouldPassJwtTokenOntoFunction(t *testing.T) {
@@ -82,10 +86,11 @@ func Test_authFromHeader_Bearer_ShouldPassJwtTokenOntoFunction(t *testing.T) {
 
 	var bearerToken string
 
-	Expect(authFromHeader(req, nil, func(token string) bool {
+	_, err := authFromHeader(req, nil, func(token string) bool {
 		bearerToken = token
 		return true
-	})).To(BeNil())
+	})
+	Expect(err).To(BeNil())
 
 	Expect(bearerToken).To(Equal("gregg.EEewGREQ.GDSG"))
 }
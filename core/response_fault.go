@@ -0,0 +1,120 @@
+package hoverfly
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/util"
+	log "github.com/sirupsen/logrus"
+)
+
+type contextKey string
+
+// The response writer of a request is kept in its context, so that faults can be applied
+// to it when the response is sent. Connection faults hijack the client connection from it.
+const responseWriterContextKey = contextKey("responseWriter")
+
+func withResponseWriter(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), responseWriterContextKey, w)))
+	})
+}
+
+// applyResponseFault changes the response so that it misbehaves in the way described by the fault
+// when it is sent to the client. HTTPS requests through the proxy do not expose the client connection,
+// so connection faults can only be applied to them once the response headers have been sent.
+func applyResponseFault(request *http.Request, response *http.Response, fault *models.ResponseFault) *http.Response {
+	if fault == nil {
+		return response
+	}
+
+	log.WithFields(log.Fields{
+		"fault":       fault.Type,
+		"destination": request.Host,
+		"path":        request.URL.Path,
+	}).Info("Applying fault to the response")
+
+	body, _ := util.GetResponseBody(response)
+
+	switch fault.Type {
+	case models.FaultConnectionReset, models.FaultEmptyResponse:
+		if hijacker, ok := request.Context().Value(responseWriterContextKey).(http.Hijacker); ok {
+			if conn, _, err := hijacker.Hijack(); err == nil {
+				if tcpConn, ok := conn.(*net.TCPConn); ok && fault.Type == models.FaultConnectionReset {
+					// Discarding unsent data makes closing the connection send a RST rather than a FIN
+					tcpConn.SetLinger(0)
+				}
+				conn.Close()
+			}
+		}
+		response.Body = &faultyReader{reader: strings.NewReader("")}
+	case models.FaultTruncatedBody:
+		if response.Header.Get("Transfer-Encoding") == "" {
+			response.Header.Set("Content-Length", fmt.Sprintf("%v", len(body)))
+		}
+		response.Body = &faultyReader{reader: strings.NewReader(body[:fault.GetTruncateAt(len(body))])}
+	case models.FaultSlowBody:
+		flusher, _ := request.Context().Value(responseWriterContextKey).(http.Flusher)
+		response.Body = &slowReader{
+			reader:    strings.NewReader(body),
+			byteDelay: time.Duration(fault.GetByteDelay()) * time.Millisecond,
+			flusher:   flusher,
+		}
+	}
+
+	return response
+}
+
+// faultyReader fails with an unexpected EOF once the underlying reader is exhausted
+type faultyReader struct {
+	reader io.Reader
+}
+
+func (this *faultyReader) Read(p []byte) (int, error) {
+	n, err := this.reader.Read(p)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return n, err
+}
+
+func (this *faultyReader) Close() error {
+	return nil
+}
+
+// slowReader returns a single byte for each read, waiting before each one. Anything which was written
+// to the client is flushed first, so that the bytes are not buffered up until the body is complete.
+type slowReader struct {
+	reader    *strings.Reader
+	byteDelay time.Duration
+	flusher   http.Flusher
+}
+
+func (this *slowReader) Read(p []byte) (int, error) {
+	if this.reader.Len() == 0 {
+		return 0, io.EOF
+	}
+
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	if this.flusher != nil {
+		this.flusher.Flush()
+	}
+
+	time.Sleep(this.byteDelay)
+
+	return this.reader.Read(p[:1])
+}
+
+func (this *slowReader) Close() error {
+	return nil
+}
@@ -0,0 +1,155 @@
+package hoverfly
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func startFaultyHoverfly(proxyPort string, webserver bool) (*Hoverfly, *http.Client) {
+	unit := NewHoverflyWithConfiguration(&Configuration{
+		ProxyPort: proxyPort,
+		Webserver: webserver,
+		Mode:      "simulate",
+	})
+
+	faults := []v2.ResponseFaultView{
+		{Type: "connectionReset"},
+		{Type: "emptyResponse"},
+		{Type: "truncatedBody", TruncateAt: 5},
+		{Type: "slowBody", ByteDelay: 20},
+	}
+
+	pairs := []v2.RequestMatcherResponsePairViewV5{}
+	for i := range faults {
+		pairs = append(pairs, v2.RequestMatcherResponsePairViewV5{
+			RequestMatcher: v2.RequestMatcherViewV5{
+				Path: []v2.MatcherViewV5{
+					v2.NewMatcherView(matchers.Exact, "/"+faults[i].Type),
+				},
+			},
+			Response: v2.ResponseDetailsViewV5{
+				Status: 200,
+				Body:   "hello world",
+				Fault:  &faults[i],
+			},
+		})
+	}
+
+	unit.PutSimulation(v2.SimulationViewV5{
+		v2.DataViewV5{
+			RequestResponsePairs: pairs,
+		},
+		v2.MetaView{},
+	})
+
+	unit.StartProxy()
+
+	client := &http.Client{}
+	if !webserver {
+		client.Transport = &http.Transport{
+			Proxy: func(req *http.Request) (*url.URL, error) {
+				return url.Parse("http://localhost:" + proxyPort)
+			},
+		}
+	}
+
+	return unit, client
+}
+
+func Test_Hoverfly_ResponseFault_ConnectionResetResetsTheConnection(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, client := startFaultyHoverfly("9781", false)
+	defer unit.StopProxy()
+
+	_, err := client.Get("http://test-server.com/connectionReset")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("connection reset by peer"))
+}
+
+func Test_Hoverfly_ResponseFault_EmptyResponseClosesTheConnectionWithoutResponding(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, client := startFaultyHoverfly("9782", false)
+	defer unit.StopProxy()
+
+	_, err := client.Get("http://test-server.com/emptyResponse")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("EOF"))
+}
+
+func Test_Hoverfly_ResponseFault_TruncatedBodyClosesTheConnectionPartWayThroughTheBody(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, client := startFaultyHoverfly("9783", false)
+	defer unit.StopProxy()
+
+	response, err := client.Get("http://test-server.com/truncatedBody")
+	Expect(err).To(BeNil())
+	Expect(response.StatusCode).To(Equal(http.StatusOK))
+	Expect(response.ContentLength).To(Equal(int64(11)))
+
+	body, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(Equal(io.ErrUnexpectedEOF))
+	Expect(string(body)).To(Equal("hello"))
+}
+
+func Test_Hoverfly_ResponseFault_SlowBodySendsTheBodyByteByByte(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, client := startFaultyHoverfly("9784", false)
+	defer unit.StopProxy()
+
+	start := time.Now()
+	response, err := client.Get("http://test-server.com/slowBody")
+	Expect(err).To(BeNil())
+	Expect(time.Since(start)).To(BeNumerically("<", 200*time.Millisecond))
+
+	body, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(BeNil())
+	Expect(string(body)).To(Equal("hello world"))
+	Expect(time.Since(start)).To(BeNumerically(">=", 220*time.Millisecond))
+}
+
+func Test_Hoverfly_ResponseFault_CanBeAppliedInWebserverMode(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, client := startFaultyHoverfly("9785", true)
+	defer unit.StopProxy()
+
+	_, err := client.Get("http://localhost:9785/connectionReset")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("connection reset by peer"))
+
+	response, err := client.Get("http://localhost:9785/truncatedBody")
+	Expect(err).To(BeNil())
+
+	body, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(Equal(io.ErrUnexpectedEOF))
+	Expect(string(body)).To(Equal("hello"))
+}
+
+func Test_Hoverfly_ResponseFault_IsNotAppliedToTheJournal(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, client := startFaultyHoverfly("9786", false)
+	defer unit.StopProxy()
+
+	response, err := client.Get("http://test-server.com/truncatedBody")
+	Expect(err).To(BeNil())
+	ioutil.ReadAll(response.Body)
+
+	journal, err := unit.Journal.GetEntries(0, 25, nil, nil, "")
+	Expect(err).To(BeNil())
+	Expect(journal.Journal).To(HaveLen(1))
+	Expect(journal.Journal[0].Response.Body).To(Equal("hello world"))
+	Expect(journal.Journal[0].Response.Status).To(Equal(200))
+}
@@ -0,0 +1,172 @@
+package hoverfly
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+
+	"github.com/SpectoLabs/hoverfly/core/cache"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/journal"
+	"github.com/SpectoLabs/hoverfly/core/matching"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/modes"
+	"github.com/SpectoLabs/hoverfly/core/state"
+)
+
+var sessionNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// newSession creates a Hoverfly which shares the configuration of this one, but keeps
+// its own simulation, state, journal and cache
+func (hf *Hoverfly) newSession() *Hoverfly {
+	session := &Hoverfly{
+		Authentication:  hf.Authentication,
+		HTTP:            hf.HTTP,
+		Cfg:             hf.Cfg,
+		Counter:         hf.Counter,
+		Metrics:         hf.Metrics,
+		Simulation:      models.NewSimulation(),
+		StoreLogsHook:   hf.StoreLogsHook,
+		Journal:         journal.NewJournal(),
+		state:           state.NewState(),
+		templator:       hf.templator,
+		responsesDiff:   make(map[v2.SimpleRequestDefinitionView][]v2.DiffReport),
+		diffComparisons: make(map[v2.SimpleRequestDefinitionView]int),
+		version:         hf.version,
+	}
+
+	session.Journal.EntryLimit = hf.Journal.EntryLimit
+
+	if hf.CacheMatcher.RequestCache != nil {
+		requestCache, err := cache.NewLRUCache(hf.Cfg.CacheSize)
+		if err != nil {
+			requestCache = cache.NewDefaultLRUCache()
+		}
+
+		session.CacheMatcher = matching.CacheMatcher{
+			RequestCache: requestCache,
+			Webserver:    hf.CacheMatcher.Webserver,
+		}
+	}
+
+	session.modeMap = newModeMap(session)
+	session.applyModeArguments(hf.modeArguments)
+
+	return session
+}
+
+func (hf *Hoverfly) GetSessions() []string {
+	hf.sessionsMutex.RLock()
+	defer hf.sessionsMutex.RUnlock()
+
+	names := []string{}
+	for name := range hf.sessions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (hf *Hoverfly) CreateSession(name string) error {
+	if !sessionNameRegexp.MatchString(name) {
+		return fmt.Errorf("The name of a session can only contain letters, numbers, '.', '-' and '_'")
+	}
+
+	hf.sessionsMutex.Lock()
+	defer hf.sessionsMutex.Unlock()
+
+	if _, ok := hf.sessions[name]; ok {
+		return fmt.Errorf("Session %s already exists", name)
+	}
+
+	if hf.sessions == nil {
+		hf.sessions = map[string]*Hoverfly{}
+	}
+	session := hf.newSession()
+	session.sessionMux = newSessionMux(session)
+	hf.sessions[name] = session
+
+	return nil
+}
+
+func (hf *Hoverfly) DeleteSession(name string) error {
+	hf.sessionsMutex.Lock()
+	defer hf.sessionsMutex.Unlock()
+
+	if _, ok := hf.sessions[name]; !ok {
+		return fmt.Errorf("Session %s does not exist", name)
+	}
+
+	delete(hf.sessions, name)
+
+	return nil
+}
+
+// GetSessionMux returns the router of the admin handlers which work with the simulation, state and journal of a session
+func (hf *Hoverfly) GetSessionMux(name string) (http.Handler, bool) {
+	session, ok := hf.getSession(name)
+	if !ok {
+		return nil, false
+	}
+
+	return session.sessionMux, true
+}
+
+func (hf *Hoverfly) getSession(name string) (*Hoverfly, bool) {
+	hf.sessionsMutex.RLock()
+	session, ok := hf.sessions[name]
+	hf.sessionsMutex.RUnlock()
+
+	return session, ok
+}
+
+func (hf *Hoverfly) getSessions() []*Hoverfly {
+	hf.sessionsMutex.RLock()
+	defer hf.sessionsMutex.RUnlock()
+
+	sessions := []*Hoverfly{}
+	for _, session := range hf.sessions {
+		sessions = append(sessions, session)
+	}
+
+	return sessions
+}
+
+// processRequestInSession processes a request in the session it chooses. The session is returned,
+// so that the request can be added to its journal.
+func (hf *Hoverfly) processRequestInSession(req *http.Request, proxyUser string) (*http.Response, *Hoverfly) {
+	session, err := hf.getSessionForRequest(req, proxyUser)
+	if err != nil {
+		return modes.ErrorResponse(req, err, "Could not choose the session for the request"), hf
+	}
+
+	return session.processRequest(req), session
+}
+
+// getSessionForRequest returns the session chosen by a request. The session header is removed,
+// so it is neither captured nor sent on. Without a session header, a session with the name of
+// the proxy user is chosen when there is one, otherwise the request uses this Hoverfly.
+func (hf *Hoverfly) getSessionForRequest(req *http.Request, proxyUser string) (*Hoverfly, error) {
+	if hf.Cfg.SessionHeader != "" {
+		name := req.Header.Get(hf.Cfg.SessionHeader)
+		req.Header.Del(hf.Cfg.SessionHeader)
+
+		if name != "" {
+			session, ok := hf.getSession(name)
+			if !ok {
+				return nil, fmt.Errorf("Session %s does not exist", name)
+			}
+			return session, nil
+		}
+	}
+
+	if hf.Cfg.ProxyUserSessions && proxyUser != "" {
+		if session, ok := hf.getSession(proxyUser); ok {
+			return session, nil
+		}
+	}
+
+	return hf, nil
+}
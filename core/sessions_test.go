@@ -0,0 +1,162 @@
+package hoverfly
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/core/modes"
+	. "github.com/onsi/gomega"
+)
+
+func sessionSimulation(body string) v2.SimulationViewV5 {
+	return v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Path: []v2.MatcherViewV5{
+							v2.NewMatcherView(matchers.Exact, "/session"),
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 200,
+						Body:   body,
+					},
+				},
+			},
+		},
+	}
+}
+
+func Test_Hoverfly_CreateSession_AddsASession(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.CreateSession("team-b")).To(BeNil())
+	Expect(unit.CreateSession("team-a")).To(BeNil())
+
+	Expect(unit.GetSessions()).To(Equal([]string{"team-a", "team-b"}))
+}
+
+func Test_Hoverfly_CreateSession_ErrorsWhenTheSessionAlreadyExists(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.CreateSession("team-a")).To(BeNil())
+
+	err := unit.CreateSession("team-a")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Session team-a already exists"))
+}
+
+func Test_Hoverfly_CreateSession_ErrorsWhenTheNameIsInvalid(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err := unit.CreateSession("team/a")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("The name of a session can only contain letters, numbers, '.', '-' and '_'"))
+
+	Expect(unit.CreateSession("")).ToNot(BeNil())
+	Expect(unit.GetSessions()).To(BeEmpty())
+}
+
+func Test_Hoverfly_DeleteSession_RemovesTheSession(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.CreateSession("team-a")
+
+	Expect(unit.DeleteSession("team-a")).To(BeNil())
+	Expect(unit.GetSessions()).To(BeEmpty())
+
+	err := unit.DeleteSession("team-a")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Session team-a does not exist"))
+}
+
+func Test_Hoverfly_processRequestInSession_UsesTheSessionChosenByTheHeader(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{SessionHeader: DefaultSessionHeader})
+	unit.SetModeWithArguments(v2.ModeView{Mode: modes.Simulate})
+	unit.PutSimulation(sessionSimulation("default"))
+
+	Expect(unit.CreateSession("team-a")).To(BeNil())
+	session, _ := unit.getSession("team-a")
+	session.PutSimulation(sessionSimulation("team-a"))
+
+	request, _ := http.NewRequest("GET", "http://test.com/session", nil)
+	request.Header.Set(DefaultSessionHeader, "team-a")
+
+	response, chosen := unit.processRequestInSession(request, "")
+	Expect(chosen).To(Equal(session))
+	Expect(request.Header).ToNot(HaveKey(DefaultSessionHeader))
+
+	body, _ := ioutil.ReadAll(response.Body)
+	Expect(string(body)).To(Equal("team-a"))
+
+	request, _ = http.NewRequest("GET", "http://test.com/session", nil)
+
+	response, chosen = unit.processRequestInSession(request, "")
+	Expect(chosen).To(Equal(unit))
+
+	body, _ = ioutil.ReadAll(response.Body)
+	Expect(string(body)).To(Equal("default"))
+}
+
+func Test_Hoverfly_processRequestInSession_ErrorsWhenTheSessionDoesNotExist(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{SessionHeader: DefaultSessionHeader})
+	unit.SetModeWithArguments(v2.ModeView{Mode: modes.Simulate})
+
+	request, _ := http.NewRequest("GET", "http://test.com/session", nil)
+	request.Header.Set(DefaultSessionHeader, "team-a")
+
+	response, _ := unit.processRequestInSession(request, "")
+	Expect(response.StatusCode).To(Equal(http.StatusBadGateway))
+
+	body, _ := ioutil.ReadAll(response.Body)
+	Expect(string(body)).To(ContainSubstring("Session team-a does not exist"))
+}
+
+func Test_Hoverfly_processRequestInSession_UsesTheSessionOfTheProxyUser(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{ProxyUserSessions: true})
+	unit.SetModeWithArguments(v2.ModeView{Mode: modes.Simulate})
+	unit.CreateSession("benjih")
+
+	request, _ := http.NewRequest("GET", "http://test.com/session", nil)
+
+	_, chosen := unit.processRequestInSession(request, "benjih")
+	Expect(chosen).ToNot(Equal(unit))
+
+	_, chosen = unit.processRequestInSession(request, "tommysitu")
+	Expect(chosen).To(Equal(unit))
+}
+
+func Test_Hoverfly_SetModeWithArguments_ChangesTheModeOfEachSession(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.CreateSession("team-a")
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: modes.Capture,
+		Arguments: v2.ModeArgumentsView{
+			Headers: []string{"Authorization"},
+		},
+	})).To(BeNil())
+
+	session, _ := unit.getSession("team-a")
+	Expect(session.modeArguments.Headers).To(Equal([]string{"Authorization"}))
+	Expect(session.Cfg.GetMode()).To(Equal(modes.Capture))
+}
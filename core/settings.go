@@ -23,6 +23,9 @@ type Configuration struct {
 	DatabasePath string
 	Webserver    bool
 
+	// Middleware which is run after Middleware, in the phases of each stage
+	MiddlewareChain middleware.Chain
+
 	TLSVerification bool
 
 	UpstreamProxy string
@@ -39,6 +42,9 @@ type Configuration struct {
 
 	ProxyAuthorizationHeader string
 
+	SessionHeader     string
+	ProxyUserSessions bool
+
 	HttpsOnly bool
 
 	PlainHttpTunneling bool
@@ -51,6 +57,8 @@ type Configuration struct {
 	ClientAuthenticationClientKey   string
 	ClientAuthenticationCACert      string
 
+	TemplatingSeed *int64
+
 	ProxyControlWG sync.WaitGroup
 
 	mu sync.Mutex
@@ -78,6 +86,21 @@ func (c *Configuration) GetMode() string {
 	return mode
 }
 
+// SetTemplatingSeed - provides safe way to set the seed of random template helpers
+func (c *Configuration) SetTemplatingSeed(seed *int64) {
+	c.mu.Lock()
+	c.TemplatingSeed = seed
+	c.mu.Unlock()
+}
+
+// GetTemplatingSeed - provides safe way to get the seed of random template helpers
+func (c *Configuration) GetTemplatingSeed() *int64 {
+	c.mu.Lock()
+	seed := c.TemplatingSeed
+	c.mu.Unlock()
+	return seed
+}
+
 // DefaultPort - default proxy port
 const DefaultPort = "8500"
 
@@ -93,6 +116,9 @@ const DefaultDatabasePath = "requests.db"
 // DefaultJWTExpirationDelta - default token expiration if environment variable is no provided
 const DefaultJWTExpirationDelta = 1 * 24 * 60 * 60
 
+// DefaultSessionHeader - default header used to choose the session of a request
+const DefaultSessionHeader = "X-Hoverfly-Session"
+
 // Environment variables
 const (
 	// TODO Should use naming convention for environment variables
@@ -181,7 +207,11 @@ func InitSettings() *Configuration {
 	}
 
 	// middleware configuration
-	newMiddleware, _ := middleware.ConvertToNewMiddleware(os.Getenv(HoverflyMiddlewareEV))
+	newMiddleware, err := middleware.ConvertToNewMiddleware(os.Getenv(HoverflyMiddlewareEV))
+	if err != nil {
+		log.Error(err.Error())
+		newMiddleware = &middleware.Middleware{}
+	}
 
 	appConfig.Middleware = *newMiddleware
 
@@ -201,6 +231,8 @@ func InitSettings() *Configuration {
 
 	appConfig.ProxyAuthorizationHeader = "Proxy-Authorization"
 
+	appConfig.SessionHeader = DefaultSessionHeader
+
 	appConfig.CacheSize = 1000
 
 	return &appConfig
@@ -0,0 +1,115 @@
+package state
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ScenarioPrefix marks a state key which belongs to a scenario, written as scenario:<name>:<key>
+const ScenarioPrefix = "scenario:"
+
+// Scenario is a named state map kept apart from the rest of Hoverfly's state, so that
+// tests using different scenarios cannot change each other's keys. When States or
+// Transitions are declared, a key can only be given a value which they allow.
+type Scenario struct {
+	State       map[string]string
+	States      []string
+	Transitions []Transition
+}
+
+type Transition struct {
+	From string
+	To   string
+}
+
+func NewScenario() *Scenario {
+	return &Scenario{
+		State: map[string]string{},
+	}
+}
+
+// ParseScenarioKey splits a key written as scenario:<name>:<key> into the name of the
+// scenario and the key within it
+func ParseScenarioKey(key string) (string, string, bool) {
+	if !strings.HasPrefix(key, ScenarioPrefix) {
+		return "", "", false
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(key, ScenarioPrefix), ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+
+	return parts[0], parts[1], true
+}
+
+func ScenarioKey(name, key string) string {
+	return ScenarioPrefix + name + ":" + key
+}
+
+// Validate checks the state of the scenario only holds values which have been declared
+func (this Scenario) Validate() error {
+	for key, value := range this.State {
+		if !this.isDeclaredState(value) {
+			return fmt.Errorf("%s is not one of the states declared for %s", value, key)
+		}
+	}
+
+	return nil
+}
+
+// CanTransition checks whether a key can be moved from its current value to the given one.
+// A key which has not been set yet is moved from an empty state.
+func (this Scenario) CanTransition(key, value string) error {
+	if !this.isDeclaredState(value) {
+		return fmt.Errorf("%s is not one of the states declared for %s", value, key)
+	}
+
+	if len(this.Transitions) == 0 {
+		return nil
+	}
+
+	current := this.State[key]
+	for _, transition := range this.Transitions {
+		if transition.From == current && transition.To == value {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s cannot transition from %s to %s", key, describeState(current), value)
+}
+
+func (this Scenario) isDeclaredState(value string) bool {
+	if len(this.States) == 0 {
+		return true
+	}
+
+	for _, state := range this.States {
+		if state == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (this Scenario) copy() Scenario {
+	scenario := Scenario{
+		State:       map[string]string{},
+		States:      this.States,
+		Transitions: this.Transitions,
+	}
+	for key, value := range this.State {
+		scenario.State[key] = value
+	}
+
+	return scenario
+}
+
+func describeState(value string) string {
+	if value == "" {
+		return "an empty state"
+	}
+
+	return value
+}
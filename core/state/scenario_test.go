@@ -0,0 +1,86 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/state"
+	. "github.com/onsi/gomega"
+)
+
+func Test_ParseScenarioKey_SplitsTheNameOfTheScenarioFromTheKey(t *testing.T) {
+	RegisterTestingT(t)
+
+	name, key, ok := state.ParseScenarioKey("scenario:checkout:basket")
+	Expect(ok).To(BeTrue())
+	Expect(name).To(Equal("checkout"))
+	Expect(key).To(Equal("basket"))
+
+	_, key, ok = state.ParseScenarioKey("scenario:checkout:basket:items")
+	Expect(ok).To(BeTrue())
+	Expect(key).To(Equal("basket:items"))
+}
+
+func Test_ParseScenarioKey_IsNotOkForOtherKeys(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, key := range []string{"basket", "sequence:1", "scenario:checkout", "scenario::basket", "scenario:checkout:"} {
+		_, _, ok := state.ParseScenarioKey(key)
+		Expect(ok).To(BeFalse(), key)
+	}
+}
+
+func Test_Scenario_CanTransition_AllowsAnyValueWhenNothingIsDeclared(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := state.NewScenario()
+
+	Expect(unit.CanTransition("basket", "full")).To(Succeed())
+}
+
+func Test_Scenario_CanTransition_OnlyAllowsDeclaredStates(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := state.Scenario{
+		States: []string{"empty", "full"},
+	}
+
+	Expect(unit.CanTransition("basket", "full")).To(Succeed())
+
+	err := unit.CanTransition("basket", "overflowing")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("overflowing is not one of the states declared for basket"))
+}
+
+func Test_Scenario_CanTransition_OnlyAllowsDeclaredTransitions(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := state.Scenario{
+		State: map[string]string{},
+		Transitions: []state.Transition{
+			{From: "", To: "empty"},
+			{From: "empty", To: "full"},
+		},
+	}
+
+	Expect(unit.CanTransition("basket", "empty")).To(Succeed())
+
+	err := unit.CanTransition("basket", "full")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("basket cannot transition from an empty state to full"))
+
+	unit.State["basket"] = "empty"
+	Expect(unit.CanTransition("basket", "full")).To(Succeed())
+}
+
+func Test_Scenario_Validate_RejectsStatesWhichHaveNotBeenDeclared(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := state.Scenario{
+		State:  map[string]string{"basket": "overflowing"},
+		States: []string{"empty", "full"},
+	}
+
+	err := unit.Validate()
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("overflowing is not one of the states declared for basket"))
+}
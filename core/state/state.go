@@ -4,16 +4,20 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+
+	log "github.com/sirupsen/logrus"
 )
 
 type State struct {
-	State   map[string]string
-	RWMutex sync.RWMutex
+	State     map[string]string
+	Scenarios map[string]*Scenario
+	RWMutex   sync.RWMutex
 }
 
 func NewState() *State {
 	return &State{
-		State: map[string]string{},
+		State:     map[string]string{},
+		Scenarios: map[string]*Scenario{},
 	}
 }
 
@@ -32,21 +36,53 @@ func (s *State) InitializeSequences(incomingState map[string]string) {
 
 func (s *State) GetState(key string) (string, bool) {
 	s.RWMutex.RLock()
+	defer s.RWMutex.RUnlock()
+
+	if name, scenarioKey, ok := ParseScenarioKey(key); ok {
+		scenario, ok := s.Scenarios[name]
+		if !ok {
+			return "", false
+		}
+		val, ok := scenario.State[scenarioKey]
+		return val, ok
+	}
+
 	val, ok := s.State[key]
-	s.RWMutex.RUnlock()
 	return val, ok
 }
 
+// Copy returns all of the state, with the keys of each scenario written as scenario:<name>:<key>
+func (s *State) Copy() map[string]string {
+	s.RWMutex.RLock()
+	defer s.RWMutex.RUnlock()
+
+	copyState := map[string]string{}
+	for key, value := range s.State {
+		copyState[key] = value
+	}
+	for name, scenario := range s.Scenarios {
+		for key, value := range scenario.State {
+			copyState[ScenarioKey(name, key)] = value
+		}
+	}
+
+	return copyState
+}
+
+// SetState replaces the state outside of scenarios. Keys for a scenario are patched into it.
 func (s *State) SetState(state map[string]string) {
 	s.RWMutex.Lock()
-	s.State = state
+	s.State = map[string]string{}
+	for k, v := range state {
+		s.setKey(k, v)
+	}
 	s.RWMutex.Unlock()
 }
 
 func (s *State) PatchState(toPatch map[string]string) {
 	s.RWMutex.Lock()
 	for k, v := range toPatch {
-		s.State[k] = v
+		s.setKey(k, v)
 	}
 	s.RWMutex.Unlock()
 }
@@ -54,9 +90,75 @@ func (s *State) PatchState(toPatch map[string]string) {
 func (s *State) RemoveState(toRemove []string) {
 	s.RWMutex.Lock()
 	for _, key := range toRemove {
-		delete(s.State, key)
+		if name, scenarioKey, ok := ParseScenarioKey(key); ok {
+			if scenario, ok := s.Scenarios[name]; ok {
+				delete(scenario.State, scenarioKey)
+			}
+		} else {
+			delete(s.State, key)
+		}
+	}
+	s.RWMutex.Unlock()
+}
+
+// GetScenario returns a copy of a scenario. A scenario which has not been used yet is empty.
+func (s *State) GetScenario(name string) Scenario {
+	s.RWMutex.RLock()
+	defer s.RWMutex.RUnlock()
+
+	if scenario, ok := s.Scenarios[name]; ok {
+		return scenario.copy()
+	}
+
+	return NewScenario().copy()
+}
+
+// SetScenario replaces a scenario, so setting an empty scenario resets it
+func (s *State) SetScenario(name string, scenario Scenario) error {
+	if scenario.State == nil {
+		scenario.State = map[string]string{}
 	}
+
+	if err := scenario.Validate(); err != nil {
+		return err
+	}
+
+	s.RWMutex.Lock()
+	s.getScenarios()[name] = &scenario
 	s.RWMutex.Unlock()
+
+	return nil
+}
+
+func (s *State) setKey(key, value string) {
+	name, scenarioKey, ok := ParseScenarioKey(key)
+	if !ok {
+		s.State[key] = value
+		return
+	}
+
+	scenario, ok := s.getScenarios()[name]
+	if !ok {
+		scenario = NewScenario()
+		s.Scenarios[name] = scenario
+	}
+
+	if err := scenario.CanTransition(scenarioKey, value); err != nil {
+		log.WithFields(log.Fields{
+			"scenario": name,
+		}).Warn("State was not changed, " + err.Error())
+		return
+	}
+
+	scenario.State[scenarioKey] = value
+}
+
+func (s *State) getScenarios() map[string]*Scenario {
+	if s.Scenarios == nil {
+		s.Scenarios = map[string]*Scenario{}
+	}
+
+	return s.Scenarios
 }
 
 func (s *State) GetNewSequenceKey() string {
@@ -150,3 +150,106 @@ func Test_State_GetNewSequenceKey_ReturnsFirstFreeInSequence(t *testing.T) {
 	})
 	Expect(s.GetNewSequenceKey()).To(Equal("sequence:4"))
 }
+
+func Test_State_PatchState_KeepsScenarioKeysInTheirScenario(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := state.NewState()
+	s.PatchState(map[string]string{
+		"basket":                   "empty",
+		"scenario:checkout:basket": "full",
+		"scenario:returns:basket":  "returned",
+	})
+
+	Expect(s.State).To(Equal(map[string]string{
+		"basket": "empty",
+	}))
+	Expect(s.GetScenario("checkout").State).To(Equal(map[string]string{
+		"basket": "full",
+	}))
+	Expect(s.GetScenario("returns").State).To(Equal(map[string]string{
+		"basket": "returned",
+	}))
+
+	val, ok := s.GetState("scenario:checkout:basket")
+	Expect(ok).To(BeTrue())
+	Expect(val).To(Equal("full"))
+}
+
+func Test_State_PatchState_DoesNotMakeTransitionsWhichTheScenarioDoesNotAllow(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := state.NewState()
+	Expect(s.SetScenario("checkout", state.Scenario{
+		State: map[string]string{"basket": "empty"},
+		Transitions: []state.Transition{
+			{From: "empty", To: "full"},
+		},
+	})).To(Succeed())
+
+	s.PatchState(map[string]string{"scenario:checkout:basket": "paid"})
+	Expect(s.GetScenario("checkout").State).To(Equal(map[string]string{"basket": "empty"}))
+
+	s.PatchState(map[string]string{"scenario:checkout:basket": "full"})
+	Expect(s.GetScenario("checkout").State).To(Equal(map[string]string{"basket": "full"}))
+}
+
+func Test_State_RemoveState_RemovesScenarioKeys(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := state.NewState()
+	s.PatchState(map[string]string{
+		"scenario:checkout:basket": "full",
+		"scenario:checkout:user":   "logged-in",
+	})
+
+	s.RemoveState([]string{"scenario:checkout:basket"})
+
+	Expect(s.GetScenario("checkout").State).To(Equal(map[string]string{
+		"user": "logged-in",
+	}))
+}
+
+func Test_State_Copy_IncludesTheKeysOfEveryScenario(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := state.NewState()
+	s.PatchState(map[string]string{
+		"basket":                   "empty",
+		"scenario:checkout:basket": "full",
+	})
+
+	Expect(s.Copy()).To(Equal(map[string]string{
+		"basket":                   "empty",
+		"scenario:checkout:basket": "full",
+	}))
+}
+
+func Test_State_SetScenario_ResetsTheScenario(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := state.NewState()
+	s.PatchState(map[string]string{
+		"basket":                   "empty",
+		"scenario:checkout:basket": "full",
+	})
+
+	Expect(s.SetScenario("checkout", state.Scenario{})).To(Succeed())
+
+	Expect(s.GetScenario("checkout").State).To(BeEmpty())
+	Expect(s.State).To(Equal(map[string]string{"basket": "empty"}))
+}
+
+func Test_State_SetScenario_RejectsStatesWhichHaveNotBeenDeclared(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := state.NewState()
+
+	err := s.SetScenario("checkout", state.Scenario{
+		State:  map[string]string{"basket": "overflowing"},
+		States: []string{"empty", "full"},
+	})
+
+	Expect(err).ToNot(BeNil())
+	Expect(s.GetScenario("checkout").State).To(BeEmpty())
+}
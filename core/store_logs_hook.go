@@ -2,6 +2,7 @@ package hoverfly
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -10,6 +11,7 @@ import (
 type StoreLogsHook struct {
 	Entries   []*logrus.Entry
 	LogsLimit int
+	mutex     sync.RWMutex
 }
 
 func NewStoreLogsHook() *StoreLogsHook {
@@ -23,6 +25,10 @@ func (hook *StoreLogsHook) Fire(entry *logrus.Entry) error {
 	if hook.LogsLimit == 0 {
 		return nil
 	}
+
+	hook.mutex.Lock()
+	defer hook.mutex.Unlock()
+
 	if len(hook.Entries) >= hook.LogsLimit {
 		hook.Entries = append(hook.Entries[:0], hook.Entries[1:]...)
 	}
@@ -30,7 +36,7 @@ func (hook *StoreLogsHook) Fire(entry *logrus.Entry) error {
 	return nil
 }
 
-func (hook StoreLogsHook) Levels() []logrus.Level {
+func (hook *StoreLogsHook) Levels() []logrus.Level {
 	return []logrus.Level{
 		logrus.PanicLevel,
 		logrus.FatalLevel,
@@ -43,10 +49,16 @@ func (hook StoreLogsHook) Levels() []logrus.Level {
 
 type Fields map[string]interface{}
 
-func (hook StoreLogsHook) GetLogs(limit int, from *time.Time) ([]*logrus.Entry, error) {
+// GetLogs returns a copy of the latest entries, as entries can be logged from
+// other goroutines while they are being read
+func (hook *StoreLogsHook) GetLogs(limit int, from *time.Time) ([]*logrus.Entry, error) {
 	if hook.LogsLimit == 0 {
 		return []*logrus.Entry{}, fmt.Errorf("Logs disabled")
 	}
+
+	hook.mutex.RLock()
+	defer hook.mutex.RUnlock()
+
 	entriesLength := len(hook.Entries)
 	if limit > entriesLength {
 		limit = entriesLength
@@ -64,6 +76,6 @@ func (hook StoreLogsHook) GetLogs(limit int, from *time.Time) ([]*logrus.Entry,
 		}
 		return entries, nil
 	} else {
-		return hook.Entries[entriesLength-limit:], nil
+		return append([]*logrus.Entry{}, hook.Entries[entriesLength-limit:]...), nil
 	}
 }
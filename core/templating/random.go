@@ -0,0 +1,176 @@
+package templating
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/big"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/aymerick/raymond"
+	"github.com/icrowley/fake"
+)
+
+// lockedSource is a rand.Source which can be used by many templates rendering at once
+type lockedSource struct {
+	mutex sync.Mutex
+	src   rand.Source
+}
+
+func (this *lockedSource) Int63() int64 {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	return this.src.Int63()
+}
+
+func (this *lockedSource) Seed(seed int64) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.src.Seed(seed)
+}
+
+// seeds gives the seed of the random generator of each unseeded render
+var seeds = rand.New(&lockedSource{src: rand.NewSource(time.Now().UnixNano())})
+
+// randomDataKey is the private data which holds the random generator of a render
+const randomDataKey = "random"
+
+// NewRandom returns a random generator for the random helpers of a render. If a seed is given,
+// the values it generates only depend on the seed and the request. The generator can be used
+// for several templates, such as those of a response, so that they are given different values.
+func NewRandom(seed *int64, requestDetails *models.RequestDetails) *rand.Rand {
+	if seed == nil {
+		return rand.New(rand.NewSource(seeds.Int63()))
+	}
+
+	return rand.New(rand.NewSource(getRequestSeed(*seed, requestDetails)))
+}
+
+// getRandom returns the random generator of the render calling a helper
+func getRandom(options *raymond.Options) *rand.Rand {
+	if options != nil {
+		if random, ok := options.Data(randomDataKey).(*rand.Rand); ok {
+			return random
+		}
+	}
+
+	return NewRandom(nil, nil)
+}
+
+// fakeLock is held while the fake package is used, as it has a single generator of its own
+var fakeLock sync.Mutex
+
+// withFake seeds the generator of the fake package from the random generator before calling
+// generate, so that the value generated only depends on the random generator
+func withFake(random *rand.Rand, generate func() string) string {
+	seed := random.Int63()
+
+	fakeLock.Lock()
+	defer fakeLock.Unlock()
+
+	fake.Seed(seed)
+	return generate()
+}
+
+// getRequestSeed combines the seed with the request, so identical requests are
+// given identical values and different requests are given different values
+func getRequestSeed(seed int64, requestDetails *models.RequestDetails) int64 {
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%d:%s", seed, requestDetails.Hash())
+	return int64(hash.Sum64())
+}
+
+const letterBytes = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// IBAN lengths of countries which only use digits in their basic bank account number
+var ibanLengths = map[string]int{
+	"AT": 20,
+	"BE": 16,
+	"DE": 22,
+	"ES": 24,
+	"FI": 18,
+	"PL": 28,
+	"PT": 25,
+	"SE": 24,
+}
+
+var ibanCountries = []string{"AT", "BE", "DE", "ES", "FI", "PL", "PT", "SE"}
+
+func randomDigits(random *rand.Rand, length int) string {
+	digits := make([]byte, length)
+	for i := range digits {
+		digits[i] = byte('0' + random.Intn(10))
+	}
+	return string(digits)
+}
+
+func generateIban(random *rand.Rand) string {
+	country := ibanCountries[random.Intn(len(ibanCountries))]
+	bban := randomDigits(random, ibanLengths[country]-4)
+
+	return country + getIbanCheckDigits(country, bban) + bban
+}
+
+// getIbanCheckDigits calculates the check digits using the ISO 7064 mod 97-10 scheme
+func getIbanCheckDigits(country, bban string) string {
+	numeric := ""
+	for _, char := range bban + country + "00" {
+		if char >= 'A' && char <= 'Z' {
+			numeric += fmt.Sprintf("%d", char-'A'+10)
+		} else {
+			numeric += string(char)
+		}
+	}
+
+	value, _ := new(big.Int).SetString(numeric, 10)
+	remainder := new(big.Int).Mod(value, big.NewInt(97)).Int64()
+
+	return fmt.Sprintf("%02d", 98-remainder)
+}
+
+var creditCardPrefixes = map[string][]string{
+	"visa":       {"4"},
+	"mastercard": {"51", "52", "53", "54", "55"},
+	"amex":       {"34", "37"},
+}
+
+var creditCardLengths = map[string]int{
+	"visa":       16,
+	"mastercard": 16,
+	"amex":       15,
+}
+
+func generateCreditCardNumber(random *rand.Rand, vendor string) string {
+	vendor = strings.ToLower(vendor)
+	prefixes, ok := creditCardPrefixes[vendor]
+	if !ok {
+		vendor = "visa"
+		prefixes = creditCardPrefixes[vendor]
+	}
+
+	number := prefixes[random.Intn(len(prefixes))]
+	number += randomDigits(random, creditCardLengths[vendor]-len(number)-1)
+
+	return number + getLuhnCheckDigit(number)
+}
+
+// getLuhnCheckDigit returns the digit which makes the number pass the Luhn check
+func getLuhnCheckDigit(number string) string {
+	sum := 0
+	for i := len(number) - 1; i >= 0; i-- {
+		digit := int(number[i] - '0')
+		// Every other digit is doubled, starting with the one next to the check digit
+		if (len(number)-i)%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+
+	return fmt.Sprintf("%d", (10-sum%10)%10)
+}
@@ -1,7 +1,9 @@
 package templating
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -11,8 +13,8 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
-	"github.com/SpectoLabs/hoverfly/core/util"
 	"github.com/icrowley/fake"
+	"k8s.io/client-go/util/jsonpath"
 )
 
 type templateHelpers struct {
@@ -62,48 +64,161 @@ func (t templateHelpers) currentDateTimeSubtract(subtractTime string, format str
 	return formatted
 }
 
-func (t templateHelpers) randomString() string {
-	return util.RandomString()
+func (t templateHelpers) randomString(options *raymond.Options) string {
+	random := getRandom(options)
+	return t.randomStringLength(3+random.Intn(13), options)
 }
 
-func (t templateHelpers) randomStringLength(length int) string {
-	return util.RandomStringWithLength(length)
+func (t templateHelpers) randomStringLength(length int, options *raymond.Options) string {
+	random := getRandom(options)
+	letters := make([]byte, length)
+	for i := range letters {
+		letters[i] = letterBytes[random.Intn(len(letterBytes))]
+	}
+	return string(letters)
+}
+
+func (t templateHelpers) randomBoolean(options *raymond.Options) string {
+	return strconv.FormatBool(getRandom(options).Intn(2) == 1)
+}
+
+func (t templateHelpers) randomInteger(options *raymond.Options) string {
+	return strconv.Itoa(getRandom(options).Int())
+}
+
+func (t templateHelpers) randomIntegerRange(min, max int, options *raymond.Options) string {
+	return strconv.Itoa(getRandom(options).Intn(max-min) + min)
+}
+
+func (t templateHelpers) randomFloat(options *raymond.Options) string {
+	return strconv.FormatFloat(getRandom(options).Float64(), 'f', 6, 64)
+}
+
+func (t templateHelpers) randomFloatRange(min, max float64, options *raymond.Options) string {
+	return strconv.FormatFloat(min+getRandom(options).Float64()*(max-min), 'f', 6, 64)
+}
+
+func (t templateHelpers) randomEmail(options *raymond.Options) string {
+	return withFake(getRandom(options), func() string {
+		return fake.EmailAddress()
+	})
+}
+
+func (t templateHelpers) randomIPv4(options *raymond.Options) string {
+	return withFake(getRandom(options), func() string {
+		return fake.IPv4()
+	})
+}
+
+func (t templateHelpers) randomIPv6(options *raymond.Options) string {
+	return withFake(getRandom(options), func() string {
+		return fake.IPv6()
+	})
+}
+
+func (t templateHelpers) randomUuid(options *raymond.Options) string {
+	bytes := make([]byte, 16)
+	getRandom(options).Read(bytes)
+	// Set the version to 4 and the variant to RFC 4122, as uuid.NewRandom does
+	bytes[6] = (bytes[6] & 0x0f) | 0x40
+	bytes[8] = (bytes[8] & 0x3f) | 0x80
+	return uuid.UUID(bytes).String()
+}
+
+func (t templateHelpers) randomFirstName(options *raymond.Options) string {
+	return withFake(getRandom(options), func() string {
+		return fake.FirstName()
+	})
+}
+
+func (t templateHelpers) randomLastName(options *raymond.Options) string {
+	return withFake(getRandom(options), func() string {
+		return fake.LastName()
+	})
+}
+
+func (t templateHelpers) randomFullName(options *raymond.Options) string {
+	return withFake(getRandom(options), func() string {
+		return fake.FullName()
+	})
+}
+
+func (t templateHelpers) randomStreetAddress(options *raymond.Options) string {
+	return withFake(getRandom(options), func() string {
+		return fake.StreetAddress()
+	})
 }
 
-func (t templateHelpers) randomBoolean() string {
-	return strconv.FormatBool(util.RandomBoolean())
+func (t templateHelpers) randomCity(options *raymond.Options) string {
+	return withFake(getRandom(options), func() string {
+		return fake.City()
+	})
 }
 
-func (t templateHelpers) randomInteger() string {
-	return strconv.Itoa(util.RandomInteger())
+func (t templateHelpers) randomCountry(options *raymond.Options) string {
+	return withFake(getRandom(options), func() string {
+		return fake.Country()
+	})
 }
 
-func (t templateHelpers) randomIntegerRange(min, max int) string {
-	return strconv.Itoa(util.RandomIntegerRange(min, max))
+func (t templateHelpers) randomZip(options *raymond.Options) string {
+	return withFake(getRandom(options), func() string {
+		return fake.Zip()
+	})
 }
 
-func (t templateHelpers) randomFloat() string {
-	return strconv.FormatFloat(util.RandomFloat(), 'f', 6, 64)
+func (t templateHelpers) randomPhoneNumber(options *raymond.Options) string {
+	return withFake(getRandom(options), func() string {
+		return fake.Phone()
+	})
 }
 
-func (t templateHelpers) randomFloatRange(min, max float64) string {
-	return strconv.FormatFloat(util.RandomFloatRange(min, max), 'f', 6, 64)
+func (t templateHelpers) randomIban(options *raymond.Options) string {
+	return generateIban(getRandom(options))
 }
 
-func (t templateHelpers) randomEmail() string {
-	return fake.EmailAddress()
+func (t templateHelpers) randomCreditCardNumber(options *raymond.Options) string {
+	random := getRandom(options)
+	vendors := []string{"visa", "mastercard", "amex"}
+	return generateCreditCardNumber(random, vendors[random.Intn(len(vendors))])
 }
 
-func (t templateHelpers) randomIPv4() string {
-	return fake.IPv4()
+func (t templateHelpers) randomLoremWords(count int, options *raymond.Options) string {
+	return withFake(getRandom(options), func() string {
+		return fake.WordsN(count)
+	})
 }
 
-func (t templateHelpers) randomIPv6() string {
-	return fake.IPv6()
+func (t templateHelpers) randomLoremSentences(count int, options *raymond.Options) string {
+	return withFake(getRandom(options), func() string {
+		return fake.SentencesN(count)
+	})
 }
 
-func (t templateHelpers) randomUuid() string {
-	return uuid.New()
+func (t templateHelpers) randomLoremParagraphs(count int, options *raymond.Options) string {
+	return withFake(getRandom(options), func() string {
+		return fake.ParagraphsN(count)
+	})
+}
+
+// randomDateBetween returns a date time between the two given, which are in the format
+// they should be returned in. If either cannot be parsed, an empty string is returned.
+func (t templateHelpers) randomDateBetween(from, to, format string, options *raymond.Options) string {
+	fromTime, err := time.Parse(format, from)
+	if err != nil {
+		return ""
+	}
+	toTime, err := time.Parse(format, to)
+	if err != nil || toTime.Before(fromTime) {
+		return ""
+	}
+
+	duration := toTime.Sub(fromTime)
+	if duration == 0 {
+		return fromTime.UTC().Format(format)
+	}
+
+	return fromTime.Add(time.Duration(getRandom(options).Int63n(int64(duration)))).UTC().Format(format)
 }
 
 func (t templateHelpers) requestBody(queryType, query string, options *raymond.Options) string {
@@ -140,6 +255,165 @@ func (t templateHelpers) replace(target, oldValue, newValue string) string {
 	return strings.Replace(target, oldValue, newValue, -1)
 }
 
+// requestBodyJson returns the values found by a JSON path query on the request body,
+// rather than their text, so that arrays and objects can be used with #each and toJson
+func (t templateHelpers) requestBodyJson(query string, options *raymond.Options) interface{} {
+	var data interface{}
+	if err := json.Unmarshal([]byte(options.Value("request").(Request).body), &data); err != nil {
+		log.Errorf("Failed to unmarshal request body to JSON for templating: %s", err.Error())
+		return nil
+	}
+
+	jsonPath := jsonpath.New("")
+	if err := jsonPath.Parse(prepareJsonPathQuery(query)); err != nil {
+		log.Errorf("Failed to parse json path query %s: %s", query, err.Error())
+		return nil
+	}
+
+	results, err := jsonPath.FindResults(data)
+	if err != nil || len(results) == 0 {
+		return nil
+	}
+
+	values := []interface{}{}
+	for _, result := range results[0] {
+		values = append(values, result.Interface())
+	}
+
+	// A query with a wildcard or a filter returns a list, otherwise it returns a single value
+	if len(values) == 1 && !strings.ContainsAny(query, "*?") {
+		return values[0]
+	}
+	return values
+}
+
+func (t templateHelpers) add(a, b string) string {
+	return t.arithmetic(a, b, func(x, y float64) float64 { return x + y })
+}
+
+func (t templateHelpers) subtract(a, b string) string {
+	return t.arithmetic(a, b, func(x, y float64) float64 { return x - y })
+}
+
+func (t templateHelpers) multiply(a, b string) string {
+	return t.arithmetic(a, b, func(x, y float64) float64 { return x * y })
+}
+
+func (t templateHelpers) divide(a, b string) string {
+	if y, err := strconv.ParseFloat(b, 64); err == nil && y == 0 {
+		return ""
+	}
+	return t.arithmetic(a, b, func(x, y float64) float64 { return x / y })
+}
+
+func (t templateHelpers) arithmetic(a, b string, operation func(x, y float64) float64) string {
+	x, err := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	if err != nil {
+		return ""
+	}
+	y, err := strconv.ParseFloat(strings.TrimSpace(b), 64)
+	if err != nil {
+		return ""
+	}
+
+	return strconv.FormatFloat(operation(x, y), 'f', -1, 64)
+}
+
+func (t templateHelpers) concat(a, b string) string {
+	return a + b
+}
+
+func (t templateHelpers) substring(target, start, end string) string {
+	runes := []rune(target)
+
+	from, err := strconv.Atoi(start)
+	if err != nil || from < 0 {
+		from = 0
+	}
+	to, err := strconv.Atoi(end)
+	if err != nil || to > len(runes) {
+		to = len(runes)
+	}
+	if from > to {
+		return ""
+	}
+
+	return string(runes[from:to])
+}
+
+func (t templateHelpers) split(target, separator string) []string {
+	return strings.Split(target, separator)
+}
+
+func (t templateHelpers) length(value interface{}) string {
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map, reflect.String:
+		return strconv.Itoa(reflectValue.Len())
+	}
+	return "0"
+}
+
+func (t templateHelpers) eq(a, b interface{}) bool {
+	return raymond.Str(a) == raymond.Str(b)
+}
+
+func (t templateHelpers) ne(a, b interface{}) bool {
+	return !t.eq(a, b)
+}
+
+func (t templateHelpers) gt(a, b interface{}) bool {
+	return t.compare(a, b) > 0
+}
+
+func (t templateHelpers) gte(a, b interface{}) bool {
+	return t.compare(a, b) >= 0
+}
+
+func (t templateHelpers) lt(a, b interface{}) bool {
+	return t.compare(a, b) < 0
+}
+
+func (t templateHelpers) lte(a, b interface{}) bool {
+	return t.compare(a, b) <= 0
+}
+
+// compare compares the values as numbers if they both are numbers, otherwise as strings
+func (t templateHelpers) compare(a, b interface{}) int {
+	x, y := raymond.Str(a), raymond.Str(b)
+
+	xNumber, xErr := strconv.ParseFloat(x, 64)
+	yNumber, yErr := strconv.ParseFloat(y, 64)
+	if xErr == nil && yErr == nil {
+		if xNumber < yNumber {
+			return -1
+		} else if xNumber > yNumber {
+			return 1
+		}
+		return 0
+	}
+
+	return strings.Compare(x, y)
+}
+
+func (t templateHelpers) parseJson(value string) interface{} {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+		log.Errorf("Failed to parse JSON for templating: %s", err.Error())
+		return nil
+	}
+	return parsed
+}
+
+func (t templateHelpers) toJson(value interface{}) raymond.SafeString {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		log.Errorf("Failed to write JSON for templating: %s", err.Error())
+		return ""
+	}
+	return raymond.SafeString(bytes)
+}
+
 func prepareJsonPathQuery(query string) string {
 	if string(query[0:1]) != "{" && string(query[len(query)-1:]) != "}" {
 		query = fmt.Sprintf("{%s}", query)
@@ -125,3 +125,188 @@ func Test_replace(t *testing.T) {
 
 	Expect(unit.replace("oink, oink, oink", "oink", "moo")).To(Equal("moo, moo, moo"))
 }
+
+func Test_arithmetic(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{
+		now: testNow,
+	}
+
+	Expect(unit.add("1", "2")).To(Equal("3"))
+	Expect(unit.add("1.5", " 2")).To(Equal("3.5"))
+	Expect(unit.subtract("1", "2")).To(Equal("-1"))
+	Expect(unit.multiply("1.5", "4")).To(Equal("6"))
+	Expect(unit.divide("10", "4")).To(Equal("2.5"))
+}
+
+func Test_arithmetic_failure(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{
+		now: testNow,
+	}
+
+	Expect(unit.add("cat", "2")).To(Equal(""))
+	Expect(unit.multiply("2", "")).To(Equal(""))
+	Expect(unit.divide("10", "0")).To(Equal(""))
+}
+
+func Test_concat(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{
+		now: testNow,
+	}
+
+	Expect(unit.concat("booking-", "123")).To(Equal("booking-123"))
+}
+
+func Test_substring(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{
+		now: testNow,
+	}
+
+	Expect(unit.substring("hoverfly", "0", "5")).To(Equal("hover"))
+	Expect(unit.substring("hoverfly", "5", "100")).To(Equal("fly"))
+	Expect(unit.substring("hoverfly", "cat", "5")).To(Equal("hover"))
+	Expect(unit.substring("hoverfly", "6", "2")).To(Equal(""))
+	Expect(unit.substring("héllo", "1", "2")).To(Equal("é"))
+}
+
+func Test_split(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{
+		now: testNow,
+	}
+
+	Expect(unit.split("a,b,c", ",")).To(Equal([]string{"a", "b", "c"}))
+}
+
+func Test_length(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{
+		now: testNow,
+	}
+
+	Expect(unit.length("hoverfly")).To(Equal("8"))
+	Expect(unit.length([]interface{}{1, 2})).To(Equal("2"))
+	Expect(unit.length(map[string]string{"a": "1"})).To(Equal("1"))
+	Expect(unit.length(nil)).To(Equal("0"))
+}
+
+func Test_comparisons(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{
+		now: testNow,
+	}
+
+	Expect(unit.eq("POST", "POST")).To(BeTrue())
+	Expect(unit.eq([]string{"1"}, 1)).To(BeTrue())
+	Expect(unit.ne("GET", "POST")).To(BeTrue())
+
+	Expect(unit.gt("10", "9")).To(BeTrue())
+	Expect(unit.gt(9.5, "10")).To(BeFalse())
+	Expect(unit.gte("10", 10)).To(BeTrue())
+	Expect(unit.lt("b", "a")).To(BeFalse())
+	Expect(unit.lte("a", "b")).To(BeTrue())
+}
+
+func Test_parseJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{
+		now: testNow,
+	}
+
+	Expect(unit.parseJson(`{"ids": [1, 2]}`)).To(Equal(map[string]interface{}{"ids": []interface{}{1.0, 2.0}}))
+	Expect(unit.parseJson(`{"ids"`)).To(BeNil())
+}
+
+func Test_toJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{
+		now: testNow,
+	}
+
+	Expect(unit.toJson([]string{"a", "b"})).To(BeEquivalentTo(`["a","b"]`))
+	Expect(unit.toJson("say \"hi\"")).To(BeEquivalentTo(`"say \"hi\""`))
+	Expect(unit.toJson(nil)).To(BeEquivalentTo(`null`))
+}
+
+func Test_randomIban_HasValidCheckDigits(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{
+		now: testNow,
+	}
+
+	for i := 0; i < 20; i++ {
+		iban := unit.randomIban(nil)
+		Expect(iban).To(HaveLen(ibanLengths[iban[0:2]]))
+		Expect(getIbanCheckDigits(iban[0:2], iban[4:])).To(Equal(iban[2:4]))
+	}
+
+	// A known valid IBAN
+	Expect(getIbanCheckDigits("DE", "370400440532013000")).To(Equal("89"))
+}
+
+func Test_randomCreditCardNumber_PassesLuhnCheck(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{
+		now: testNow,
+	}
+
+	for i := 0; i < 20; i++ {
+		number := unit.randomCreditCardNumber(nil)
+		Expect(len(number)).To(BeNumerically(">=", 15))
+		Expect(getLuhnCheckDigit(number[:len(number)-1])).To(Equal(number[len(number)-1:]))
+	}
+
+	// A known valid Visa test number
+	Expect(getLuhnCheckDigit("411111111111111")).To(Equal("1"))
+	Expect(generateCreditCardNumber(NewRandom(nil, nil), "amex")).To(HaveLen(15))
+}
+
+func Test_randomDateBetween(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{
+		now: testNow,
+	}
+
+	for i := 0; i < 20; i++ {
+		date := unit.randomDateBetween("2018-01-01", "2018-01-31", "2006-01-02", nil)
+		Expect(date >= "2018-01-01" && date < "2018-01-31").To(BeTrue(), date)
+	}
+
+	Expect(unit.randomDateBetween("2018-01-01", "2018-01-01", "2006-01-02", nil)).To(Equal("2018-01-01"))
+}
+
+func Test_randomDateBetween_failure(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{
+		now: testNow,
+	}
+
+	Expect(unit.randomDateBetween("cat", "2018-01-31", "2006-01-02", nil)).To(Equal(""))
+	Expect(unit.randomDateBetween("2018-01-31", "2018-01-01", "2006-01-02", nil)).To(Equal(""))
+}
+
+func Test_randomUuid_IsVersion4(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{
+		now: testNow,
+	}
+
+	Expect(unit.randomUuid(nil)).To(MatchRegexp(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`))
+}
@@ -2,6 +2,7 @@ package templating
 
 import (
 	"fmt"
+	"math/rand"
 	"strings"
 	"time"
 
@@ -16,12 +17,17 @@ type TemplatingData struct {
 }
 
 type Request struct {
-	QueryParam map[string][]string
-	Path       []string
-	Scheme     string
-	Body       func(queryType, query string, options *raymond.Options) string
-	body       string
-	Method     string
+	QueryParam  map[string][]string
+	RawQuery    string
+	Path        []string
+	PathParams  map[string]string
+	Scheme      string
+	Destination string
+	Header      map[string][]string
+	Body        func(queryType, query string, options *raymond.Options) string
+	BodyJson    func(query string, options *raymond.Options) interface{}
+	body        string
+	Method      string
 }
 
 type Templator struct {
@@ -51,7 +57,37 @@ func NewTemplator() *Templator {
 		raymond.RegisterHelper("randomIPv4", t.randomIPv4)
 		raymond.RegisterHelper("randomIPv6", t.randomIPv6)
 		raymond.RegisterHelper("randomUuid", t.randomUuid)
+		raymond.RegisterHelper("randomFirstName", t.randomFirstName)
+		raymond.RegisterHelper("randomLastName", t.randomLastName)
+		raymond.RegisterHelper("randomFullName", t.randomFullName)
+		raymond.RegisterHelper("randomStreetAddress", t.randomStreetAddress)
+		raymond.RegisterHelper("randomCity", t.randomCity)
+		raymond.RegisterHelper("randomCountry", t.randomCountry)
+		raymond.RegisterHelper("randomZip", t.randomZip)
+		raymond.RegisterHelper("randomPhoneNumber", t.randomPhoneNumber)
+		raymond.RegisterHelper("randomIban", t.randomIban)
+		raymond.RegisterHelper("randomCreditCardNumber", t.randomCreditCardNumber)
+		raymond.RegisterHelper("randomLoremWords", t.randomLoremWords)
+		raymond.RegisterHelper("randomLoremSentences", t.randomLoremSentences)
+		raymond.RegisterHelper("randomLoremParagraphs", t.randomLoremParagraphs)
+		raymond.RegisterHelper("randomDateBetween", t.randomDateBetween)
 		raymond.RegisterHelper("replace", t.replace)
+		raymond.RegisterHelper("add", t.add)
+		raymond.RegisterHelper("subtract", t.subtract)
+		raymond.RegisterHelper("multiply", t.multiply)
+		raymond.RegisterHelper("divide", t.divide)
+		raymond.RegisterHelper("concat", t.concat)
+		raymond.RegisterHelper("substring", t.substring)
+		raymond.RegisterHelper("split", t.split)
+		raymond.RegisterHelper("length", t.length)
+		raymond.RegisterHelper("eq", t.eq)
+		raymond.RegisterHelper("ne", t.ne)
+		raymond.RegisterHelper("gt", t.gt)
+		raymond.RegisterHelper("gte", t.gte)
+		raymond.RegisterHelper("lt", t.lt)
+		raymond.RegisterHelper("lte", t.lte)
+		raymond.RegisterHelper("parseJson", t.parseJson)
+		raymond.RegisterHelper("toJson", t.toJson)
 
 		helpersRegistered = true
 	}
@@ -64,24 +100,43 @@ func (*Templator) ParseTemplate(responseBody string) (*raymond.Template, error)
 	return raymond.Parse(responseBody)
 }
 
-func (*Templator) RenderTemplate(tpl *raymond.Template, requestDetails *models.RequestDetails, state map[string]string) (string, error) {
+// RenderTemplate renders the template with the request. The path parameters are the
+// values captured by the path matchers of the pair which matched the request. Random
+// helpers use the random generator given, or an unseeded one if it is nil.
+func (*Templator) RenderTemplate(tpl *raymond.Template, requestDetails *models.RequestDetails, pathParams map[string]string, state map[string]string, random *rand.Rand) (string, error) {
 	if tpl == nil {
 		return "", fmt.Errorf("template cannot be nil")
 	}
+	if random == nil {
+		random = NewRandom(nil, requestDetails)
+	}
 	ctx := NewTemplatingDataFromRequest(requestDetails, state)
-	return tpl.Exec(ctx)
+	ctx.Request.PathParams = pathParams
+	data := raymond.NewDataFrame()
+	data.Set(randomDataKey, random)
+	return tpl.ExecWith(ctx, data)
 }
 
-
 func NewTemplatingDataFromRequest(requestDetails *models.RequestDetails, state map[string]string) *TemplatingData {
+	// The raw query is lost if the request has been through middleware, so is rebuilt from the query params
+	rawQuery := requestDetails.GetRawQuery()
+	if rawQuery == "" {
+		rawQuery = requestDetails.QueryString()
+	}
+
 	return &TemplatingData{
 		Request: Request{
-			Path:       strings.Split(requestDetails.Path, "/")[1:],
-			QueryParam: requestDetails.Query,
-			Scheme:     requestDetails.Scheme,
-			Body:       templateHelpers{}.requestBody,
-			body:       requestDetails.Body,
-			Method:     requestDetails.Method,
+			Path:        strings.Split(requestDetails.Path, "/")[1:],
+			PathParams:  map[string]string{},
+			QueryParam:  requestDetails.Query,
+			RawQuery:    rawQuery,
+			Scheme:      requestDetails.Scheme,
+			Destination: requestDetails.Destination,
+			Header:      requestDetails.Headers,
+			Body:        templateHelpers{}.requestBody,
+			BodyJson:    templateHelpers{}.requestBodyJson,
+			body:        requestDetails.Body,
+			Method:      requestDetails.Method,
 		},
 		State: state,
 		CurrentDateTime: func(a1, a2, a3 string) string {
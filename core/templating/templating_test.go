@@ -371,9 +371,157 @@ func Test_ApplyTemplate_ReplaceStringInQueryParams(t *testing.T) {
 	Expect(template).To(Equal(`moo,moo,moo`))
 }
 
+func Test_ApplyTemplate_Request_PathParams(t *testing.T) {
+	RegisterTestingT(t)
+
+	templator := templating.NewTemplator()
+	template, _ := templator.ParseTemplate(`{{ Request.PathParams.id }}/{{ Request.PathParams.orderId }}`)
+
+	result, err := templator.RenderTemplate(template, &models.RequestDetails{
+		Path: "/users/123/orders/456",
+	}, map[string]string{
+		"id":      "123",
+		"orderId": "456",
+	}, make(map[string]string), nil)
+
+	Expect(err).To(BeNil())
+	Expect(result).To(Equal("123/456"))
+}
+
+func Test_ApplyTemplate_Request_HeaderDestinationAndRawQuery(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{
+		Destination: "test.com",
+		Query: map[string][]string{
+			"b": {"2"},
+			"a": {"1"},
+		},
+		Headers: map[string][]string{
+			"X-Correlation-Id": {"abc-123"},
+		},
+	}, make(map[string]string), `{{ Request.Header.X-Correlation-Id }} {{ Request.Destination }} {{{ Request.RawQuery }}}`)
+
+	Expect(err).To(BeNil())
+
+	Expect(template).To(Equal(`abc-123 test.com a=1&b=2`))
+}
+
+func Test_ApplyTemplate_ArithmeticAndStringHelpers(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{
+		Query: map[string][]string{
+			"page":  {"2"},
+			"names": {"ben,tommy"},
+		},
+	}, make(map[string]string), `{{ add (multiply Request.QueryParam.page 10) 1 }} {{ concat "booking-" (substring Request.QueryParam.names 0 3) }}`)
+
+	Expect(err).To(BeNil())
+	Expect(template).To(Equal("21 booking-ben"))
+}
+
+func Test_ApplyTemplate_ConditionalsAndLookupInState(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{
+		Method: "POST",
+		Path:   "/bookings/1",
+	}, map[string]string{
+		"booking-1": "confirmed",
+		"count":     "11",
+	}, `{{#if (eq Request.Method "POST")}}created{{else}}found{{/if}} {{ lookup State (concat "booking-" Request.Path.[1]) }} {{#if (gt State.count 9)}}many{{/if}}`)
+
+	Expect(err).To(BeNil())
+	Expect(template).To(Equal("created confirmed many"))
+}
+
+func Test_ApplyTemplate_EachOverJsonArrayInRequestBody(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{
+		Body: `{"items": [{"id": 1, "name": "one"}, {"id": 2, "name": "two"}]}`,
+	}, make(map[string]string), `[{{#each (Request.BodyJson '$.items')}}{"id": {{ add this.id 100 }}, "name": {{ toJson this.name }} }{{#unless @last}}, {{/unless}}{{/each}}]`)
+
+	Expect(err).To(BeNil())
+	Expect(template).To(Equal(`[{"id": 101, "name": "one" }, {"id": 102, "name": "two" }]`))
+}
+
+func Test_ApplyTemplate_BuildsJsonFromValues(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{
+		Body: `{"items": [{"id": 1}, {"id": 2}]}`,
+		Query: map[string][]string{
+			"tags": {"a,b"},
+		},
+	}, map[string]string{
+		"basket": `{"total": 3}`,
+	}, `{"ids": {{ toJson (Request.BodyJson '$.items[*].id') }}, "tags": {{ toJson (split Request.QueryParam.tags ",") }}, "count": {{ length (Request.BodyJson '$.items') }}, "total": {{ lookup (parseJson State.basket) "total" }} }`)
+
+	Expect(err).To(BeNil())
+	Expect(template).To(Equal(`{"ids": [1,2], "tags": ["a","b"], "count": 2, "total": 3 }`))
+}
+
+func Test_RenderTemplate_WithSeed_GivesTheSameValuesForTheSameRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	templator := templating.NewTemplator()
+	template, _ := templator.ParseTemplate(`{{ randomFullName }} {{ randomStreetAddress }} {{ randomPhoneNumber }} {{ randomIban }} {{ randomCreditCardNumber }} {{ randomLoremWords 3 }} {{ randomDateBetween "2018-01-01" "2018-12-31" "2006-01-02" }} {{ randomString }} {{ randomInteger }} {{ randomUuid }} {{ randomEmail }}`)
+
+	seed := int64(42)
+	otherSeed := int64(43)
+	request := &models.RequestDetails{Method: "GET", Destination: "test.com", Path: "/users/1"}
+	otherRequest := &models.RequestDetails{Method: "GET", Destination: "test.com", Path: "/users/2"}
+
+	first, err := templator.RenderTemplate(template, request, nil, nil, templating.NewRandom(&seed, request))
+	Expect(err).To(BeNil())
+
+	// Rendering something else in between should not change the values
+	templator.RenderTemplate(template, otherRequest, nil, nil, nil)
+
+	second, err := templator.RenderTemplate(template, request, nil, nil, templating.NewRandom(&seed, request))
+	Expect(err).To(BeNil())
+	Expect(second).To(Equal(first))
+
+	third, _ := templator.RenderTemplate(template, otherRequest, nil, nil, templating.NewRandom(&seed, otherRequest))
+	Expect(third).ToNot(Equal(first))
+
+	fourth, _ := templator.RenderTemplate(template, request, nil, nil, templating.NewRandom(&otherSeed, request))
+	Expect(fourth).ToNot(Equal(first))
+}
+
+func Test_RenderTemplate_WithTheSameRandom_GivesDifferentValuesToEachTemplate(t *testing.T) {
+	RegisterTestingT(t)
+
+	templator := templating.NewTemplator()
+	template, _ := templator.ParseTemplate(`{{ randomFullName }} {{ randomUuid }}`)
+
+	seed := int64(42)
+	request := &models.RequestDetails{Method: "GET", Destination: "test.com", Path: "/users/1"}
+
+	random := templating.NewRandom(&seed, request)
+	first, _ := templator.RenderTemplate(template, request, nil, nil, random)
+	second, _ := templator.RenderTemplate(template, request, nil, nil, random)
+	Expect(second).ToNot(Equal(first))
+}
+
+func Test_RenderTemplate_WithoutSeed_GivesDifferentValues(t *testing.T) {
+	RegisterTestingT(t)
+
+	templator := templating.NewTemplator()
+	template, _ := templator.ParseTemplate(`{{ randomFullName }} {{ randomUuid }}`)
+
+	request := &models.RequestDetails{Method: "GET", Destination: "test.com", Path: "/users/1"}
+
+	first, _ := templator.RenderTemplate(template, request, nil, nil, nil)
+	second, _ := templator.RenderTemplate(template, request, nil, nil, nil)
+	Expect(second).ToNot(Equal(first))
+}
+
 func ApplyTemplate(requestDetails *models.RequestDetails, state map[string]string, responseBody string) (string, error) {
 	templator := templating.NewTemplator()
 	template, _ := templator.ParseTemplate(responseBody)
 
-	return templator.RenderTemplate(template, requestDetails, state)
+	return templator.RenderTemplate(template, requestDetails, nil, state, nil)
 }
\ No newline at end of file
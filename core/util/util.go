@@ -3,7 +3,10 @@ package util
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -143,10 +146,57 @@ func GetContentTypeFromHeaders(headers map[string][]string) string {
 		if regexp.MustCompile("[/+]xml$").MatchString(v) {
 			return "xml"
 		}
+		if regexp.MustCompile("^application/x-www-form-urlencoded").MatchString(v) {
+			return "form"
+		}
+		if regexp.MustCompile("^multipart/form-data").MatchString(v) {
+			return "multipart"
+		}
 	}
 	return ""
 }
 
+// ParseMultipartForm returns the contents of each part of a multipart/form-data
+// body by form name. The boundary is read from the first delimiter line of the
+// body, so the Content-Type header is not needed.
+func ParseMultipartForm(body string) (map[string][]string, error) {
+	boundary := ""
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.HasPrefix(line, "--") && len(line) > 2 {
+			boundary = strings.TrimPrefix(line, "--")
+			break
+		}
+	}
+
+	if boundary == "" {
+		return nil, errors.New("multipart body has no boundary")
+	}
+
+	form := map[string][]string{}
+	reader := multipart.NewReader(strings.NewReader(body), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := ioutil.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+
+		if name := part.FormName(); name != "" {
+			form[name] = append(form[name], string(content))
+		}
+	}
+
+	return form, nil
+}
+
 func JSONMarshal(t interface{}) ([]byte, error) {
 	buffer := &bytes.Buffer{}
 	encoder := json.NewEncoder(buffer)
@@ -186,3 +236,30 @@ func CopyMap(originalMap map[string]string) map[string]string {
 	}
 	return newMap
 }
+
+var jsonPathIndex = regexp.MustCompile(`\[([0-9]+|\*)\]`)
+
+// ParseJsonPath splits a path such as $.items[0].id into the keys and indexes it is made of,
+// where indexes keep their brackets. It returns nothing when the path is not in that form.
+func ParseJsonPath(jsonPath string) []string {
+	jsonPath = strings.TrimPrefix(strings.TrimPrefix(jsonPath, "$"), ".")
+	if jsonPath == "" {
+		return nil
+	}
+
+	tokens := []string{}
+	for _, part := range strings.Split(jsonPath, ".") {
+		key := jsonPathIndex.ReplaceAllString(part, "")
+		if key == "" && part == "" {
+			return nil
+		}
+		if key != "" {
+			tokens = append(tokens, key)
+		}
+		for _, index := range jsonPathIndex.FindAllStringSubmatch(part, -1) {
+			tokens = append(tokens, "["+index[1]+"]")
+		}
+	}
+
+	return tokens
+}
@@ -140,6 +140,49 @@ func Test_GetContentTypeFromHeaders_ReturnsXmlIfXml(t *testing.T) {
 	})).To(Equal("xml"))
 }
 
+func Test_GetContentTypeFromHeaders_ReturnsFormIfFormUrlEncoded(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(GetContentTypeFromHeaders(map[string][]string{
+		"Content-Type": {"application/x-www-form-urlencoded; charset=utf-8"},
+	})).To(Equal("form"))
+}
+
+func Test_GetContentTypeFromHeaders_ReturnsMultipartIfMultipartFormData(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(GetContentTypeFromHeaders(map[string][]string{
+		"Content-Type": {"multipart/form-data; boundary=abc"},
+	})).To(Equal("multipart"))
+}
+
+func Test_ParseMultipartForm_ReturnsPartsByFormName(t *testing.T) {
+	RegisterTestingT(t)
+
+	form, err := ParseMultipartForm("--abc\r\n" +
+		"Content-Disposition: form-data; name=\"name\"\r\n\r\n" +
+		"Kevin\r\n" +
+		"--abc\r\n" +
+		"Content-Disposition: form-data; name=\"file\"; filename=\"test.txt\"\r\n" +
+		"Content-Type: text/plain\r\n\r\n" +
+		"file contents\r\n" +
+		"--abc--\r\n")
+
+	Expect(err).To(BeNil())
+	Expect(form).To(Equal(map[string][]string{
+		"name": {"Kevin"},
+		"file": {"file contents"},
+	}))
+}
+
+func Test_ParseMultipartForm_ReturnsErrorWithoutBoundary(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := ParseMultipartForm("name=Kevin")
+
+	Expect(err).ToNot(BeNil())
+}
+
 func Test_JSONMarshal_MarshalsIntoJson(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -210,3 +253,12 @@ func Test_CopyMap(t *testing.T) {
 	Expect(newMap["first"]).To(Equal("1"))
 	Expect(newMap["second"]).To(Equal("2"))
 }
+
+func Test_ParseJsonPath(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(ParseJsonPath("$.items[0].id")).To(Equal([]string{"items", "[0]", "id"}))
+	Expect(ParseJsonPath("$.matrix[1][*]")).To(Equal([]string{"matrix", "[1]", "[*]"}))
+	Expect(ParseJsonPath("$")).To(BeNil())
+	Expect(ParseJsonPath("$.items..id")).To(BeNil())
+}
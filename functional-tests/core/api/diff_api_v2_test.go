@@ -1,6 +1,7 @@
 package api_test
 
 import (
+	"encoding/xml"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -101,6 +102,134 @@ var _ = Describe("/api/v2/hoverfly/diff", func() {
 				Actual:   "[application/json]",
 			}))
 		})
+
+		It("Should apply diff rules to the response body", func() {
+			body := `{"id": "a1b2", "created": "2018-01-01T00:00:00Z", "total": 10, "tags": ["a", "b"], "status": "open"}`
+			fakeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(body))
+			}))
+
+			defer fakeServer.Close()
+
+			resp := hoverfly.Proxy(sling.New().Get(fakeServer.URL))
+			Expect(resp.StatusCode).To(Equal(200))
+
+			body = `{"id": "c3d4", "created": "2019-06-30T12:00:00Z", "total": 10.004, "tags": ["b", "a"], "status": "closed"}`
+			hoverfly.SetModeWithArgs("diff", v2.ModeArgumentsView{
+				Headers: []string{"*"},
+				DiffRules: []v2.DiffRuleView{
+					{Path: "$.created", Ignore: true},
+					{Path: "$.id", Regex: "^[a-z0-9]{4}$"},
+					{Path: "$.total", Tolerance: 0.01},
+					{Path: "$.tags", Unordered: true},
+				},
+			})
+
+			resp = hoverfly.Proxy(sling.New().Get(fakeServer.URL))
+			Expect(resp.StatusCode).To(Equal(200))
+
+			req := sling.New().Get("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/diff")
+			res := functional_tests.DoRequest(req)
+			Expect(res.StatusCode).To(Equal(200))
+
+			var diffs v2.DiffView
+			functional_tests.UnmarshalFromResponse(res, &diffs)
+
+			Expect(diffs.Diff).To(HaveLen(1))
+			Expect(diffs.Diff[0].DiffReport).To(HaveLen(1))
+			Expect(diffs.Diff[0].DiffReport[0].DiffEntries).To(ConsistOf(v2.DiffReportEntry{
+				Field:    "body/status",
+				Expected: "open",
+				Actual:   "closed",
+			}))
+		})
+
+		It("Should get the diff as a JUnit XML report", func() {
+			contentType := "text/plain"
+			fakeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", contentType)
+			}))
+
+			defer fakeServer.Close()
+
+			hoverfly.Proxy(sling.New().Get(fakeServer.URL + "/same"))
+			hoverfly.Proxy(sling.New().Get(fakeServer.URL + "/changed"))
+			contentType = "application/json"
+			hoverfly.SetMode("diff")
+
+			hoverfly.Proxy(sling.New().Get(fakeServer.URL + "/changed"))
+			contentType = "text/plain"
+			hoverfly.Proxy(sling.New().Get(fakeServer.URL + "/same"))
+
+			req := sling.New().Get("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/diff?format=junit")
+			res := functional_tests.DoRequest(req)
+			Expect(res.StatusCode).To(Equal(200))
+			Expect(res.Header.Get("Content-Type")).To(Equal("application/xml"))
+
+			var junitView v2.JUnitTestSuitesView
+			junitXml, err := ioutil.ReadAll(res.Body)
+			Expect(err).To(BeNil())
+			Expect(xml.Unmarshal(junitXml, &junitView)).To(Succeed())
+
+			host := strings.Replace(fakeServer.URL, "http://", "", 1)
+
+			Expect(junitView.Tests).To(Equal(2))
+			Expect(junitView.Failures).To(Equal(1))
+			Expect(junitView.TestSuites[0].TestCases).To(HaveLen(2))
+			Expect(junitView.TestSuites[0].TestCases[0].Name).To(Equal("GET " + host + "/changed"))
+			Expect(junitView.TestSuites[0].TestCases[0].Failure.Contents).To(ContainSubstring(
+				"header/Content-Type: expected [text/plain] but was [application/json]"))
+			Expect(junitView.TestSuites[0].TestCases[1].Name).To(Equal("GET " + host + "/same"))
+			Expect(junitView.TestSuites[0].TestCases[1].Failure).To(BeNil())
+		})
+	})
+
+	Context("GET /api/v2/diff/summary", func() {
+
+		It("Should count the responses which differed", func() {
+			contentType := "text/plain"
+			fakeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", contentType)
+			}))
+
+			defer fakeServer.Close()
+
+			hoverfly.Proxy(sling.New().Get(fakeServer.URL + "/same"))
+			hoverfly.Proxy(sling.New().Get(fakeServer.URL + "/changed"))
+			hoverfly.SetMode("diff")
+
+			hoverfly.Proxy(sling.New().Get(fakeServer.URL + "/same"))
+			contentType = "application/json"
+			hoverfly.Proxy(sling.New().Get(fakeServer.URL + "/changed"))
+			hoverfly.Proxy(sling.New().Get(fakeServer.URL + "/changed"))
+
+			req := sling.New().Get("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/diff/summary")
+			res := functional_tests.DoRequest(req)
+			Expect(res.StatusCode).To(Equal(200))
+
+			var summary v2.DiffSummaryView
+			functional_tests.UnmarshalFromResponse(res, &summary)
+
+			Expect(summary).To(Equal(v2.DiffSummaryView{
+				Requests:        2,
+				FailedRequests:  1,
+				Responses:       3,
+				FailedResponses: 2,
+				Differences:     2,
+				Passed:          false,
+			}))
+		})
+
+		It("Should pass when there are no diffs", func() {
+			req := sling.New().Get("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/diff/summary")
+			res := functional_tests.DoRequest(req)
+			Expect(res.StatusCode).To(Equal(200))
+
+			summaryJson, err := ioutil.ReadAll(res.Body)
+			Expect(err).To(BeNil())
+			Expect(summaryJson).To(MatchJSON(`{"requests":0,"failedRequests":0,"responses":0,"failedResponses":0,"differences":0,"passed":true}`))
+		})
 	})
 
 	Context("DELETE", func() {
@@ -102,6 +102,31 @@ var _ = Describe("/api/v2/journal", func() {
 				Expect(*journalView.Journal[2].Request.Path).To(Equal("/"))
 			})
 
+			It("should export the journal as a HAR", func() {
+				hoverfly.Proxy(sling.New().Get("http://hoverfly.io/path?query=one"))
+				hoverfly.Proxy(sling.New().Get("http://hoverfly.io/path?query=two"))
+
+				req := sling.New().Get("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/journal?format=har")
+				res := functional_tests.DoRequest(req)
+
+				Expect(res.StatusCode).To(Equal(200))
+
+				var harView v2.HarView
+
+				functional_tests.UnmarshalFromResponse(res, &harView)
+
+				Expect(harView.Log.Version).To(Equal("1.2"))
+				Expect(harView.Log.Creator.Name).To(Equal("Hoverfly"))
+				Expect(harView.Log.Entries).To(HaveLen(2))
+
+				Expect(harView.Log.Entries[0].Request.Method).To(Equal("GET"))
+				Expect(harView.Log.Entries[0].Request.URL).To(Equal("http://hoverfly.io/path?query=one"))
+				Expect(harView.Log.Entries[0].Request.QueryString).To(Equal([]v2.HarNameValueView{{Name: "query", Value: "one"}}))
+				Expect(harView.Log.Entries[0].Response.Status).To(Equal(502))
+				Expect(harView.Log.Entries[0].Response.Content.MimeType).To(Equal("text/plain"))
+				Expect(harView.Log.Entries[1].Request.URL).To(Equal("http://hoverfly.io/path?query=two"))
+			})
+
 			It("should display the mode each request was in", func() {
 				hoverfly.SetMode("simulate")
 				hoverfly.Proxy(sling.New().Get("http://localhost:" + hoverfly.GetAdminPort()))
@@ -185,6 +210,90 @@ var _ = Describe("/api/v2/journal", func() {
 			})
 		})
 
+		Context("POST /verify", func() {
+
+			BeforeEach(func() {
+				hoverfly.Proxy(sling.New().Get("http://localhost:" + hoverfly.GetAdminPort() + "/first"))
+				hoverfly.Proxy(sling.New().Get("http://localhost:" + hoverfly.GetAdminPort() + "/first"))
+				hoverfly.Proxy(sling.New().Get("http://localhost:" + hoverfly.GetAdminPort() + "/second"))
+			})
+
+			It("should pass when the count is met", func() {
+				req := sling.New().Post("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/journal/verify")
+				req.Body(bytes.NewBufferString(`{
+					"request": {
+						"path": [
+							{
+								"matcher": "exact",
+								"value": "/first"
+							}
+						]
+					},
+					"count": {
+						"exactly": 2
+					}
+				}`))
+				res := functional_tests.DoRequest(req)
+
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+				var resultView v2.JournalVerifyResultView
+
+				functional_tests.UnmarshalFromResponse(res, &resultView)
+
+				Expect(resultView.Passed).To(BeTrue())
+				Expect(resultView.Count).To(Equal(2))
+				Expect(resultView.Matches).To(HaveLen(2))
+			})
+
+			It("should fail with the closest entries when the count is not met", func() {
+				req := sling.New().Post("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/journal/verify")
+				req.Body(bytes.NewBufferString(`{
+					"request": {
+						"method": [
+							{
+								"matcher": "exact",
+								"value": "POST"
+							}
+						],
+						"path": [
+							{
+								"matcher": "exact",
+								"value": "/second"
+							}
+						]
+					}
+				}`))
+				res := functional_tests.DoRequest(req)
+
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+				var resultView v2.JournalVerifyResultView
+
+				functional_tests.UnmarshalFromResponse(res, &resultView)
+
+				Expect(resultView.Passed).To(BeFalse())
+				Expect(resultView.Message).To(Equal("Expected request to be made at least 1 time, but it was made 0 times"))
+				Expect(resultView.ClosestEntries).To(HaveLen(1))
+				Expect(*resultView.ClosestEntries[0].Entry.Request.Path).To(Equal("/second"))
+				Expect(resultView.ClosestEntries[0].MissedFields).To(Equal([]string{"method"}))
+			})
+
+			It("should error when the count is invalid", func() {
+				req := sling.New().Post("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/journal/verify")
+				req.Body(bytes.NewBufferString(`{"request": {}, "count": {"exactly": -1}}`))
+				res := functional_tests.DoRequest(req)
+
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+
+				var errorView handlers.ErrorView
+
+				functional_tests.UnmarshalFromResponse(res, &errorView)
+
+				Expect(errorView.Error).To(Equal("count exactly must not be negative"))
+			})
+		})
+
 		Context("DELETE", func() {
 			It("should delete journal entries", func() {
 				hoverfly.Proxy(sling.New().Get("http://localhost:" + hoverfly.GetAdminPort()))
@@ -1,15 +1,26 @@
 package api_test
 
 import (
+	"context"
 	"io/ioutil"
+	"net"
 	"strings"
 
+	"github.com/SpectoLabs/hoverfly/core/middleware"
 	"github.com/SpectoLabs/hoverfly/functional-tests"
 	"github.com/dghubble/sling"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
 )
 
+// echoGrpcMiddleware returns the pair it was sent unchanged
+type echoGrpcMiddleware struct{}
+
+func (echoGrpcMiddleware) Process(ctx context.Context, pair *middleware.RequestResponsePair) (*middleware.RequestResponsePair, error) {
+	return pair, nil
+}
+
 var _ = Describe("/api/v2/hoverfly/middleware", func() {
 
 	var (
@@ -54,5 +65,61 @@ var _ = Describe("/api/v2/hoverfly/middleware", func() {
 			Expect(err).To(BeNil())
 			Expect(modeJson).To(Equal([]byte(`{"binary":"ruby","script":"#!/usr/bin/env ruby\n# encoding: utf-8\nwhile payload = STDIN.gets\nnext unless payload\n\nSTDOUT.puts payload\nend","remote":""}`)))
 		})
+
+		It("Should put gRPC middleware", func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).To(BeNil())
+
+			grpcServer := grpc.NewServer()
+			middleware.RegisterMiddlewareServer(grpcServer, echoGrpcMiddleware{})
+			go grpcServer.Serve(listener)
+			defer grpcServer.Stop()
+
+			grpcAddress := "grpc://" + listener.Addr().String()
+
+			req := sling.New().Put("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/hoverfly/middleware")
+			req.Body(strings.NewReader(`{"grpc":"` + grpcAddress + `", "timeout": 1000}`))
+			res := functional_tests.DoRequest(req)
+			Expect(res.StatusCode).To(Equal(200))
+			middlewareJson, err := ioutil.ReadAll(res.Body)
+			Expect(err).To(BeNil())
+			Expect(middlewareJson).To(Equal([]byte(`{"binary":"","script":"","remote":"","grpc":"` + grpcAddress + `","timeout":1000}`)))
+		})
+
+		It("Should put JavaScript middleware", func() {
+			req := sling.New().Put("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/hoverfly/middleware")
+			req.Body(strings.NewReader(`{"javascript":"pair.response.status = 201;", "timeout": 500}`))
+			res := functional_tests.DoRequest(req)
+			Expect(res.StatusCode).To(Equal(200))
+			middlewareJson, err := ioutil.ReadAll(res.Body)
+			Expect(err).To(BeNil())
+			Expect(middlewareJson).To(Equal([]byte(`{"binary":"","script":"","remote":"","javascript":"pair.response.status = 201;","timeout":500}`)))
+		})
+
+		It("Should not put JavaScript middleware with a syntax error", func() {
+			req := sling.New().Put("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/hoverfly/middleware")
+			req.Body(strings.NewReader(`{"javascript":"pair.response.status = "}`))
+			res := functional_tests.DoRequest(req)
+			Expect(res.StatusCode).To(Equal(422))
+			errorJson, err := ioutil.ReadAll(res.Body)
+			Expect(err).To(BeNil())
+			Expect(string(errorJson)).To(ContainSubstring("JavaScript middleware is not valid"))
+		})
+
+		It("Should not put gRPC middleware that cannot be reached", func() {
+			req := sling.New().Put("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/hoverfly/middleware")
+			req.Body(strings.NewReader(`{"grpc":"grpc://localhost:4321", "timeout": 1000}`))
+			res := functional_tests.DoRequest(req)
+			Expect(res.StatusCode).To(Equal(422))
+			errorJson, err := ioutil.ReadAll(res.Body)
+			Expect(err).To(BeNil())
+			Expect(string(errorJson)).To(ContainSubstring("gRPC middleware health check failed"))
+
+			req = sling.New().Get("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/hoverfly/middleware")
+			res = functional_tests.DoRequest(req)
+			middlewareJson, err := ioutil.ReadAll(res.Body)
+			Expect(err).To(BeNil())
+			Expect(middlewareJson).To(Equal([]byte(`{"binary":"","script":"","remote":""}`)))
+		})
 	})
 })
@@ -0,0 +1,101 @@
+package api_test
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/functional-tests"
+	"github.com/dghubble/sling"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("/api/v2/hoverfly/middleware/chain", func() {
+
+	var (
+		hoverfly *functional_tests.Hoverfly
+	)
+
+	BeforeEach(func() {
+		hoverfly = functional_tests.NewHoverfly()
+		hoverfly.Start()
+	})
+
+	AfterEach(func() {
+		hoverfly.Stop()
+	})
+
+	Context("GET", func() {
+
+		It("Should get the middleware chain which should be empty", func() {
+			req := sling.New().Get("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/hoverfly/middleware/chain")
+			res := functional_tests.DoRequest(req)
+			Expect(res.StatusCode).To(Equal(200))
+			chainJson, err := ioutil.ReadAll(res.Body)
+			Expect(err).To(BeNil())
+			Expect(chainJson).To(Equal([]byte(`{"stages":[]}`)))
+		})
+	})
+
+	Context("PUT", func() {
+
+		It("Should put the middleware chain", func() {
+			req := sling.New().Put("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/hoverfly/middleware/chain")
+			req.Body(strings.NewReader(`{"stages":[{"phase":"on-miss","modes":["simulate"],"destination":"test\\.com","javascript":"pair.response.status = 404;"}]}`))
+			res := functional_tests.DoRequest(req)
+			Expect(res.StatusCode).To(Equal(200))
+			chainJson, err := ioutil.ReadAll(res.Body)
+			Expect(err).To(BeNil())
+			Expect(chainJson).To(Equal([]byte(`{"stages":[{"phase":"on-miss","modes":["simulate"],"destination":"test\\.com","binary":"","script":"","remote":"","javascript":"pair.response.status = 404;"}]}`)))
+
+			req = sling.New().Get("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/hoverfly/middleware/chain")
+			res = functional_tests.DoRequest(req)
+			chainJson, err = ioutil.ReadAll(res.Body)
+			Expect(err).To(BeNil())
+			Expect(chainJson).To(Equal([]byte(`{"stages":[{"phase":"on-miss","modes":["simulate"],"destination":"test\\.com","binary":"","script":"","remote":"","javascript":"pair.response.status = 404;"}]}`)))
+		})
+
+		It("Should not put a middleware chain with a stage that fails", func() {
+			req := sling.New().Put("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/hoverfly/middleware/chain")
+			req.Body(strings.NewReader(`{"stages":[{"phase":"pre-request","javascript":"pair.request.path = '/';"},{"phase":"post-response","javascript":"pair.nothing.status = 404;"}]}`))
+			res := functional_tests.DoRequest(req)
+			Expect(res.StatusCode).To(Equal(422))
+			errorJson, err := ioutil.ReadAll(res.Body)
+			Expect(err).To(BeNil())
+			Expect(string(errorJson)).To(ContainSubstring(`JavaScript middleware failed\nStage: 2 (post-response)`))
+
+			req = sling.New().Get("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/hoverfly/middleware/chain")
+			res = functional_tests.DoRequest(req)
+			chainJson, err := ioutil.ReadAll(res.Body)
+			Expect(err).To(BeNil())
+			Expect(chainJson).To(Equal([]byte(`{"stages":[]}`)))
+		})
+
+		It("Should not put a middleware chain with a stage in an unknown phase", func() {
+			req := sling.New().Put("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/hoverfly/middleware/chain")
+			req.Body(strings.NewReader(`{"stages":[{"phase":"after-response","javascript":"pair.response.status = 404;"}]}`))
+			res := functional_tests.DoRequest(req)
+			Expect(res.StatusCode).To(Equal(422))
+			errorJson, err := ioutil.ReadAll(res.Body)
+			Expect(err).To(BeNil())
+			Expect(string(errorJson)).To(ContainSubstring("Stage 1: Middleware phase must be one of [pre-request post-response on-miss], not after-response"))
+		})
+	})
+
+	Context("DELETE", func() {
+
+		It("Should delete the middleware chain", func() {
+			req := sling.New().Put("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/hoverfly/middleware/chain")
+			req.Body(strings.NewReader(`{"stages":[{"phase":"on-miss","javascript":"pair.response.status = 404;"}]}`))
+			res := functional_tests.DoRequest(req)
+			Expect(res.StatusCode).To(Equal(200))
+
+			req = sling.New().Delete("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/hoverfly/middleware/chain")
+			res = functional_tests.DoRequest(req)
+			Expect(res.StatusCode).To(Equal(200))
+			chainJson, err := ioutil.ReadAll(res.Body)
+			Expect(err).To(BeNil())
+			Expect(chainJson).To(Equal([]byte(`{"stages":[]}`)))
+		})
+	})
+})
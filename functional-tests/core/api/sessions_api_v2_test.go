@@ -0,0 +1,84 @@
+package api_test
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/functional-tests"
+	"github.com/SpectoLabs/hoverfly/functional-tests/testdata"
+	"github.com/dghubble/sling"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Working with sessions via the API", func() {
+
+	var (
+		hoverfly *functional_tests.Hoverfly
+	)
+
+	BeforeEach(func() {
+		hoverfly = functional_tests.NewHoverfly()
+		hoverfly.Start()
+		hoverfly.SetMode("simulate")
+	})
+
+	AfterEach(func() {
+		hoverfly.Stop()
+	})
+
+	It("Should keep the simulation, state and journal of a session apart", func() {
+		sessionsURL := "http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/sessions"
+
+		res := functional_tests.DoRequest(sling.New().Post(sessionsURL).BodyJSON(v2.SessionView{Name: "team-a"}))
+		Expect(res.StatusCode).To(Equal(200))
+		Expect(ioutil.ReadAll(res.Body)).To(MatchJSON(`{"sessions": ["team-a"]}`))
+
+		res = functional_tests.DoRequest(sling.New().Put(sessionsURL + "/team-a/simulation").Body(bytes.NewBufferString(testdata.ScenarioState)))
+		Expect(res.StatusCode).To(Equal(200))
+
+		res = hoverfly.Proxy(sling.New().Get("http://test.com/add-eggs").Set("X-Hoverfly-Session", "team-a"))
+		Expect(res.StatusCode).To(Equal(200))
+		Expect(ioutil.ReadAll(res.Body)).To(Equal([]byte("added eggs")))
+
+		res = hoverfly.Proxy(sling.New().Get("http://test.com/basket").Set("X-Hoverfly-Session", "team-a"))
+		Expect(res.StatusCode).To(Equal(200))
+		Expect(ioutil.ReadAll(res.Body)).To(Equal([]byte("eggs")))
+
+		res = hoverfly.Proxy(sling.New().Get("http://test.com/basket"))
+		Expect(res.StatusCode).To(Equal(502))
+
+		res = functional_tests.DoRequest(sling.New().Get(sessionsURL + "/team-a/scenarios/checkout"))
+		Expect(res.StatusCode).To(Equal(200))
+		Expect(ioutil.ReadAll(res.Body)).To(MatchJSON(`{"state": {"eggs": "present"}}`))
+
+		res = functional_tests.DoRequest(sling.New().Get("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/scenarios/checkout"))
+		Expect(ioutil.ReadAll(res.Body)).To(MatchJSON(`{"state": {}}`))
+
+		journalView := v2.JournalView{}
+		functional_tests.UnmarshalFromResponse(functional_tests.DoRequest(sling.New().Get(sessionsURL+"/team-a/journal")), &journalView)
+		Expect(journalView.Journal).To(HaveLen(2))
+
+		journalView = v2.JournalView{}
+		functional_tests.UnmarshalFromResponse(functional_tests.DoRequest(sling.New().Get("http://localhost:"+hoverfly.GetAdminPort()+"/api/v2/journal")), &journalView)
+		Expect(journalView.Journal).To(HaveLen(1))
+	})
+
+	It("Should delete a session", func() {
+		sessionsURL := "http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/sessions"
+
+		functional_tests.DoRequest(sling.New().Post(sessionsURL).BodyJSON(v2.SessionView{Name: "team-a"}))
+
+		res := functional_tests.DoRequest(sling.New().Delete(sessionsURL + "/team-a"))
+		Expect(res.StatusCode).To(Equal(200))
+		Expect(ioutil.ReadAll(res.Body)).To(MatchJSON(`{"sessions": []}`))
+
+		res = hoverfly.Proxy(sling.New().Get("http://test.com/basket").Set("X-Hoverfly-Session", "team-a"))
+		Expect(res.StatusCode).To(Equal(502))
+		Expect(ioutil.ReadAll(res.Body)).To(ContainSubstring("Session team-a does not exist"))
+
+		res = functional_tests.DoRequest(sling.New().Get(sessionsURL + "/team-a/state"))
+		Expect(res.StatusCode).To(Equal(404))
+	})
+})
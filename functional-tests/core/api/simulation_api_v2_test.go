@@ -46,7 +46,7 @@ var _ = Describe("/api/v2/simulation", func() {
 			Expect(err).To(BeNil())
 			schemaVersion, err := metaObject.GetString("schemaVersion")
 			Expect(err).To(BeNil())
-			Expect(schemaVersion).To(Equal("v5"))
+			Expect(schemaVersion).To(Equal("v5.1"))
 			hoverflyVersion, err := metaObject.GetString("hoverflyVersion")
 			Expect(err).To(BeNil())
 			Expect(hoverflyVersion).ToNot(BeNil())
@@ -173,7 +173,7 @@ var _ = Describe("/api/v2/simulation", func() {
 			Expect(err).To(BeNil())
 			schemaVersion, err := metaObject.GetString("schemaVersion")
 			Expect(err).To(BeNil())
-			Expect(schemaVersion).To(Equal("v5"))
+			Expect(schemaVersion).To(Equal("v5.1"))
 			hoverflyVersion, err := metaObject.GetString("hoverflyVersion")
 			Expect(err).To(BeNil())
 			Expect(hoverflyVersion).ToNot(BeNil())
@@ -406,6 +406,40 @@ var _ = Describe("/api/v2/simulation", func() {
 			Expect(string(responseBody)).To(Equal(`{"error":"Invalid simulation: schema version r3 is not supported by this version of Hoverfly, you may need to update Hoverfly"}`))
 		})
 
+		It("should import a HAR using a PUT", func() {
+			request := sling.New().Put("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/simulation")
+			payload := bytes.NewBufferString(`{
+				"log": {
+					"version": "1.2",
+					"creator": {"name": "test", "version": "1"},
+					"entries": [{
+						"request": {
+							"method": "GET",
+							"url": "http://test-server.com/path?query=value",
+							"headers": []
+						},
+						"response": {
+							"status": 200,
+							"headers": [{"name": "Content-Type", "value": "text/plain"}, {"name": "Content-Encoding", "value": "gzip"}],
+							"content": {"size": 9, "mimeType": "text/plain", "text": "from har"}
+						}
+					}]
+				}
+			}`)
+
+			request.Body(payload)
+			response := functional_tests.DoRequest(request)
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			proxyResponse := hoverfly.Proxy(sling.New().Get("http://test-server.com/path?query=value"))
+			Expect(proxyResponse.StatusCode).To(Equal(http.StatusOK))
+			Expect(ioutil.ReadAll(proxyResponse.Body)).To(Equal([]byte("from har")))
+			Expect(proxyResponse.Header.Get("Content-Encoding")).To(BeEmpty())
+
+			proxyResponse = hoverfly.Proxy(sling.New().Get("http://test-server.com/path?query=other"))
+			Expect(proxyResponse.StatusCode).To(Equal(http.StatusBadGateway))
+		})
+
 		It("should warn when importing deprecatedQuery", func() {
 			request := sling.New().Put("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/simulation")
 			payload := bytes.NewBufferString(testdata.V1JsonPayload)
@@ -693,4 +727,62 @@ var _ = Describe("/api/v2/simulation", func() {
 			Expect(encodedBody).To(BeFalse())
 		})
 	})
+
+	Context("POST /api/v2/simulation/compact", func() {
+
+		BeforeEach(func() {
+			hoverfly.ImportSimulation(`{
+				"data": {
+					"pairs": [{
+						"request": {
+							"path": [{"matcher": "exact", "value": "/orders/1"}],
+							"method": [{"matcher": "exact", "value": "GET"}]
+						},
+						"response": {"status": 200, "body": "order 1"}
+					}, {
+						"request": {
+							"path": [{"matcher": "exact", "value": "/orders/2"}],
+							"method": [{"matcher": "exact", "value": "GET"}]
+						},
+						"response": {"status": 200, "body": "order 2"}
+					}]
+				},
+				"meta": {
+					"schemaVersion": "v5"
+				}
+			}`)
+		})
+
+		It("Should return the pairs which would be merged on a dry run", func() {
+			req := sling.New().Post("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/simulation/compact?dryRun=true")
+			res := functional_tests.DoRequest(req)
+			Expect(res.StatusCode).To(Equal(200))
+
+			compactJson, err := ioutil.ReadAll(res.Body)
+			Expect(err).To(BeNil())
+			Expect(compactJson).To(ContainSubstring(`"dryRun":true,"pairsBefore":2,"pairsAfter":1`))
+			Expect(compactJson).To(ContainSubstring(`{"matcher":"glob","value":"/orders/*"}`))
+
+			Expect(hoverfly.ExportSimulation().RequestResponsePairs).To(HaveLen(2))
+		})
+
+		It("Should merge the pairs", func() {
+			req := sling.New().Post("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/simulation/compact")
+			res := functional_tests.DoRequest(req)
+			Expect(res.StatusCode).To(Equal(200))
+
+			pairs := hoverfly.ExportSimulation().RequestResponsePairs
+			Expect(pairs).To(HaveLen(1))
+			Expect(pairs[0].RequestMatcher.Path[0].Value).To(Equal("/orders/*"))
+			Expect(pairs[0].Response.Templated).To(BeTrue())
+
+			hoverfly.SetMode("simulate")
+			response := hoverfly.Proxy(sling.New().Get("http://destination.com/orders/3"))
+			Expect(response.StatusCode).To(Equal(200))
+
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).To(BeNil())
+			Expect(string(body)).To(Equal("order 3"))
+		})
+	})
 })
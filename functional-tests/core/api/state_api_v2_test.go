@@ -2,9 +2,11 @@ package api_test
 
 import (
 	"io/ioutil"
+	"strings"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/functional-tests"
+	"github.com/SpectoLabs/hoverfly/functional-tests/testdata"
 	"github.com/dghubble/sling"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -69,4 +71,69 @@ var _ = Describe("Working with the current state of Hoverfly via the API", func(
 		Expect(res.StatusCode).To(Equal(200))
 		Expect(ioutil.ReadAll(res.Body)).To(MatchJSON(`{"state":{}}`))
 	})
+
+	It("Should be able to get, put and reset a scenario", func() {
+
+		hoverfly.Start()
+
+		scenarioURL := "http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/scenarios/checkout"
+
+		// GET AN UNUSED SCENARIO
+		req := sling.New().Get(scenarioURL)
+		res := functional_tests.DoRequest(req)
+		Expect(res.StatusCode).To(Equal(200))
+		Expect(ioutil.ReadAll(res.Body)).To(MatchJSON(`{"state":{}}`))
+
+		// PUT
+		req = sling.New().Put(scenarioURL).BodyJSON(v2.ScenarioView{
+			State:  map[string]string{"basket": "empty"},
+			States: []string{"empty", "full"},
+		})
+		res = functional_tests.DoRequest(req)
+		Expect(res.StatusCode).To(Equal(200))
+		Expect(ioutil.ReadAll(res.Body)).To(MatchJSON(`{"state":{"basket":"empty"},"states":["empty","full"]}`))
+
+		// PUT A STATE WHICH HAS NOT BEEN DECLARED
+		req = sling.New().Put(scenarioURL).BodyJSON(v2.ScenarioView{
+			State:  map[string]string{"basket": "overflowing"},
+			States: []string{"empty", "full"},
+		})
+		res = functional_tests.DoRequest(req)
+		Expect(res.StatusCode).To(Equal(400))
+		Expect(ioutil.ReadAll(res.Body)).To(MatchJSON(`{"error":"overflowing is not one of the states declared for basket"}`))
+
+		// THE STATE INCLUDES THE SCENARIO UNDER ITS OWN KEYS
+		req = sling.New().Get("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/state")
+		res = functional_tests.DoRequest(req)
+		Expect(res.StatusCode).To(Equal(200))
+		Expect(ioutil.ReadAll(res.Body)).To(MatchJSON(`{"state":{"scenario:checkout:basket":"empty"}}`))
+
+		// RESET
+		req = sling.New().Put(scenarioURL).Body(strings.NewReader(`{}`))
+		res = functional_tests.DoRequest(req)
+		Expect(res.StatusCode).To(Equal(200))
+		Expect(ioutil.ReadAll(res.Body)).To(MatchJSON(`{"state":{}}`))
+	})
+
+	It("Should match and transition the state of a scenario", func() {
+
+		hoverfly.Start()
+		hoverfly.SetMode("simulate")
+		hoverfly.ImportSimulation(testdata.ScenarioState)
+
+		scenarioURL := "http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/scenarios/checkout"
+
+		resp := hoverfly.Proxy(sling.New().Get("http://test-server.com/basket"))
+		Expect(ioutil.ReadAll(resp.Body)).To(Equal([]byte(`empty`)))
+
+		resp = hoverfly.Proxy(sling.New().Get("http://test-server.com/add-eggs"))
+		Expect(ioutil.ReadAll(resp.Body)).To(Equal([]byte(`added eggs`)))
+
+		req := sling.New().Get(scenarioURL)
+		res := functional_tests.DoRequest(req)
+		Expect(ioutil.ReadAll(res.Body)).To(MatchJSON(`{"state":{"eggs":"present"}}`))
+
+		resp = hoverfly.Proxy(sling.New().Get("http://test-server.com/basket"))
+		Expect(ioutil.ReadAll(resp.Body)).To(Equal([]byte(`eggs`)))
+	})
 })
@@ -0,0 +1,98 @@
+package api_test
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/functional-tests"
+	"github.com/dghubble/sling"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const seededTemplatingSimulation = `{
+	"data": {
+		"pairs": [{
+			"request": {
+				"path": [{"matcher": "exact", "value": "/users"}]
+			},
+			"response": {
+				"status": 200,
+				"body": "{{ randomFullName }} {{ randomIban }} {{ randomUuid }}",
+				"templated": true
+			}
+		}]
+	},
+	"meta": {
+		"schemaVersion": "v5.1"
+	}
+}`
+
+var _ = Describe("/api/v2/hoverfly/templating", func() {
+
+	var (
+		hoverfly *functional_tests.Hoverfly
+	)
+
+	BeforeEach(func() {
+		hoverfly = functional_tests.NewHoverfly()
+		hoverfly.Start()
+	})
+
+	AfterEach(func() {
+		hoverfly.Stop()
+	})
+
+	Context("GET", func() {
+
+		It("Should get no seed by default", func() {
+			req := sling.New().Get("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/hoverfly/templating")
+
+			res := functional_tests.DoRequest(req)
+			Expect(res.StatusCode).To(Equal(200))
+			templatingJson, err := ioutil.ReadAll(res.Body)
+			Expect(err).To(BeNil())
+			Expect(templatingJson).To(Equal([]byte(`{"seed":null}`)))
+		})
+	})
+
+	Context("PUT", func() {
+
+		It("Should put the seed", func() {
+			req := sling.New().Put("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/hoverfly/templating")
+			req.Body(strings.NewReader(`{"seed":42}`))
+
+			res := functional_tests.DoRequest(req)
+			Expect(res.StatusCode).To(Equal(200))
+			templatingJson, err := ioutil.ReadAll(res.Body)
+			Expect(err).To(BeNil())
+			Expect(templatingJson).To(Equal([]byte(`{"seed":42}`)))
+		})
+
+		It("Should give the same random values for the same request across runs", func() {
+			getSeededBody := func(hoverfly *functional_tests.Hoverfly) string {
+				req := sling.New().Put("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/hoverfly/templating")
+				req.Body(strings.NewReader(`{"seed":42}`))
+				Expect(functional_tests.DoRequest(req).StatusCode).To(Equal(200))
+
+				hoverfly.ImportSimulation(seededTemplatingSimulation)
+				hoverfly.SetMode("simulate")
+
+				res := hoverfly.Proxy(sling.New().Get("http://test-server.com/users"))
+				Expect(res.StatusCode).To(Equal(200))
+				body, err := ioutil.ReadAll(res.Body)
+				Expect(err).To(BeNil())
+				return string(body)
+			}
+
+			first := getSeededBody(hoverfly)
+			Expect(getSeededBody(hoverfly)).To(Equal(first))
+
+			otherHoverfly := functional_tests.NewHoverfly()
+			otherHoverfly.Start()
+			defer otherHoverfly.Stop()
+
+			Expect(getSeededBody(otherHoverfly)).To(Equal(first))
+		})
+	})
+})
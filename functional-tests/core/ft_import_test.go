@@ -5,9 +5,12 @@ import (
 	v2 "github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/functional-tests"
 	"github.com/SpectoLabs/hoverfly/functional-tests/testdata"
+	"github.com/dghubble/sling"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"io/ioutil"
+	"os"
+	"path/filepath"
 )
 
 var _ = Describe("When I run Hoverfly", func() {
@@ -43,4 +46,54 @@ var _ = Describe("When I run Hoverfly", func() {
 			Expect(payload.RequestResponsePairs).To(HaveLen(2))
 		})
 	})
+
+	Context("with -import-watch", func() {
+
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "hoverfly-import-watch")
+			Expect(err).To(BeNil())
+
+			Expect(ioutil.WriteFile(filepath.Join(dir, "scenario.json"), []byte(testdata.ScenarioState), 0644)).To(Succeed())
+
+			hoverfly.Start("-import-watch", dir)
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		getPairs := func() []v2.RequestMatcherResponsePairViewV5 {
+			return hoverfly.ExportSimulation().RequestResponsePairs
+		}
+
+		It("should import the simulations in the directory", func() {
+			Expect(getPairs()).To(HaveLen(3))
+
+			resp := hoverfly.Proxy(sling.New().Get("http://test.com/basket"))
+			Expect(ioutil.ReadAll(resp.Body)).To(Equal([]byte("empty")))
+		})
+
+		It("should reload the simulations when a file is added", func() {
+			Expect(ioutil.WriteFile(filepath.Join(dir, "sequence.json"), []byte(testdata.ResponsesSequence), 0644)).To(Succeed())
+
+			Eventually(func() int {
+				return len(getPairs())
+			}).Should(BeNumerically(">", 3))
+		})
+
+		It("should keep the previous simulation and log an error when a file is invalid", func() {
+			Expect(ioutil.WriteFile(filepath.Join(dir, "invalid.json"), []byte("{"), 0644)).To(Succeed())
+
+			Eventually(func() string {
+				resp := functional_tests.DoRequest(sling.New().Get("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/logs"))
+				body, _ := ioutil.ReadAll(resp.Body)
+				return string(body)
+			}).Should(ContainSubstring("Failed to reload simulations, the previous simulation has been kept"))
+
+			Expect(getPairs()).To(HaveLen(3))
+		})
+	})
 })
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/functional-tests"
@@ -72,3 +73,76 @@ var _ = Describe("Running Hoverfly with middleware", func() {
 		})
 	})
 })
+
+var _ = Describe("Running Hoverfly with JavaScript middleware", func() {
+
+	var (
+		hoverfly *functional_tests.Hoverfly
+	)
+
+	BeforeEach(func() {
+		hoverfly = functional_tests.NewHoverfly()
+		hoverfly.Start("-middleware", "javascript:testdata/middleware.js")
+		hoverfly.ImportSimulation(testdata.JsonGetAndPost)
+		hoverfly.SetMode("simulate")
+	})
+
+	AfterEach(func() {
+		hoverfly.Stop()
+	})
+
+	It("should run the script against the response with access to the state", func() {
+		resp := hoverfly.Proxy(sling.New().Get("http://destination1/path1"))
+		Expect(ioutil.ReadAll(resp.Body)).To(Equal([]byte("CHANGED_RESPONSE_BODY 1")))
+		Expect(resp.Header.Get("X-Middleware")).To(Equal("javascript"))
+
+		resp = hoverfly.Proxy(sling.New().Get("http://destination1/path1"))
+		Expect(ioutil.ReadAll(resp.Body)).To(Equal([]byte("CHANGED_RESPONSE_BODY 2")))
+	})
+})
+
+var _ = Describe("Running Hoverfly with a middleware chain", func() {
+
+	var (
+		hoverfly *functional_tests.Hoverfly
+	)
+
+	BeforeEach(func() {
+		hoverfly = functional_tests.NewHoverfly()
+		hoverfly.Start()
+		hoverfly.ImportSimulation(testdata.JsonGetAndPost)
+		hoverfly.SetMode("simulate")
+
+		req := sling.New().Put("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/hoverfly/middleware/chain")
+		req.Body(strings.NewReader(`{"stages": [
+			{"phase": "post-response", "javascript": "pair.response.body += ' first';"},
+			{"phase": "post-response", "modes": ["spy"], "javascript": "pair.response.body += ' spy';"},
+			{"phase": "post-response", "javascript": "pair.response.body += ' second';"},
+			{"phase": "on-miss", "destination": "^missing$", "javascript": "pair.response.status = 404; pair.response.body = 'not simulated';"}
+		]}`))
+		res := functional_tests.DoRequest(req)
+		Expect(res.StatusCode).To(Equal(200))
+	})
+
+	AfterEach(func() {
+		hoverfly.Stop()
+	})
+
+	It("should pass the response through the stages which apply to the mode in order", func() {
+		resp := hoverfly.Proxy(sling.New().Get("http://destination1/path1"))
+		Expect(resp.StatusCode).To(Equal(201))
+		Expect(ioutil.ReadAll(resp.Body)).To(Equal([]byte("body1 first second")))
+	})
+
+	It("should respond with the on-miss middleware when the request does not match", func() {
+		resp := hoverfly.Proxy(sling.New().Get("http://missing/path1"))
+		Expect(resp.StatusCode).To(Equal(404))
+		Expect(ioutil.ReadAll(resp.Body)).To(Equal([]byte("not simulated")))
+	})
+
+	It("should return the matching error when the on-miss middleware does not apply to the destination", func() {
+		resp := hoverfly.Proxy(sling.New().Get("http://other/path1"))
+		Expect(resp.StatusCode).To(Equal(502))
+		Expect(ioutil.ReadAll(resp.Body)).To(ContainSubstring("There was an error when matching"))
+	})
+})
@@ -132,6 +132,85 @@ var _ = Describe("When I run Hoverfly in simulate mode", func() {
 		resp = hoverfly.Proxy(sling.New().Get("http://test-server.com/basket"))
 		Expect(ioutil.ReadAll(resp.Body)).To(Equal([]byte(`empty`)))
 	})
+
+	It("should return each of a sequence of responses in turn", func() {
+		hoverfly.ImportSimulation(testdata.ResponsesSequence)
+
+		resp := hoverfly.Proxy(sling.New().Get("http://test-server.com/health"))
+		Expect(resp.StatusCode).To(Equal(503))
+		Expect(ioutil.ReadAll(resp.Body)).To(Equal([]byte(`starting up`)))
+
+		resp = hoverfly.Proxy(sling.New().Get("http://test-server.com/health"))
+		Expect(resp.StatusCode).To(Equal(200))
+		Expect(ioutil.ReadAll(resp.Body)).To(Equal([]byte(`healthy`)))
+
+		resp = hoverfly.Proxy(sling.New().Get("http://test-server.com/health"))
+		Expect(resp.StatusCode).To(Equal(200))
+		Expect(ioutil.ReadAll(resp.Body)).To(Equal([]byte(`healthy`)))
+	})
+
+	It("should stop matching once a sequence of responses which fails after being exhausted has been returned", func() {
+		hoverfly.ImportSimulation(testdata.ResponsesSequence)
+
+		resp := hoverfly.Proxy(sling.New().Get("http://test-server.com/token"))
+		Expect(resp.StatusCode).To(Equal(200))
+		Expect(ioutil.ReadAll(resp.Body)).To(Equal([]byte(`first token`)))
+
+		resp = hoverfly.Proxy(sling.New().Get("http://test-server.com/token"))
+		Expect(resp.StatusCode).To(Equal(502))
+
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).To(BeNil())
+		Expect(string(body)).To(ContainSubstring("Every response for the matched request has already been returned"))
+	})
+
+	It("should start sequences of responses again when the state is deleted", func() {
+		hoverfly.ImportSimulation(testdata.ResponsesSequence)
+
+		hoverfly.Proxy(sling.New().Get("http://test-server.com/health"))
+		hoverfly.Proxy(sling.New().Get("http://test-server.com/token"))
+
+		res := functional_tests.DoRequest(sling.New().Delete(stateURL))
+		Expect(res.StatusCode).To(Equal(200))
+
+		resp := hoverfly.Proxy(sling.New().Get("http://test-server.com/health"))
+		Expect(resp.StatusCode).To(Equal(503))
+
+		resp = hoverfly.Proxy(sling.New().Get("http://test-server.com/token"))
+		Expect(resp.StatusCode).To(Equal(200))
+	})
+
+	It("should export sequences of responses", func() {
+		hoverfly.ImportSimulation(testdata.ResponsesSequence)
+
+		simulation := hoverfly.ExportSimulation()
+
+		Expect(simulation.RequestResponsePairs[0].ResponsesMode).To(Equal("stickAtLast"))
+		Expect(simulation.RequestResponsePairs[0].Responses).To(HaveLen(2))
+		Expect(simulation.RequestResponsePairs[0].Responses[0].Status).To(Equal(503))
+		Expect(simulation.RequestResponsePairs[0].Response.Status).To(Equal(0))
+		Expect(simulation.RequestResponsePairs[1].ResponsesMode).To(Equal("failAfterExhausted"))
+	})
+
+	It("should run the javascript of a response with access to the state", func() {
+		hoverfly.ImportSimulation(testdata.JavascriptResponse)
+
+		resp := hoverfly.Proxy(sling.New().Get("http://test-server.com/visits"))
+		Expect(resp.StatusCode).To(Equal(200))
+		Expect(ioutil.ReadAll(resp.Body)).To(MatchJSON(`{"visits": 1}`))
+
+		resp = hoverfly.Proxy(sling.New().Get("http://test-server.com/visits"))
+		Expect(ioutil.ReadAll(resp.Body)).To(MatchJSON(`{"visits": 2}`))
+
+		assertState(stateURL, `{"state":{"visits":"2"}}`)
+
+		resp = hoverfly.Proxy(sling.New().Get("http://test-server.com/broken"))
+		Expect(resp.StatusCode).To(Equal(502))
+
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).To(BeNil())
+		Expect(string(body)).To(ContainSubstring("script broke"))
+	})
 })
 
 func assertState(stateURL, expectedState string) {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io/ioutil"
 	"net"
+	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
@@ -277,7 +278,7 @@ var _ = Describe("When I run Hoverfly", func() {
 			Expect(err).To(BeNil())
 
 			// TODO: Handle this?
-			Expect(string(body)).To(ContainSubstring("{map[] [Request] http %!s(func(string, string, *raymond.Options)"))
+			Expect(string(body)).To(ContainSubstring("{map[]  [Request] map[] http test-server.com map[Accept-Encoding:[gzip] User-Agent:[Go-http-client/1.1]] %!s(func(string, string, *raymond.Options)"))
 		})
 
 		It("Request.Body jsonpath", func() {
@@ -391,6 +392,32 @@ var _ = Describe("When I run Hoverfly", func() {
 			Expect(string(body)).To(Equal("map[query:[param]]"))
 		})
 
+		It("Request.Header, Request.Destination and Request.RawQuery", func() {
+			hoverfly.ImportSimulation(testdata.TemplatingRequest)
+
+			req, err := http.NewRequest("GET", "http://test-server.com/Request.Header?b=2&a=1", nil)
+			Expect(err).To(BeNil())
+			req.Header.Set("X-Correlation-Id", "abc-123")
+
+			resp := hoverfly.ProxyRequest(req)
+			Expect(resp.StatusCode).To(Equal(200))
+
+			body, err := ioutil.ReadAll(resp.Body)
+			Expect(err).To(BeNil())
+
+			Expect(string(body)).To(Equal("abc-123 test-server.com b=2&a=1"))
+		})
+
+		It("templates response headers and status", func() {
+			hoverfly.ImportSimulation(testdata.TemplatingRequest)
+
+			resp := hoverfly.Proxy(sling.New().Post("http://test-server.com/bookings?status=201").Set("X-Correlation-Id", "abc-123"))
+			Expect(resp.StatusCode).To(Equal(201))
+
+			Expect(resp.Header.Get("Location")).To(Equal("http://test-server.com/bookings/1"))
+			Expect(resp.Header.Get("X-Correlation-Id")).To(Equal("abc-123"))
+		})
+
 		It("Request.QueryParam.query", func() {
 			hoverfly.ImportSimulation(testdata.TemplatingRequest)
 
@@ -0,0 +1,5 @@
+var visits = Number(state.get("visits") || 0) + 1;
+state.set("visits", visits);
+
+pair.response.body = "CHANGED_RESPONSE_BODY " + visits;
+pair.response.headers = {"X-Middleware": ["javascript"]};
@@ -0,0 +1,75 @@
+package hoverctl_suite
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/SpectoLabs/hoverfly/functional-tests"
+	"github.com/dghubble/sling"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("hoverctl diff", func() {
+
+	var (
+		hoverfly   *functional_tests.Hoverfly
+		fakeServer *httptest.Server
+	)
+
+	BeforeEach(func() {
+		contentType := "text/plain"
+		fakeServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+		}))
+
+		hoverfly = functional_tests.NewHoverfly()
+		hoverfly.Start()
+		hoverfly.SetMode("capture")
+		hoverfly.Proxy(sling.New().Get(fakeServer.URL + "/same"))
+		hoverfly.Proxy(sling.New().Get(fakeServer.URL + "/changed"))
+
+		hoverfly.SetMode("diff")
+		hoverfly.Proxy(sling.New().Get(fakeServer.URL + "/same"))
+		contentType = "application/json"
+		hoverfly.Proxy(sling.New().Get(fakeServer.URL + "/changed"))
+
+		functional_tests.Run(hoverctlBinary, "targets", "update", "local", "--admin-port", hoverfly.GetAdminPort())
+	})
+
+	AfterEach(func() {
+		hoverfly.Stop()
+		fakeServer.Close()
+	})
+
+	It("should get the diffs", func() {
+		output := functional_tests.Run(hoverctlBinary, "diff", "get")
+
+		Expect(output).To(ContainSubstring("Path: /changed"))
+		Expect(output).To(ContainSubstring("the expected value was [[text/plain]], but actual value was [[application/json]]"))
+		Expect(output).ToNot(ContainSubstring("Path: /same"))
+	})
+
+	It("should get the diffs as a JUnit XML report", func() {
+		output := functional_tests.Run(hoverctlBinary, "diff", "get", "--format", "junit")
+
+		Expect(output).To(ContainSubstring(`<testsuites name="hoverfly" tests="2" failures="1">`))
+		Expect(output).To(ContainSubstring(`/changed">`))
+		Expect(output).To(ContainSubstring(`<failure message="1 of 1 responses differed from the simulation" type="diff">`))
+		Expect(output).To(ContainSubstring(`/same"></testcase>`))
+	})
+
+	It("should get a summary of the diffs", func() {
+		output := functional_tests.Run(hoverctlBinary, "diff", "get", "--format", "summary")
+
+		Expect(output).To(ContainSubstring("Failed: 1 of 2 responses differed from the simulation"))
+		Expect(output).To(ContainSubstring("Requests: 2, with differences: 1"))
+		Expect(output).To(ContainSubstring("Differences: 1"))
+	})
+
+	It("should error on an unsupported format", func() {
+		output := functional_tests.Run(hoverctlBinary, "diff", "get", "--format", "csv")
+
+		Expect(output).To(ContainSubstring("Unsupported format csv, should be text, junit or summary"))
+	})
+})
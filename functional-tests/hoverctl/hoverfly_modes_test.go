@@ -1,6 +1,7 @@
 package hoverctl_suite
 
 import (
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/functional-tests"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -224,6 +225,28 @@ var _ = Describe("When I use hoverfly-cli", func() {
 				Expect(output).To(ContainSubstring("Hoverfly is currently set to diff mode"))
 				Expect(hoverfly.GetMode().Mode).To(Equal(diff))
 			})
+
+			It("to diff mode with diff rules", func() {
+				output := functional_tests.Run(hoverctlBinary, "mode", "diff",
+					"--diff-ignore", "$.timestamp", "--diff-tolerance", "/order/total=0.01",
+					"--diff-unordered", "$.tags", "--diff-regex", "/order[@id='1']/ref=^[a-z]+$")
+
+				Expect(output).To(ContainSubstring("Hoverfly has been set to diff mode and will apply 4 diff rules"))
+
+				Expect(hoverfly.GetMode().Arguments.DiffRules).To(Equal([]v2.DiffRuleView{
+					{Path: "$.timestamp", Ignore: true},
+					{Path: "/order/total", Tolerance: 0.01},
+					{Path: "$.tags", Unordered: true},
+					{Path: "/order[@id='1']/ref", Regex: "^[a-z]+$"},
+				}))
+			})
+
+			It("not to diff mode with an invalid diff rule", func() {
+				output := functional_tests.Run(hoverctlBinary, "mode", "diff", "--diff-ignore", "timestamp")
+
+				Expect(output).To(ContainSubstring("Diff rule path 'timestamp' should be a JSON path starting with $ or an XPath starting with /"))
+				Expect(hoverfly.GetMode().Mode).ToNot(Equal(diff))
+			})
 		})
 	})
 
@@ -184,7 +184,7 @@ var _ = Describe("When I use hoverctl", func() {
 
 		hoverflySimulation = `"pairs":[{"request":{"path":[{"matcher":"exact","value":"/api/bookings"}],"method":[{"matcher":"exact","value":"POST"}],"destination":[{"matcher":"exact","value":"www.my-test.com"}],"scheme":[{"matcher":"exact","value":"http"}],"body":[{"matcher":"exact","value":"{\"flightId\": \"1\"}"}],"headers":{"Content-Type":[{"matcher":"exact","value":"application/json"}]}},"response":{"status":201,"body":"","encodedBody":false,"headers":{"Location":["http://localhost/api/bookings/1"]},"templated":false}}],"globalActions":{"delays":[],"delaysLogNormal":[]}}`
 
-		hoverflyMeta = `"meta":{"schemaVersion":"v5","hoverflyVersion":"v\d+.\d+.\d+(-rc.\d)*","timeExported":`
+		hoverflyMeta = `"meta":{"schemaVersion":"v5.1","hoverflyVersion":"v\d+.\d+.\d+(-rc.\d)*","timeExported":`
 	)
 
 	Describe("with a running hoverfly", func() {
@@ -276,6 +276,71 @@ var _ = Describe("When I use hoverctl", func() {
 				Expect(string(bytes)).To(MatchRegexp(hoverflyMeta))
 			})
 
+			It("can import a HAR", func() {
+				fileName := functional_tests.GenerateFileName()
+				err := ioutil.WriteFile(fileName, []byte(`{
+					"log": {
+						"version": "1.2",
+						"creator": {"name": "test", "version": "1"},
+						"entries": [{
+							"request": {
+								"method": "POST",
+								"url": "http://www.my-test.com/api/bookings",
+								"headers": [{"name": "Content-Type", "value": "application/json"}],
+								"postData": {"mimeType": "application/json", "text": "{\"flightId\": \"1\"}"}
+							},
+							"response": {
+								"status": 201,
+								"headers": [{"name": "Location", "value": "http://localhost/api/bookings/1"}],
+								"content": {"size": 0, "mimeType": ""}
+							}
+						}]
+					}
+				}`), 0644)
+				Expect(err).To(BeNil())
+
+				output := functional_tests.Run(hoverctlBinary, "import", fileName, "--format", "har")
+
+				Expect(output).To(ContainSubstring("Successfully imported simulation from " + fileName))
+
+				resp := functional_tests.DoRequest(sling.New().Get(fmt.Sprintf("http://localhost:%v/api/v2/simulation", hoverfly.GetAdminPort())))
+				bytes, _ := ioutil.ReadAll(resp.Body)
+				Expect(string(bytes)).To(ContainSubstring(`"body":[{"matcher":"json","value":"{\"flightId\": \"1\"}"}]`))
+				Expect(string(bytes)).To(ContainSubstring(`"headers":{"Location":["http://localhost/api/bookings/1"]}`))
+			})
+
+			It("cannot import a simulation as a HAR", func() {
+				fileName := functional_tests.GenerateFileName()
+				err := ioutil.WriteFile(fileName, []byte(hoverflyData), 0644)
+				Expect(err).To(BeNil())
+
+				output := functional_tests.Run(hoverctlBinary, "import", fileName, "--format", "har")
+
+				Expect(output).To(ContainSubstring(fileName + " is not a HAR file"))
+			})
+
+			It("can export the journal as a HAR", func() {
+				hoverfly.SetMode("simulate")
+				hoverfly.Proxy(sling.New().Post("http://www.my-test.com/api/bookings").
+					Set("Content-Type", "application/json").
+					Body(bytes.NewBufferString(`{"flightId": "1"}`)))
+
+				fileName := functional_tests.GenerateFileName()
+				output := functional_tests.Run(hoverctlBinary, "export", fileName, "--format", "har")
+
+				Expect(output).To(ContainSubstring("Successfully exported journal to " + fileName))
+
+				data, err := ioutil.ReadFile(fileName)
+				Expect(err).To(BeNil())
+
+				buffer := new(bytes.Buffer)
+				json.Compact(buffer, data)
+
+				Expect(buffer.String()).To(ContainSubstring(`"creator":{"name":"Hoverfly"`))
+				Expect(buffer.String()).To(ContainSubstring(`"method":"POST","url":"http://www.my-test.com/api/bookings"`))
+				Expect(buffer.String()).To(ContainSubstring(`"status":201`))
+			})
+
 			// TODO: Fix this test
 			// It("cannot import incorrect json / missing meta", func() {
 			// 	hoverfly.ImportSimulation(v3HoverflyData)
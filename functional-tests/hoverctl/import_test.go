@@ -2,6 +2,8 @@ package hoverctl_suite
 
 import (
 	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	"github.com/SpectoLabs/hoverfly/functional-tests"
 	. "github.com/onsi/ginkgo"
@@ -104,5 +106,41 @@ var _ = Describe("When I import with hoverctl", func() {
 			Expect(output).To(ContainSubstring("Successfully imported simulation "))
 
 		})
+
+		It("can import a YAML simulation with a body file and export it as YAML", func() {
+			dir, err := ioutil.TempDir("", "hoverctl-yaml")
+			Expect(err).To(BeNil())
+			defer os.RemoveAll(dir)
+
+			Expect(ioutil.WriteFile(filepath.Join(dir, "body.json"), []byte("{\n  \"id\": 1\n}"), 0644)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(dir, "simulation.yaml"), []byte(`
+data:
+  pairs:
+  - request:
+      path:
+      - matcher: exact
+        value: /bookings
+    response:
+      status: 200
+      bodyFile: body.json
+meta:
+  schemaVersion: v5
+`), 0644)).To(Succeed())
+
+			output := functional_tests.Run(hoverctlBinary, "import", filepath.Join(dir, "simulation.yaml"))
+			Expect(output).To(ContainSubstring("Successfully imported simulation"))
+
+			simulation := hoverfly.ExportSimulation()
+			Expect(simulation.RequestResponsePairs).To(HaveLen(1))
+			Expect(simulation.RequestResponsePairs[0].Response.Body).To(Equal("{\n  \"id\": 1\n}"))
+
+			output = functional_tests.Run(hoverctlBinary, "export", filepath.Join(dir, "exported.yml"))
+			Expect(output).To(ContainSubstring("Successfully exported simulation"))
+
+			exported, err := ioutil.ReadFile(filepath.Join(dir, "exported.yml"))
+			Expect(err).To(BeNil())
+			Expect(string(exported)).To(ContainSubstring("      body: |-\n        {\n          \"id\": 1\n        }\n"))
+			Expect(string(exported)).To(ContainSubstring("  schemaVersion: v5"))
+		})
 	})
 })
@@ -0,0 +1,62 @@
+package hoverctl_suite
+
+import (
+	"github.com/SpectoLabs/hoverfly/functional-tests"
+	"github.com/dghubble/sling"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("When I use hoverctl", func() {
+
+	var (
+		hoverfly *functional_tests.Hoverfly
+	)
+
+	Describe("with a running hoverfly", func() {
+
+		BeforeEach(func() {
+			hoverfly = functional_tests.NewHoverfly()
+			hoverfly.Start()
+			hoverfly.SetMode("capture")
+
+			functional_tests.Run(hoverctlBinary, "targets", "update", "local", "--admin-port", hoverfly.GetAdminPort())
+
+			hoverfly.Proxy(sling.New().Get("http://destination-server.com/one"))
+			hoverfly.Proxy(sling.New().Get("http://destination-server.com/one"))
+			hoverfly.Proxy(sling.New().Get("http://destination-server.com/two"))
+		})
+
+		AfterEach(func() {
+			hoverfly.Stop()
+		})
+
+		Describe("verifying the journal", func() {
+
+			It("passes when the request was made the expected number of times", func() {
+				output := functional_tests.Run(hoverctlBinary, "journal", "verify", "--path", "/one", "--exactly", "2")
+				Expect(output).To(ContainSubstring("Request was made 2 times, as expected"))
+
+				output = functional_tests.Run(hoverctlBinary, "journal", "verify", "--destination", "destination-server.com", "--path", "/t*")
+				Expect(output).To(ContainSubstring("Request was made once, as expected"))
+
+				output = functional_tests.Run(hoverctlBinary, "journal", "verify", "--path", "/three", "--never")
+				Expect(output).To(ContainSubstring("Request was made 0 times, as expected"))
+			})
+
+			It("fails with the closest requests when the request was not made the expected number of times", func() {
+				output := functional_tests.Run(hoverctlBinary, "journal", "verify", "--method", "POST", "--path", "/two")
+				Expect(output).To(ContainSubstring("Expected request to be made at least 1 time, but it was made 0 times"))
+				Expect(output).To(ContainSubstring("The closest requests in the journal were:"))
+				Expect(output).To(ContainSubstring("GET http://destination-server.com/two"))
+				Expect(output).To(ContainSubstring("Did not match on: method"))
+			})
+
+			It("fails when the count constraint is invalid", func() {
+				output := functional_tests.Run(hoverctlBinary, "journal", "verify", "--path", "/one", "--exactly", "2", "--never")
+				Expect(output).To(ContainSubstring("Could not verify journal"))
+				Expect(output).To(ContainSubstring("count never cannot be combined with other count constraints"))
+			})
+		})
+	})
+})
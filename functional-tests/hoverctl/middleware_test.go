@@ -100,6 +100,32 @@ var _ = Describe("When I use hoverctl", func() {
 				"\n    main()"))
 		})
 
+		It("I can set the hoverfly's middleware with workers and a timeout", func() {
+			output := functional_tests.Run(hoverctlBinary, "middleware", "--binary", "python", "--script", "testdata/worker_middleware.py", "--workers", "2", "--timeout", "1500ms")
+
+			Expect(output).To(ContainSubstring("Hoverfly middleware configuration has been set to"))
+			Expect(output).To(ContainSubstring("Binary: python"))
+			Expect(output).To(ContainSubstring("Workers: 2"))
+			Expect(output).To(ContainSubstring("Timeout: 1.5s"))
+
+			output = functional_tests.Run(hoverctlBinary, "middleware")
+
+			Expect(output).To(ContainSubstring("Workers: 2"))
+			Expect(output).To(ContainSubstring("Timeout: 1.5s"))
+		})
+
+		It("I can set the hoverfly's middleware with javascript", func() {
+			output := functional_tests.Run(hoverctlBinary, "middleware", "--javascript", "testdata/middleware.js", "--timeout", "500ms")
+
+			Expect(output).To(ContainSubstring("Hoverfly middleware configuration has been set to"))
+			Expect(output).To(ContainSubstring(`JavaScript: pair.response.status = 201;`))
+			Expect(output).To(ContainSubstring("Timeout: 500ms"))
+
+			output = functional_tests.Run(hoverctlBinary, "middleware")
+
+			Expect(output).To(ContainSubstring(`JavaScript: pair.response.status = 201;`))
+		})
+
 		It("I can set the hoverfly's middleware with a remote", func() {
 
 			middlewareServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -157,6 +183,74 @@ var _ = Describe("When I use hoverctl", func() {
 		})
 	})
 
+	Describe("with a running hoverfly and the middleware chain", func() {
+
+		BeforeEach(func() {
+			hoverfly = functional_tests.NewHoverfly()
+			hoverfly.Start()
+
+			functional_tests.Run(hoverctlBinary, "targets", "update", "local", "--admin-port", hoverfly.GetAdminPort())
+		})
+
+		AfterEach(func() {
+			hoverfly.Stop()
+		})
+
+		It("I can see that the middleware chain is empty", func() {
+			output := functional_tests.Run(hoverctlBinary, "middleware", "chain")
+
+			Expect(output).To(ContainSubstring("There is no middleware in the Hoverfly middleware chain"))
+		})
+
+		It("I can add middleware to the middleware chain", func() {
+			output := functional_tests.Run(hoverctlBinary, "middleware", "chain", "add", "--phase", "on-miss", "--modes", "simulate,spy", "--destination", "test.com", "--javascript", "testdata/middleware.js")
+
+			Expect(output).To(ContainSubstring("Testing middleware against Hoverfly..."))
+			Expect(output).To(ContainSubstring("1. Phase: on-miss\nModes: simulate, spy\nDestination: test.com\nJavaScript: pair.response.status = 201;"))
+
+			output = functional_tests.Run(hoverctlBinary, "middleware", "chain", "add", "--phase", "post-response", "--javascript", "testdata/middleware.js", "--timeout", "500ms")
+
+			Expect(output).To(ContainSubstring("1. Phase: on-miss"))
+			Expect(output).To(ContainSubstring("2. Phase: post-response\nJavaScript: pair.response.status = 201;"))
+			Expect(output).To(ContainSubstring("Timeout: 500ms"))
+		})
+
+		It("I can remove middleware from the middleware chain", func() {
+			functional_tests.Run(hoverctlBinary, "middleware", "chain", "add", "--phase", "on-miss", "--javascript", "testdata/middleware.js")
+			functional_tests.Run(hoverctlBinary, "middleware", "chain", "add", "--phase", "pre-request", "--javascript", "testdata/middleware.js")
+
+			output := functional_tests.Run(hoverctlBinary, "middleware", "chain", "remove", "1")
+
+			Expect(output).To(ContainSubstring("1. Phase: pre-request"))
+			Expect(output).ToNot(ContainSubstring("on-miss"))
+
+			output = functional_tests.Run(hoverctlBinary, "middleware", "chain", "remove", "2")
+
+			Expect(output).To(ContainSubstring("There is no stage 2 in the middleware chain"))
+		})
+
+		It("I can clear the middleware chain", func() {
+			functional_tests.Run(hoverctlBinary, "middleware", "chain", "add", "--phase", "on-miss", "--javascript", "testdata/middleware.js")
+
+			output := functional_tests.Run(hoverctlBinary, "middleware", "chain", "clear")
+
+			Expect(output).To(ContainSubstring("There is no middleware in the Hoverfly middleware chain"))
+		})
+
+		It("I cannae add middleware to the middleware chain without a phase", func() {
+			output := functional_tests.Run(hoverctlBinary, "middleware", "chain", "add", "--javascript", "testdata/middleware.js")
+
+			Expect(output).To(ContainSubstring("The phase of the middleware must be set with --phase"))
+		})
+
+		It("I cannae add middleware to the middleware chain which fails", func() {
+			output := functional_tests.Run(hoverctlBinary, "middleware", "chain", "add", "--phase", "on-miss", "--remote", "http://specto.io/404/nothere")
+
+			Expect(output).To(ContainSubstring("Could not set middleware chain, it may have failed the test"))
+			Expect(output).To(ContainSubstring("Stage: 1 (on-miss)"))
+		})
+	})
+
 	Context("with a target that doesn't exist", func() {
 		It("should error", func() {
 			output := functional_tests.Run(hoverctlBinary, "middleware", "--target", "test-target")
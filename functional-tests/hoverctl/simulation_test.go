@@ -119,5 +119,77 @@ var _ = Describe("When I add simulation with hoverctl", func() {
 			Expect(output).To(ContainSubstring("Successfully added simulation from " + file2))
 
 		})
+
+		Context("compacting the simulation", func() {
+
+			BeforeEach(func() {
+				hoverfly.ImportSimulation(`{
+					"data": {
+						"pairs": [` + capturedUserPair("1") + `,` + capturedUserPair("2") + `, {
+							"request": {
+								"path": [{
+									"matcher": "exact",
+									"value": "/health"
+								}]
+							},
+							"response": {
+								"status": 200,
+								"body": "ok"
+							}
+						}]
+					},
+					"meta": {
+						"schemaVersion": "v5"
+					}
+				}`)
+			})
+
+			It("can show the pairs which would be merged without changing the simulation", func() {
+				output := functional_tests.Run(hoverctlBinary, "simulation", "compact", "--dry-run")
+
+				Expect(output).To(ContainSubstring("- GET http://api.example.com/users/1"))
+				Expect(output).To(ContainSubstring("- GET http://api.example.com/users/2"))
+				Expect(output).To(ContainSubstring("+ GET http://api.example.com/users/* (templated response)"))
+				Expect(output).To(ContainSubstring("The simulation would be compacted from 3 to 2 request/response pairs"))
+
+				Expect(hoverfly.ExportSimulation().RequestResponsePairs).To(HaveLen(3))
+			})
+
+			It("can merge the pairs", func() {
+				output := functional_tests.Run(hoverctlBinary, "simulation", "compact")
+
+				Expect(output).To(ContainSubstring("The simulation has been compacted from 3 to 2 request/response pairs"))
+
+				pairs := hoverfly.ExportSimulation().RequestResponsePairs
+				Expect(pairs).To(HaveLen(2))
+				Expect(pairs[0].RequestMatcher.Path[0].Matcher).To(Equal("glob"))
+				Expect(pairs[0].RequestMatcher.Path[0].Value).To(Equal("/users/*"))
+				Expect(pairs[0].Response.Body).To(Equal("user {{{ Request.Path.[1] }}}"))
+			})
+
+			It("says when there is nothing to merge", func() {
+				functional_tests.Run(hoverctlBinary, "simulation", "compact")
+
+				output := functional_tests.Run(hoverctlBinary, "simulation", "compact")
+
+				Expect(output).To(ContainSubstring("There are no request/response pairs which can be compacted"))
+			})
+		})
 	})
 })
+
+func capturedUserPair(id string) string {
+	return `{
+		"request": {
+			"path": [{"matcher": "exact", "value": "/users/` + id + `"}],
+			"method": [{"matcher": "exact", "value": "GET"}],
+			"destination": [{"matcher": "exact", "value": "api.example.com"}],
+			"scheme": [{"matcher": "exact", "value": "http"}],
+			"body": [{"matcher": "exact", "value": ""}]
+		},
+		"response": {
+			"status": 200,
+			"body": "user ` + id + `"
+		}
+	}`
+}
@@ -73,6 +73,36 @@ var _ = Describe("When I use hoverctl", func() {
 
 			})
 
+			Describe("when managing scenarios", func() {
+
+				It("Can set, get and reset a scenario", func() {
+					output := functional_tests.Run(hoverctlBinary, "state", "get-scenario", "checkout")
+					Expect(output).To(ContainSubstring("The state for the scenario checkout is empty"))
+
+					output = functional_tests.Run(hoverctlBinary, "state", "set-scenario", "checkout", "basket", "empty", "--states", "empty,full", "--transition", "empty:full")
+					Expect(output).To(ContainSubstring("Successfully set scenario checkout"))
+
+					output = functional_tests.Run(hoverctlBinary, "state", "get-scenario", "checkout")
+					Expect(output).To(ContainSubstring("State of scenario checkout:\n\"basket\"=\"empty\""))
+					Expect(output).To(ContainSubstring("States: empty, full"))
+					Expect(output).To(ContainSubstring("Transitions:\n\"empty\" -> \"full\""))
+
+					output = functional_tests.Run(hoverctlBinary, "state", "get-all")
+					Expect(output).To(ContainSubstring(`"scenario:checkout:basket"="empty"`))
+
+					output = functional_tests.Run(hoverctlBinary, "state", "set-scenario", "checkout", "basket", "overflowing")
+					Expect(output).To(ContainSubstring("overflowing is not one of the states declared for basket"))
+
+					output = functional_tests.Run(hoverctlBinary, "state", "reset-scenario", "checkout")
+					Expect(output).To(ContainSubstring("Scenario checkout has been reset"))
+
+					output = functional_tests.Run(hoverctlBinary, "state", "get-scenario", "checkout")
+					Expect(output).To(ContainSubstring("The state for the scenario checkout is empty"))
+					Expect(output).To(ContainSubstring("States: empty, full"))
+				})
+
+			})
+
 		})
 	})
 })
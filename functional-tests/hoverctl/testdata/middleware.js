@@ -0,0 +1,2 @@
+pair.response.status = 201;
+pair.response.body = "modified by javascript";
@@ -0,0 +1,6 @@
+#!/usr/bin/env python
+import sys
+
+for line in iter(sys.stdin.readline, ''):
+    sys.stdout.write(line)
+    sys.stdout.flush()
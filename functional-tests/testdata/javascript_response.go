@@ -0,0 +1,34 @@
+package testdata
+
+var JavascriptResponse = `{
+	"data": {
+		"pairs": [{
+			"request": {
+				"path": [{
+					"matcher": "exact",
+					"value": "/visits"
+				}]
+			},
+			"response": {
+				"status": 200,
+				"body": "{\"visits\": 0}",
+				"javascript": "var visits = Number(state.get('visits') || 0) + 1; state.set('visits', visits); var body = JSON.parse(pair.response.body); body.visits = visits; pair.response.body = JSON.stringify(body);"
+			}
+		}, {
+			"request": {
+				"path": [{
+					"matcher": "exact",
+					"value": "/broken"
+				}]
+			},
+			"response": {
+				"status": 200,
+				"body": "broken",
+				"javascript": "throw new Error('script broke')"
+			}
+		}]
+	},
+	"meta": {
+		"schemaVersion": "v5.1"
+	}
+}`
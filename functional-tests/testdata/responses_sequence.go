@@ -0,0 +1,37 @@
+package testdata
+
+var ResponsesSequence = `{
+	"data": {
+		"pairs": [{
+			"request": {
+				"path": [{
+					"matcher": "exact",
+					"value": "/health"
+				}]
+			},
+			"responses": [{
+				"status": 503,
+				"body": "starting up"
+			}, {
+				"status": 200,
+				"body": "healthy"
+			}],
+			"responsesMode": "stickAtLast"
+		}, {
+			"request": {
+				"path": [{
+					"matcher": "exact",
+					"value": "/token"
+				}]
+			},
+			"responses": [{
+				"status": 200,
+				"body": "first token"
+			}],
+			"responsesMode": "failAfterExhausted"
+		}]
+	},
+	"meta": {
+		"schemaVersion": "v5.1"
+	}
+}`
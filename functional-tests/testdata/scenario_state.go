@@ -0,0 +1,49 @@
+package testdata
+
+var ScenarioState = `{
+	"data": {
+		"pairs": [{
+			"request": {
+				"path": [{
+					"matcher": "exact",
+					"value": "/basket"
+				}]
+			},
+			"response": {
+				"status": 200,
+				"body": "empty"
+			}
+		}, {
+			"request": {
+				"path": [{
+					"matcher": "exact",
+					"value": "/basket"
+				}],
+				"requiresState": {
+					"scenario:checkout:eggs": "present"
+				}
+			},
+			"response": {
+				"status": 200,
+				"body": "eggs"
+			}
+		}, {
+			"request": {
+				"path": [{
+					"matcher": "exact",
+					"value": "/add-eggs"
+				}]
+			},
+			"response": {
+				"status": 200,
+				"body": "added eggs",
+				"transitionsState": {
+					"scenario:checkout:eggs": "present"
+				}
+			}
+		}]
+	},
+	"meta": {
+		"schemaVersion": "v5"
+	}
+}`
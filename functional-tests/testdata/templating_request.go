@@ -146,6 +146,43 @@ var TemplatingRequest = `{
 					"encodedBody": false,
 					"templated": true
 				}
+			},
+			{
+				"request": {
+					"path": [
+						{
+							"matcher": "exact",
+							"value": "/Request.Header"
+						}
+					]
+				},
+				"response": {
+					"status": 200,
+					"body": "{{ Request.Header.X-Correlation-Id }} {{ Request.Destination }} {{{ Request.RawQuery }}}",
+					"encodedBody": false,
+					"templated": true
+				}
+			},
+			{
+				"request": {
+					"path": [
+						{
+							"matcher": "exact",
+							"value": "/bookings"
+						}
+					]
+				},
+				"response": {
+					"status": 200,
+					"statusTemplate": "{{ Request.QueryParam.status }}",
+					"body": "",
+					"encodedBody": false,
+					"headers": {
+						"Location": ["{{ Request.Scheme }}://{{ Request.Destination }}/{{ Request.Path.[0] }}/1"],
+						"X-Correlation-Id": ["{{ Request.Header.X-Correlation-Id }}"]
+					},
+					"templated": true
+				}
 			}
 		],
 		"globalActions": {
@@ -153,6 +190,6 @@ var TemplatingRequest = `{
 		}
 	},
 	"meta": {
-		"schemaVersion": "v5"
+		"schemaVersion": "v5.1"
 	}
 }`
@@ -23,48 +23,39 @@ as lists of strings grouped by the same requests.
 
 const errorMsgTemplate = "\"%s\"\nthe expected value was [%s], but actual value was [%s]\n\n"
 
+var diffFormat string
 var getAllDiffCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Gets all diffs stored in Hoverfly",
 	Long: `
 Returns all differences between expected and actual responses from Hoverfly.
+
+With "--format junit", the diffs are written as a JUnit
+XML report with a test case for each request, which
+fails when any of its responses differed.
+
+With "--format summary", only the number of requests
+and responses compared, and those which differed,
+are written.
 	`,
 	Run: func(cmd *cobra.Command, args []string) {
 
 		checkTargetAndExit(target)
 
 		if len(args) == 0 {
-			diffs, err := wrapper.GetAllDiffs(*target)
-			handleIfError(err)
-			var output bytes.Buffer
-
-			for _, diffsWithRequest := range diffs {
-
-				diffString := "diff"
-				if len(diffsWithRequest.DiffReport) > 1 {
-					diffString = "diffs"
-				}
-				output.WriteString(
-					fmt.Sprintf("For request:\n"+
-						"\n Method: %s \n Host: %s \n Path: %s \n Query:  %s \n\n%s %s recorded:\n",
-						diffsWithRequest.Request.Method,
-						diffsWithRequest.Request.Host,
-						diffsWithRequest.Request.Path,
-						diffsWithRequest.Request.Query,
-						fmt.Sprint(len(diffsWithRequest.DiffReport)),
-						diffString,
-					))
-
-				for index, diff := range diffsWithRequest.DiffReport {
-					output.WriteString(fmt.Sprintf("\n%s. %s\n%s\n",
-						fmt.Sprint(index+1), diff.Timestamp, diffReportMessage(diff)))
-				}
-			}
-
-			if len(output.Bytes()) == 0 {
-				fmt.Println("There are no diffs stored in Hoverfly")
-			} else {
-				fmt.Println(output.String())
+			switch diffFormat {
+			case "text":
+				printDiffs()
+			case "junit":
+				report, err := wrapper.ExportDiffsJUnit(*target)
+				handleIfError(err)
+				fmt.Println(string(report))
+			case "summary":
+				summary, err := wrapper.GetDiffSummary(*target)
+				handleIfError(err)
+				printDiffSummary(summary)
+			default:
+				handleIfError(fmt.Errorf("Unsupported format %s, should be text, junit or summary", diffFormat))
 			}
 		}
 	},
@@ -86,6 +77,52 @@ Deletes all differences between expected and actual responses stored in Hoverfly
 	},
 }
 
+func printDiffs() {
+	diffs, err := wrapper.GetAllDiffs(*target)
+	handleIfError(err)
+	var output bytes.Buffer
+
+	for _, diffsWithRequest := range diffs {
+
+		diffString := "diff"
+		if len(diffsWithRequest.DiffReport) > 1 {
+			diffString = "diffs"
+		}
+		output.WriteString(
+			fmt.Sprintf("For request:\n"+
+				"\n Method: %s \n Host: %s \n Path: %s \n Query:  %s \n\n%s %s recorded:\n",
+				diffsWithRequest.Request.Method,
+				diffsWithRequest.Request.Host,
+				diffsWithRequest.Request.Path,
+				diffsWithRequest.Request.Query,
+				fmt.Sprint(len(diffsWithRequest.DiffReport)),
+				diffString,
+			))
+
+		for index, diff := range diffsWithRequest.DiffReport {
+			output.WriteString(fmt.Sprintf("\n%s. %s\n%s\n",
+				fmt.Sprint(index+1), diff.Timestamp, diffReportMessage(diff)))
+		}
+	}
+
+	if len(output.Bytes()) == 0 {
+		fmt.Println("There are no diffs stored in Hoverfly")
+	} else {
+		fmt.Println(output.String())
+	}
+}
+
+func printDiffSummary(summary *v2.DiffSummaryView) {
+	result := "Passed"
+	if !summary.Passed {
+		result = "Failed"
+	}
+
+	fmt.Printf("%s: %d of %d responses differed from the simulation\n", result, summary.FailedResponses, summary.Responses)
+	fmt.Printf("Requests: %d, with differences: %d\n", summary.Requests, summary.FailedRequests)
+	fmt.Printf("Differences: %d\n", summary.Differences)
+}
+
 func diffReportMessage(report v2.DiffReport) string {
 	var msg bytes.Buffer
 	for index, entry := range report.DiffEntries {
@@ -98,4 +135,6 @@ func init() {
 	RootCmd.AddCommand(diffCmd)
 	diffCmd.AddCommand(getAllDiffCmd)
 	diffCmd.AddCommand(deleteDiffsCmd)
+
+	getAllDiffCmd.Flags().StringVar(&diffFormat, "format", "text", "Get the diffs as text, a junit XML report or a summary")
 }
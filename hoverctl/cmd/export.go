@@ -3,18 +3,26 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
 	"github.com/SpectoLabs/hoverfly/hoverctl/wrapper"
 	"github.com/spf13/cobra"
 )
 
 var urlPattern string
+var exportFormat string
 var exportCmd = &cobra.Command{
 	Use:   "export [path to simulation]",
 	Short: "Export a simulation from Hoverfly",
 	Long: `
 Exports a simulation from Hoverfly. The simulation JSON
 will be written to the file path provided.
+
+With "--format yaml", or a path ending in .yaml or
+.yml, the simulation is written as YAML instead.
+
+With "--format har", the requests and responses in
+the journal are exported as an HTTP Archive instead.
 	`,
 
 	Run: func(cmd *cobra.Command, args []string) {
@@ -22,13 +30,39 @@ will be written to the file path provided.
 
 		checkArgAndExit(args, "You have not provided a path to simulation", "export")
 
-		simulationData, err := wrapper.ExportSimulation(*target, urlPattern)
+		if !cmd.Flags().Changed("format") && v2.IsYAMLFile(args[0]) {
+			exportFormat = "yaml"
+		}
+
+		var simulationData []byte
+		var err error
+
+		switch exportFormat {
+		case "json":
+			simulationData, err = wrapper.ExportSimulation(*target, urlPattern)
+		case "yaml":
+			simulationData, err = wrapper.ExportSimulation(*target, urlPattern)
+			if err == nil {
+				simulationData, err = v2.ConvertJSONToYAML(simulationData)
+			}
+		case "har":
+			if urlPattern != "" {
+				handleIfError(fmt.Errorf("--url-pattern cannot be used with --format har"))
+			}
+			simulationData, err = wrapper.ExportJournalHar(*target)
+		default:
+			err = fmt.Errorf("Unsupported format %s, should be json, yaml or har", exportFormat)
+		}
 		handleIfError(err)
 
 		err = configuration.WriteFile(args[0], simulationData)
 		handleIfError(err)
 
-		fmt.Println("Successfully exported simulation to", args[0])
+		if exportFormat == "har" {
+			fmt.Println("Successfully exported journal to", args[0])
+		} else {
+			fmt.Println("Successfully exported simulation to", args[0])
+		}
 	},
 }
 
@@ -36,4 +70,5 @@ func init() {
 	RootCmd.AddCommand(exportCmd)
 
 	exportCmd.Flags().StringVar(&urlPattern, "url-pattern", "", "Export simulation for the urls that matches a pattern, eg. foo.com/api/v(.+)")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "json", "Export a simulation as json or yaml, or the journal as har")
 }
@@ -1,13 +1,19 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"strings"
 
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
 	"github.com/SpectoLabs/hoverfly/hoverctl/wrapper"
 	"github.com/spf13/cobra"
 )
 
+var importFormat string
+
 // importCmd represents the import command
 var importCmd = &cobra.Command{
 	Use:   "import [path to simulation]",
@@ -18,14 +24,47 @@ simulation data will be replaced. An absolute or
 relative path to a Hoverfly simulation JSON file
 must be provided. To add multiple simulations,
 use "hoverctl simulation add [paths]" instead.
+
+A simulation can also be written in YAML, which is
+used for files ending in .yaml or .yml, or with
+"--format yaml". A response can load its body from
+another file with "bodyFile", a path relative to
+the simulation.
+
+With "--format har", an HTTP Archive is imported
+instead, with a request matcher and response
+created for each of its entries.
 	`,
 
 	Run: func(cmd *cobra.Command, args []string) {
 		checkTargetAndExit(target)
 
 		checkArgAndExit(args, "You have not provided a path to simulation", "import")
-		simulationData, err := configuration.ReadFile(args[0])
-		handleIfError(err)
+
+		if !cmd.Flags().Changed("format") && v2.IsYAMLFile(args[0]) {
+			importFormat = "yaml"
+		}
+
+		var simulationData []byte
+		var err error
+
+		switch importFormat {
+		case "json", "yaml":
+			simulationData, err = readSimulation(args[0], importFormat == "yaml")
+			handleIfError(err)
+		case "har":
+			simulationData, err = configuration.ReadFile(args[0])
+			handleIfError(err)
+
+			var har struct {
+				Log *json.RawMessage `json:"log"`
+			}
+			if json.Unmarshal(simulationData, &har) != nil || har.Log == nil {
+				handleIfError(fmt.Errorf("%s is not a HAR file", args[0]))
+			}
+		default:
+			handleIfError(fmt.Errorf("Unsupported format %s, should be json, yaml or har", importFormat))
+		}
 
 		err = wrapper.ImportSimulation(*target, string(simulationData))
 		handleIfError(err)
@@ -36,4 +75,34 @@ use "hoverctl simulation add [paths]" instead.
 
 func init() {
 	RootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().StringVar(&importFormat, "format", "json", "Import a simulation as json or yaml, or an HTTP Archive as har")
+}
+
+// readSimulation reads a simulation file, converting it to JSON when it is written in YAML
+// and loading any bodies which are kept in files next to it
+func readSimulation(path string, isYAML bool) ([]byte, error) {
+	simulationData, err := configuration.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if isYAML {
+		simulationData, err = v2.ConvertYAMLToJSON(simulationData)
+		if err != nil {
+			return nil, fmt.Errorf("Could not read %s: %s", path, err.Error())
+		}
+	}
+
+	dir := filepath.Dir(path)
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		dir = ""
+	}
+
+	simulationData, err = v2.ResolveBodyFiles(simulationData, dir)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read %s: %s", path, err.Error())
+	}
+
+	return simulationData, nil
 }
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/hoverctl/wrapper"
+	"github.com/spf13/cobra"
+)
+
+var verifyRequestFile string
+var verifyMethod, verifyScheme, verifyDestination, verifyPath, verifyQuery, verifyBody string
+var verifyHeaders []string
+var verifyExactly, verifyAtLeast, verifyAtMost int
+var verifyNever bool
+
+var journalCmd = &cobra.Command{
+	Use:   "journal",
+	Short: "Manage the journal for Hoverfly",
+	Long: `
+This allows you to check the requests stored
+in the journal of Hoverfly.
+	`,
+}
+
+var verifyJournalCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verifies how many times a request was made",
+	Long: `
+Verifies that the journal of Hoverfly has the
+expected number of requests matching the given
+fields. Fields are matched using globs, or a
+request matcher can be read from a JSON file.
+
+If no count is given, the request is expected
+to have been made at least once. Exits with a
+non-zero status if verification fails.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkTargetAndExit(target)
+
+		journalVerifyView := v2.JournalVerifyView{
+			Request: getVerifyRequestMatcher(),
+		}
+
+		count := &v2.CountConstraintView{
+			Never: verifyNever,
+		}
+		if cmd.Flags().Changed("exactly") {
+			count.Exactly = &verifyExactly
+		}
+		if cmd.Flags().Changed("at-least") {
+			count.AtLeast = &verifyAtLeast
+		}
+		if cmd.Flags().Changed("at-most") {
+			count.AtMost = &verifyAtMost
+		}
+		if count.Never || count.Exactly != nil || count.AtLeast != nil || count.AtMost != nil {
+			journalVerifyView.Count = count
+		}
+
+		result, err := wrapper.VerifyJournal(*target, journalVerifyView)
+		handleIfError(err)
+
+		if result.Passed {
+			fmt.Println(result.Message)
+		} else {
+			fmt.Fprintln(os.Stderr, result.Message)
+			if len(result.ClosestEntries) > 0 {
+				fmt.Fprintln(os.Stderr, "\nThe closest requests in the journal were:")
+				for _, closestEntry := range result.ClosestEntries {
+					request := closestEntry.Entry.Request
+					fmt.Fprintf(os.Stderr, "\n %s %s://%s%s at %s\n Did not match on: %s\n",
+						stringOrEmpty(request.Method), stringOrEmpty(request.Scheme), stringOrEmpty(request.Destination),
+						stringOrEmpty(request.Path), closestEntry.Entry.TimeStarted,
+						strings.Join(closestEntry.MissedFields, ", "))
+				}
+			}
+			os.Exit(1)
+		}
+	},
+}
+
+func getVerifyRequestMatcher() *v2.RequestMatcherViewV5 {
+	requestMatcher := &v2.RequestMatcherViewV5{}
+
+	if verifyRequestFile != "" {
+		data, err := ioutil.ReadFile(verifyRequestFile)
+		handleIfError(err)
+		handleIfError(json.Unmarshal(data, requestMatcher))
+	}
+
+	fields := []struct {
+		value   string
+		matcher *[]v2.MatcherViewV5
+	}{
+		{verifyMethod, &requestMatcher.Method},
+		{verifyScheme, &requestMatcher.Scheme},
+		{verifyDestination, &requestMatcher.Destination},
+		{verifyPath, &requestMatcher.Path},
+		{verifyQuery, &requestMatcher.DeprecatedQuery},
+		{verifyBody, &requestMatcher.Body},
+	}
+	for _, field := range fields {
+		if field.value != "" {
+			*field.matcher = []v2.MatcherViewV5{v2.NewMatcherView(matchers.Glob, field.value)}
+		}
+	}
+
+	for _, header := range verifyHeaders {
+		headerParts := strings.SplitN(header, ":", 2)
+		if len(headerParts) != 2 {
+			handleIfError(fmt.Errorf("Header '%s' should be in the form 'Name: value'", header))
+		}
+		if requestMatcher.Headers == nil {
+			requestMatcher.Headers = map[string][]v2.MatcherViewV5{}
+		}
+		requestMatcher.Headers[strings.TrimSpace(headerParts[0])] = []v2.MatcherViewV5{
+			v2.NewMatcherView(matchers.Glob, strings.TrimSpace(headerParts[1])),
+		}
+	}
+
+	return requestMatcher
+}
+
+func stringOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func init() {
+	RootCmd.AddCommand(journalCmd)
+	journalCmd.AddCommand(verifyJournalCmd)
+
+	verifyJournalCmd.Flags().StringVar(&verifyRequestFile, "request-file", "",
+		"A JSON file containing a request matcher, which the other request flags are added to")
+	verifyJournalCmd.Flags().StringVar(&verifyMethod, "method", "", "Glob matching the method of the request")
+	verifyJournalCmd.Flags().StringVar(&verifyScheme, "scheme", "", "Glob matching the scheme of the request")
+	verifyJournalCmd.Flags().StringVar(&verifyDestination, "destination", "", "Glob matching the destination of the request")
+	verifyJournalCmd.Flags().StringVar(&verifyPath, "path", "", "Glob matching the path of the request")
+	verifyJournalCmd.Flags().StringVar(&verifyQuery, "query", "", "Glob matching the query string of the request")
+	verifyJournalCmd.Flags().StringVar(&verifyBody, "body", "", "Glob matching the body of the request")
+	verifyJournalCmd.Flags().StringSliceVar(&verifyHeaders, "header", []string{},
+		"Glob matching a header of the request `Name: value`, can be given more than once")
+
+	verifyJournalCmd.Flags().IntVar(&verifyExactly, "exactly", 0, "The request should have been made exactly this many times")
+	verifyJournalCmd.Flags().IntVar(&verifyAtLeast, "at-least", 0, "The request should have been made at least this many times")
+	verifyJournalCmd.Flags().IntVar(&verifyAtMost, "at-most", 0, "The request should have been made at most this many times")
+	verifyJournalCmd.Flags().BoolVar(&verifyNever, "never", false, "The request should never have been made")
+}
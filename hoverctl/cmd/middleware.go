@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
@@ -10,7 +12,11 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var middlewareBinary, middlewareScript, middlewareRemote string
+var middlewareBinary, middlewareScript, middlewareRemote, middlewareGrpc, middlewareJavascript string
+var middlewareWorkers int
+var middlewareTimeout time.Duration
+var middlewareChainPhase, middlewareChainDestination string
+var middlewareChainModes []string
 
 var middlewareCmd = &cobra.Command{
 	Use:   "middleware",
@@ -22,6 +28,16 @@ combinations of flags:
 	--binary
 	--binary --script
 	--remote
+	--grpc
+	--javascript
+
+JavaScript middleware is run by Hoverfly itself, with
+--timeout as its execution time limit.
+
+Local middleware is started for every request unless
+--workers is used, in which case that many middleware
+processes are kept running and are sent one request per
+line. --timeout kills middleware that takes too long.
 
 If flags are not used, the current Hoverfly middleware
 configuration will be shown.
@@ -33,61 +49,264 @@ configuration will be shown.
 
 		var middleware v2.MiddlewareView
 		var err error
-		if middlewareBinary == "" && middlewareScript == "" && middlewareRemote == "" {
+		if middlewareBinary == "" && middlewareScript == "" && middlewareRemote == "" && middlewareGrpc == "" && middlewareJavascript == "" {
 			middleware, err = wrapper.GetMiddleware(*target)
 			handleIfError(err)
 			fmt.Println("Hoverfly middleware configuration is currently set to")
 		} else {
-			if middlewareRemote != "" {
-				fmt.Println("Testing middleware against Hoverfly...")
-				middleware, err = wrapper.SetMiddleware(*target, "", "", middlewareRemote)
-				handleIfError(err)
-				fmt.Println("Hoverfly middleware configuration has been set to")
-			} else {
-				var script []byte
-				if middlewareScript != "" {
-					script, err = configuration.ReadFile(middlewareScript)
-					handleIfError(err)
-				}
-
-				fmt.Println("Testing middleware against Hoverfly...")
-				middleware, err = wrapper.SetMiddleware(*target, middlewareBinary, string(script), "")
-				handleIfError(err)
-
-				fmt.Println("Hoverfly middleware configuration has been set to")
-			}
-		}
+			middleware, err = middlewareViewFromFlags()
+			handleIfError(err)
 
-		if middleware.Binary != "" {
-			fmt.Println("Binary: " + middleware.Binary)
+			fmt.Println("Testing middleware against Hoverfly...")
+			middleware, err = wrapper.SetMiddleware(*target, middleware)
+			handleIfError(err)
+			fmt.Println("Hoverfly middleware configuration has been set to")
 		}
 
-		if middleware.Script != "" {
-			middlewareScript := strings.Split(middleware.Script, "\n")
-			if verbose || len(middlewareScript) < 5 {
-				fmt.Println("Script: " + middleware.Script)
-			} else {
-				fmt.Println("Script: " + middlewareScript[0] + "\n" +
-					middlewareScript[1] + "\n" +
-					middlewareScript[2] + "\n" +
-					middlewareScript[3] + "\n" +
-					middlewareScript[4] + "\n" +
-					"...")
-			}
+		printMiddleware(middleware)
+	},
+}
+
+var middlewareChainCmd = &cobra.Command{
+	Use:   "chain",
+	Short: "Get the Hoverfly middleware chain",
+	Long: `
+The middleware chain is run after the middleware set
+with hoverctl middleware. Each stage of the chain is
+given the output of the stage before it, and is run in
+one phase:
+
+	pre-request    before the request is sent on, in
+	               capture and modify mode
+	post-response  once there is a response, in every
+	               mode other than capture
+	on-miss        when a request does not match the
+	               simulation, in simulate and spy mode
+
+Stages can be limited to modes and to destinations
+matching a regular expression.
+
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		checkTargetAndExit(target)
+
+		chain, err := wrapper.GetMiddlewareChain(*target)
+		handleIfError(err)
+
+		printMiddlewareChain(chain)
+	},
+}
+
+var middlewareChainAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add middleware to the end of the Hoverfly middleware chain",
+	Long: `
+Adds middleware to the end of the middleware chain. The
+middleware is set with the same flags as hoverctl
+middleware, along with the phase it is run in.
+
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		checkTargetAndExit(target)
+
+		if middlewareChainPhase == "" {
+			handleIfError(fmt.Errorf("The phase of the middleware must be set with --phase"))
 		}
 
-		if middleware.Remote != "" {
-			fmt.Println("Remote: " + middleware.Remote)
+		middleware, err := middlewareViewFromFlags()
+		handleIfError(err)
+
+		chain, err := wrapper.GetMiddlewareChain(*target)
+		handleIfError(err)
+
+		chain.Stages = append(chain.Stages, v2.MiddlewareStageView{
+			Phase:          middlewareChainPhase,
+			Modes:          middlewareChainModes,
+			Destination:    middlewareChainDestination,
+			MiddlewareView: middleware,
+		})
+
+		fmt.Println("Testing middleware against Hoverfly...")
+		chain, err = wrapper.SetMiddlewareChain(*target, chain)
+		handleIfError(err)
+
+		printMiddlewareChain(chain)
+	},
+}
+
+var middlewareChainRemoveCmd = &cobra.Command{
+	Use:   "remove [stage]",
+	Short: "Remove a stage from the Hoverfly middleware chain",
+	Long: `
+Removes a stage from the middleware chain, using the
+number shown by hoverctl middleware chain.
+
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		checkTargetAndExit(target)
+		checkArgAndExit(args, "You have not specified a stage to remove", "middleware chain remove")
+
+		chain, err := wrapper.GetMiddlewareChain(*target)
+		handleIfError(err)
+
+		stage, err := strconv.Atoi(args[0])
+		if err != nil || stage < 1 || stage > len(chain.Stages) {
+			handleIfError(fmt.Errorf("There is no stage %s in the middleware chain", args[0]))
 		}
+
+		chain.Stages = append(chain.Stages[:stage-1], chain.Stages[stage:]...)
+
+		chain, err = wrapper.SetMiddlewareChain(*target, chain)
+		handleIfError(err)
+
+		printMiddlewareChain(chain)
 	},
 }
 
+var middlewareChainClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove every stage from the Hoverfly middleware chain",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		checkTargetAndExit(target)
+
+		chain, err := wrapper.SetMiddlewareChain(*target, v2.MiddlewareChainView{})
+		handleIfError(err)
+
+		printMiddlewareChain(chain)
+	},
+}
+
+// middlewareViewFromFlags reads the middleware flags, along with the files they refer to
+func middlewareViewFromFlags() (v2.MiddlewareView, error) {
+	timeout := int(middlewareTimeout / time.Millisecond)
+
+	if middlewareRemote != "" {
+		return v2.MiddlewareView{Remote: middlewareRemote, Timeout: timeout}, nil
+	}
+
+	if middlewareGrpc != "" {
+		return v2.MiddlewareView{Grpc: middlewareGrpc, Timeout: timeout}, nil
+	}
+
+	if middlewareJavascript != "" {
+		javascript, err := configuration.ReadFile(middlewareJavascript)
+		if err != nil {
+			return v2.MiddlewareView{}, err
+		}
+
+		return v2.MiddlewareView{Javascript: string(javascript), Timeout: timeout}, nil
+	}
+
+	var script []byte
+	if middlewareScript != "" {
+		var err error
+		script, err = configuration.ReadFile(middlewareScript)
+		if err != nil {
+			return v2.MiddlewareView{}, err
+		}
+	}
+
+	return v2.MiddlewareView{
+		Binary:  middlewareBinary,
+		Script:  string(script),
+		Workers: middlewareWorkers,
+		Timeout: timeout,
+	}, nil
+}
+
+func printMiddleware(middleware v2.MiddlewareView) {
+	if middleware.Binary != "" {
+		fmt.Println("Binary: " + middleware.Binary)
+	}
+
+	if middleware.Script != "" {
+		fmt.Println("Script: " + shortenMiddlewareScript(middleware.Script))
+	}
+
+	if middleware.Remote != "" {
+		fmt.Println("Remote: " + middleware.Remote)
+	}
+
+	if middleware.Grpc != "" {
+		fmt.Println("gRPC: " + middleware.Grpc)
+	}
+
+	if middleware.Javascript != "" {
+		fmt.Println("JavaScript: " + shortenMiddlewareScript(middleware.Javascript))
+	}
+
+	if middleware.Workers != 0 {
+		fmt.Printf("Workers: %d\n", middleware.Workers)
+	}
+
+	if middleware.Timeout != 0 {
+		fmt.Printf("Timeout: %v\n", time.Duration(middleware.Timeout)*time.Millisecond)
+	}
+}
+
+func printMiddlewareChain(chain v2.MiddlewareChainView) {
+	if len(chain.Stages) == 0 {
+		fmt.Println("There is no middleware in the Hoverfly middleware chain")
+		return
+	}
+
+	fmt.Println("Hoverfly middleware chain is currently set to")
+
+	for i, stage := range chain.Stages {
+		fmt.Printf("\n%d. Phase: %s\n", i+1, stage.Phase)
+
+		if len(stage.Modes) != 0 {
+			fmt.Println("Modes: " + strings.Join(stage.Modes, ", "))
+		}
+
+		if stage.Destination != "" {
+			fmt.Println("Destination: " + stage.Destination)
+		}
+
+		printMiddleware(stage.MiddlewareView)
+	}
+}
+
+// shortenMiddlewareScript keeps the first five lines of a script unless verbose is set
+func shortenMiddlewareScript(script string) string {
+	lines := strings.Split(script, "\n")
+	if verbose || len(lines) < 5 {
+		return script
+	}
+
+	return strings.Join(lines[:5], "\n") + "\n..."
+}
+
 func init() {
 	RootCmd.AddCommand(middlewareCmd)
+	middlewareCmd.AddCommand(middlewareChainCmd)
+	middlewareChainCmd.AddCommand(middlewareChainAddCmd)
+	middlewareChainCmd.AddCommand(middlewareChainRemoveCmd)
+	middlewareChainCmd.AddCommand(middlewareChainClearCmd)
+
 	middlewareCmd.PersistentFlags().StringVar(&middlewareBinary, "binary", "",
 		"An absolute or relative path to a binary that Hoverfly will execute as middleware")
 	middlewareCmd.PersistentFlags().StringVar(&middlewareScript, "script", "",
 		"An absolute or relative path to a script that will be executed by the middleware binary")
 	middlewareCmd.PersistentFlags().StringVar(&middlewareRemote, "remote", "",
 		"A URL to a remote address that will be called by Hoverfly as middleware")
+	middlewareCmd.PersistentFlags().StringVar(&middlewareGrpc, "grpc", "",
+		"The address of gRPC middleware that will be called by Hoverfly (i.e. grpc://localhost:50051)")
+	middlewareCmd.PersistentFlags().StringVar(&middlewareJavascript, "javascript", "",
+		"An absolute or relative path to a JavaScript file that Hoverfly will run itself as middleware")
+	middlewareCmd.PersistentFlags().IntVar(&middlewareWorkers, "workers", 0,
+		"Keep this many middleware processes running instead of starting the middleware for every request")
+	middlewareCmd.PersistentFlags().DurationVar(&middlewareTimeout, "timeout", 0,
+		"Kill middleware that takes longer than this to process a request (i.e. 5s)")
+
+	middlewareChainAddCmd.Flags().StringVar(&middlewareChainPhase, "phase", "",
+		"The phase the middleware is run in, one of pre-request, post-response or on-miss")
+	middlewareChainAddCmd.Flags().StringSliceVar(&middlewareChainModes, "modes", []string{},
+		"Only run the middleware in these modes, it is run in every mode by default")
+	middlewareChainAddCmd.Flags().StringVar(&middlewareChainDestination, "destination", "",
+		"Only run the middleware for destinations matching this regular expression")
 }
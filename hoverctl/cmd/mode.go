@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
@@ -14,6 +15,11 @@ var specificHeaders string
 var allHeaders bool
 var stateful bool
 var matchingStrategy string
+var pathMatcher string
+var bodyMatcher string
+var ignoreJsonPaths []string
+var ignoreQueryKeys []string
+var diffRules []v2.DiffRuleView
 
 var modeCmd = &cobra.Command{
 	Use:   "mode [capture|diff|simulate|spy|modify|synthesize (optional)]",
@@ -48,10 +54,15 @@ mode is shown.
 				break
 			case modes.Capture:
 				modeView.Arguments.Stateful = stateful
+				modeView.Arguments.PathMatcher = pathMatcher
+				modeView.Arguments.BodyMatcher = bodyMatcher
+				modeView.Arguments.IgnoreJsonPaths = ignoreJsonPaths
+				modeView.Arguments.IgnoreQueryKeys = ignoreQueryKeys
 				setHeaderArgument(modeView)
 				break
 			case modes.Diff:
 				setHeaderArgument(modeView)
+				modeView.Arguments.DiffRules = diffRules
 				break
 			}
 
@@ -88,6 +99,10 @@ func getExtraInfo(mode *v2.ModeView) string {
 				extraInfo = fmt.Sprintf("and will capture the following request headers: %s", mode.Arguments.Headers)
 			}
 		}
+		if len(mode.Arguments.PathMatcher) > 0 || len(mode.Arguments.BodyMatcher) > 0 {
+			extraInfo = strings.TrimSpace(fmt.Sprintf("%s and will match paths with '%s' and bodies with '%s'", extraInfo,
+				getMatcherOrDefault(mode.Arguments.PathMatcher, "exact"), getMatcherOrDefault(mode.Arguments.BodyMatcher, "content type")))
+		}
 		break
 	case modes.Diff:
 		if len(mode.Arguments.Headers) > 0 {
@@ -97,12 +112,84 @@ func getExtraInfo(mode *v2.ModeView) string {
 				extraInfo = fmt.Sprintf("and will exclude the following response headers from diffing: %s", mode.Arguments.Headers)
 			}
 		}
+		if len(mode.Arguments.DiffRules) > 0 {
+			extraInfo = strings.TrimSpace(fmt.Sprintf("%s and will apply %d diff rules", extraInfo, len(mode.Arguments.DiffRules)))
+		}
 		break
 	}
 
 	return extraInfo
 }
 
+// diffRuleFlag adds a diff rule each time its flag is given. Tolerance and regex
+// rules are given as path=value.
+type diffRuleFlag string
+
+func (this diffRuleFlag) String() string {
+	return ""
+}
+
+func (this diffRuleFlag) Type() string {
+	return "string"
+}
+
+func (this diffRuleFlag) Set(value string) error {
+	rule := v2.DiffRuleView{Path: value}
+
+	switch this {
+	case "ignore":
+		rule.Ignore = true
+	case "unordered":
+		rule.Unordered = true
+	default:
+		path, argument, ok := splitDiffRule(value)
+		if !ok {
+			return fmt.Errorf("should be in the form path=%s", this)
+		}
+		rule.Path = path
+
+		if this == "regex" {
+			rule.Regex = argument
+		} else {
+			tolerance, err := strconv.ParseFloat(argument, 64)
+			if err != nil {
+				return fmt.Errorf("%s is not a number", argument)
+			}
+			rule.Tolerance = tolerance
+		}
+	}
+
+	diffRules = append(diffRules, rule)
+	return nil
+}
+
+// splitDiffRule splits at the first = which is not inside the brackets of a path,
+// as XPath predicates can also contain =
+func splitDiffRule(value string) (string, string, bool) {
+	depth := 0
+	for i, character := range value {
+		switch character {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '=':
+			if depth == 0 {
+				return value[:i], value[i+1:], true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+func getMatcherOrDefault(matcher, defaultMatcher string) string {
+	if len(matcher) == 0 {
+		return defaultMatcher
+	}
+	return matcher
+}
+
 func init() {
 
 	RootCmd.AddCommand(modeCmd)
@@ -114,4 +201,20 @@ func init() {
 		"Sets the matching strategy - 'strongest | first'")
 	modeCmd.PersistentFlags().BoolVar(&stateful, "stateful", false,
 		"Record stateful responses as a sequence in capture mode")
+	modeCmd.PersistentFlags().StringVar(&pathMatcher, "path-matcher", "",
+		"Sets the matcher for captured paths in capture mode - 'exact | glob'")
+	modeCmd.PersistentFlags().StringVar(&bodyMatcher, "body-matcher", "",
+		"Sets the matcher for captured bodies in capture mode - 'exact | json | jsonpartial | xml'")
+	modeCmd.PersistentFlags().StringSliceVar(&ignoreJsonPaths, "ignore-json-path", []string{},
+		"A JSON path to leave out of captured bodies in capture mode, can be given more than once `$.timestamp`")
+	modeCmd.PersistentFlags().StringSliceVar(&ignoreQueryKeys, "ignore-query-key", []string{},
+		"A query key to leave out of captured queries in capture mode, can be given more than once `nonce`")
+	modeCmd.PersistentFlags().Var(diffRuleFlag("ignore"), "diff-ignore",
+		"A JSON path or XPath to leave out of diffs in diff mode, can be given more than once `$.timestamp`")
+	modeCmd.PersistentFlags().Var(diffRuleFlag("tolerance"), "diff-tolerance",
+		"A JSON path or XPath of numbers which can differ by up to a tolerance in diff mode, can be given more than once `$.price=0.01`")
+	modeCmd.PersistentFlags().Var(diffRuleFlag("unordered"), "diff-unordered",
+		"A JSON path or XPath of arrays or elements whose items can be in any order in diff mode, can be given more than once `$.items`")
+	modeCmd.PersistentFlags().Var(diffRuleFlag("regex"), "diff-regex",
+		"A JSON path or XPath of values which should match a regex in diff mode, can be given more than once `$.id=^[0-9a-f-]+$`")
 }
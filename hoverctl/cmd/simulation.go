@@ -2,7 +2,10 @@ package cmd
 
 import (
 	"fmt"
-	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
+	"sort"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/hoverctl/wrapper"
 	"github.com/spf13/cobra"
 )
@@ -27,7 +30,8 @@ to those in the existing data will be discarded with a
 warning message. 
 
 You may provide an absolute or relative path to each 
-simulation file.
+simulation file. Files ending in .yaml or .yml are 
+read as YAML.
 	`,
 	Run: func(cmd *cobra.Command, args []string) {
 
@@ -37,7 +41,7 @@ simulation file.
 
 		for _, arg := range args {
 
-			simulationData, err := configuration.ReadFile(arg)
+			simulationData, err := readSimulation(arg, v2.IsYAMLFile(arg))
 			handleIfError(err)
 
 			err = wrapper.AddSimulation(*target, string(simulationData))
@@ -48,7 +52,87 @@ simulation file.
 	},
 }
 
+var compactDryRun bool
+
+var compactSimulationCmd = &cobra.Command{
+	Use:   "compact",
+	Short: "Merge similar request/response pairs in Hoverfly",
+	Long: `
+Merges request/response pairs which were captured from 
+requests that only differ in their IDs, query and header 
+values or JSON body fields. Each group of pairs is 
+replaced by one pair using glob and jsonpartial 
+matchers, with a templated response when possible.
+
+Use --dry-run to see the pairs which would be merged 
+without changing the simulation.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkTargetAndExit(target)
+
+		compactView, err := wrapper.CompactSimulation(*target, compactDryRun)
+		handleIfError(err)
+
+		if len(compactView.Merges) == 0 {
+			fmt.Println("There are no request/response pairs which can be compacted")
+			return
+		}
+
+		for _, merge := range compactView.Merges {
+			for _, replaced := range merge.Replaces {
+				fmt.Println("-", describePair(replaced))
+			}
+			fmt.Println("+", describePair(merge.Pair))
+			fmt.Println()
+		}
+
+		if compactView.DryRun {
+			fmt.Printf("The simulation would be compacted from %d to %d request/response pairs\n", compactView.PairsBefore, compactView.PairsAfter)
+		} else {
+			fmt.Printf("The simulation has been compacted from %d to %d request/response pairs\n", compactView.PairsBefore, compactView.PairsAfter)
+		}
+	},
+}
+
+func describePair(pair v2.RequestMatcherResponsePairViewV5) string {
+	request := pair.RequestMatcher
+
+	description := fmt.Sprintf("%s %s://%s%s", describeField(request.Method, "*"), describeField(request.Scheme, "*"),
+		describeField(request.Destination, "*"), describeField(request.Path, "/*"))
+
+	if request.Query != nil {
+		queries := []string{}
+		for key, matchers := range *request.Query {
+			queries = append(queries, key+"="+describeField(matchers, "*"))
+		}
+		sort.Strings(queries)
+		description += "?" + strings.Join(queries, "&")
+	}
+
+	if len(describeField(request.Body, "")) > 0 {
+		description += fmt.Sprintf(" with body %s '%s'", request.Body[0].Matcher, describeField(request.Body, ""))
+	}
+
+	if pair.Response.Templated {
+		description += " (templated response)"
+	}
+
+	return description
+}
+
+func describeField(matchers []v2.MatcherViewV5, missing string) string {
+	if len(matchers) == 0 {
+		return missing
+	}
+
+	return fmt.Sprint(matchers[0].Value)
+}
+
 func init() {
 	RootCmd.AddCommand(simulationCmd)
 	simulationCmd.AddCommand(addSimulationCmd)
+	simulationCmd.AddCommand(compactSimulationCmd)
+
+	compactSimulationCmd.Flags().BoolVar(&compactDryRun, "dry-run", false,
+		"Show the request/response pairs which would be merged without changing the simulation")
 }
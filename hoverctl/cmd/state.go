@@ -2,13 +2,19 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"os"
 
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/hoverctl/wrapper"
 	"github.com/spf13/cobra"
 )
 
+var scenarioStates []string
+var scenarioTransitions []string
+
 var stateCmd = &cobra.Command{
 	Use:     "state",
 	Aliases: []string{"state-store"},
@@ -123,10 +129,162 @@ Provide two arguments, the state key and the state value.
 	},
 }
 
+var getScenarioCmd = &cobra.Command{
+	Use:   "get-scenario",
+	Short: "Gets the state of a scenario",
+	Long: `
+Returns the state of a scenario, along with the states
+and transitions declared for it.
+
+Provide a single argument, the name of the scenario.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		checkTargetAndExit(target)
+
+		if len(args) != 1 {
+			fmt.Fprintln(os.Stderr, "You must provide the name of a scenario as an argument")
+			fmt.Fprintln(os.Stderr, "\nTry hoverctl state get-scenario --help for more information")
+			os.Exit(1)
+		}
+
+		scenario, err := wrapper.GetScenario(*target, args[0])
+		handleIfError(err)
+
+		fmt.Println(describeScenario(args[0], *scenario))
+	},
+}
+
+var setScenarioCmd = &cobra.Command{
+	Use:   "set-scenario",
+	Short: "Sets the state of a scenario",
+	Long: `
+Sets a key in the state of a scenario.
+
+Provide three arguments, the name of the scenario, the
+state key and the state value, separated by spaces. The
+states and transitions allowed by the scenario can be
+declared with the --states and --transition flags.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		checkTargetAndExit(target)
+
+		if len(args) != 1 && len(args) != 3 {
+			fmt.Fprintln(os.Stderr, "You must provide the name of a scenario, and optionally a key and a value, separated by spaces")
+			fmt.Fprintln(os.Stderr, "\nTry hoverctl state set-scenario --help for more information")
+			os.Exit(1)
+		}
+
+		scenario, err := wrapper.GetScenario(*target, args[0])
+		handleIfError(err)
+
+		if cmd.Flags().Changed("states") {
+			scenario.States = scenarioStates
+		}
+		if cmd.Flags().Changed("transition") {
+			scenario.Transitions = []v2.TransitionView{}
+			for _, transition := range scenarioTransitions {
+				fromAndTo := strings.SplitN(transition, ":", 2)
+				if len(fromAndTo) != 2 {
+					handleIfError(fmt.Errorf("A transition must be given as from:to, not %s", transition))
+				}
+				scenario.Transitions = append(scenario.Transitions, v2.TransitionView{
+					From: fromAndTo[0],
+					To:   fromAndTo[1],
+				})
+			}
+		}
+		if len(args) == 3 {
+			if scenario.State == nil {
+				scenario.State = map[string]string{}
+			}
+			scenario.State[args[1]] = args[2]
+		}
+
+		err = wrapper.SetScenario(*target, args[0], *scenario)
+		handleIfError(err)
+
+		fmt.Println("Successfully set scenario " + args[0])
+	},
+}
+
+var resetScenarioCmd = &cobra.Command{
+	Use:   "reset-scenario",
+	Short: "Resets the state of a scenario",
+	Long: `
+Removes every key from the state of a scenario. The
+states and transitions declared for it are kept.
+
+Provide a single argument, the name of the scenario.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		checkTargetAndExit(target)
+
+		if len(args) != 1 {
+			fmt.Fprintln(os.Stderr, "You must provide the name of a scenario as an argument")
+			fmt.Fprintln(os.Stderr, "\nTry hoverctl state reset-scenario --help for more information")
+			os.Exit(1)
+		}
+
+		scenario, err := wrapper.GetScenario(*target, args[0])
+		handleIfError(err)
+
+		scenario.State = map[string]string{}
+
+		err = wrapper.SetScenario(*target, args[0], *scenario)
+		handleIfError(err)
+
+		fmt.Println("Scenario " + args[0] + " has been reset")
+	},
+}
+
 func init() {
 	RootCmd.AddCommand(stateCmd)
 	stateCmd.AddCommand(getStateCmd)
 	stateCmd.AddCommand(getAllStateCmd)
 	stateCmd.AddCommand(setStateCmd)
 	stateCmd.AddCommand(deleteStateCmd)
+	stateCmd.AddCommand(getScenarioCmd)
+	stateCmd.AddCommand(setScenarioCmd)
+	stateCmd.AddCommand(resetScenarioCmd)
+
+	setScenarioCmd.Flags().StringSliceVar(&scenarioStates, "states", []string{},
+		"The states which keys in the scenario are allowed to have, separated by commas")
+	setScenarioCmd.Flags().StringSliceVar(&scenarioTransitions, "transition", []string{},
+		"A transition the scenario allows, given as from:to. Leave from empty for a key which is not set")
+}
+
+func describeScenario(name string, scenario v2.ScenarioView) string {
+	output := ""
+
+	keys := []string{}
+	for key := range scenario.State {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		output = output + "\n\"" + key + "\"=\"" + scenario.State[key] + "\""
+	}
+
+	if output == "" {
+		output = "The state for the scenario " + name + " is empty"
+	} else {
+		output = "State of scenario " + name + ":" + output
+	}
+
+	if len(scenario.States) > 0 {
+		output = output + "\n\nStates: " + strings.Join(scenario.States, ", ")
+	}
+
+	if len(scenario.Transitions) > 0 {
+		output = output + "\n\nTransitions:"
+		for _, transition := range scenario.Transitions {
+			output = output + "\n\"" + transition.From + "\" -> \"" + transition.To + "\""
+		}
+	}
+
+	return output
 }
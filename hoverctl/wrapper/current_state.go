@@ -58,3 +58,42 @@ func DeleteCurrentState(target configuration.Target) error {
 
 	return err
 }
+
+func GetScenario(target configuration.Target, name string) (*v2.ScenarioView, error) {
+	response, err := doRequest(target, "GET", v2ApiScenarios+"/"+name, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	err = handleResponseError(response, "Could not retrieve scenario")
+	if err != nil {
+		return nil, err
+	}
+
+	var scenarioView v2.ScenarioView
+
+	err = UnmarshalToInterface(response, &scenarioView)
+	if err != nil {
+		return nil, err
+	}
+
+	return &scenarioView, nil
+}
+
+func SetScenario(target configuration.Target, name string, scenarioView v2.ScenarioView) error {
+	marshal, err := json.Marshal(scenarioView)
+	if err != nil {
+		return err
+	}
+
+	response, err := doRequest(target, "PUT", v2ApiScenarios+"/"+name, string(marshal), nil)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	return handleResponseError(response, "Could not set scenario")
+}
@@ -0,0 +1,154 @@
+package wrapper
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func Test_GetScenario_GetsScenarioFromHoverfly(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "GET",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/scenarios/checkout",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 200,
+						Body:   `{"state": {"basket": "full"}, "states": ["empty", "full"]}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	scenario, err := GetScenario(target, "checkout")
+	Expect(err).To(BeNil())
+
+	Expect(*scenario).To(Equal(v2.ScenarioView{
+		State:  map[string]string{"basket": "full"},
+		States: []string{"empty", "full"},
+	}))
+}
+
+func Test_GetScenario_ErrorsWhen_HoverflyNotAccessible(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := GetScenario(inaccessibleTarget, "checkout")
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not connect to Hoverfly at something:1234"))
+}
+
+func Test_SetScenario_SendsCorrectHTTPRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "PUT",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/scenarios/checkout",
+							},
+						},
+						Body: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Json,
+								Value:   `{"state": {"basket": "empty"}, "transitions": [{"from": "empty", "to": "full"}]}`,
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 200,
+						Body:   `{"state": {"basket": "empty"}, "transitions": [{"from": "empty", "to": "full"}]}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	err := SetScenario(target, "checkout", v2.ScenarioView{
+		State: map[string]string{"basket": "empty"},
+		Transitions: []v2.TransitionView{
+			{
+				From: "empty",
+				To:   "full",
+			},
+		},
+	})
+	Expect(err).To(BeNil())
+}
+
+func Test_SetScenario_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "PUT",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/scenarios/checkout",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 400,
+						Body:   `{"error": "overflowing is not one of the states declared for basket"}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	err := SetScenario(target, "checkout", v2.ScenarioView{
+		State: map[string]string{"basket": "overflowing"},
+	})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not set scenario\n\noverflowing is not one of the states declared for basket"))
+}
@@ -35,6 +35,46 @@ func GetAllDiffs(target configuration.Target) ([]v2.ResponseDiffForRequestView,
 	return diffs.Diff, nil
 }
 
+// ExportDiffsJUnit gets the diffs as a JUnit XML report, with a test case for each request
+func ExportDiffsJUnit(target configuration.Target) ([]byte, error) {
+	response, err := doRequest(target, "GET", v2ApiDiff+"?format=junit", "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	err = handleResponseError(response, "Could not retrieve diffs")
+	if err != nil {
+		return nil, err
+	}
+
+	return ioutil.ReadAll(response.Body)
+}
+
+// GetDiffSummary gets how many responses have been compared and how many of them differed
+func GetDiffSummary(target configuration.Target) (*v2.DiffSummaryView, error) {
+	response, err := doRequest(target, "GET", v2ApiDiffSummary, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	err = handleResponseError(response, "Could not retrieve diffs")
+	if err != nil {
+		return nil, err
+	}
+
+	var summaryView v2.DiffSummaryView
+	err = UnmarshalToInterface(response, &summaryView)
+	if err != nil {
+		return nil, err
+	}
+
+	return &summaryView, nil
+}
+
 func DeleteAllDiffs(target configuration.Target) error {
 
 	_, err := doRequest(target, "DELETE", v2ApiDiff, "", nil)
@@ -20,16 +20,23 @@ import (
 )
 
 const (
-	v2ApiSimulation  = "/api/v2/simulation"
-	v2ApiMode        = "/api/v2/hoverfly/mode"
-	v2ApiDestination = "/api/v2/hoverfly/destination"
-	v2ApiState       = "/api/v2/state"
-	v2ApiMiddleware  = "/api/v2/hoverfly/middleware"
-	v2ApiPac         = "/api/v2/hoverfly/pac"
-	v2ApiCache       = "/api/v2/cache"
-	v2ApiLogs        = "/api/v2/logs"
-	v2ApiHoverfly    = "/api/v2/hoverfly"
-	v2ApiDiff        = "/api/v2/diff"
+	v2ApiSimulation        = "/api/v2/simulation"
+	v2ApiSimulationCompact = "/api/v2/simulation/compact"
+	v2ApiMode              = "/api/v2/hoverfly/mode"
+	v2ApiDestination       = "/api/v2/hoverfly/destination"
+	v2ApiState             = "/api/v2/state"
+	v2ApiScenarios         = "/api/v2/scenarios"
+	v2ApiMiddleware        = "/api/v2/hoverfly/middleware"
+	v2ApiMiddlewareChain   = "/api/v2/hoverfly/middleware/chain"
+	v2ApiPac               = "/api/v2/hoverfly/pac"
+	v2ApiCache             = "/api/v2/cache"
+	v2ApiLogs              = "/api/v2/logs"
+	v2ApiHoverfly          = "/api/v2/hoverfly"
+	v2ApiDiff              = "/api/v2/diff"
+	v2ApiDiffSummary       = "/api/v2/diff/summary"
+
+	v2ApiJournal       = "/api/v2/journal"
+	v2ApiJournalVerify = "/api/v2/journal/verify"
 
 	v2ApiShutdown = "/api/v2/shutdown"
 	v2ApiHealth   = "/api/health"
@@ -0,0 +1,71 @@
+package wrapper
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
+)
+
+// VerifyJournal will go the journal verify endpoint in Hoverfly, sending JSON that describes the
+// request and how many times it should have been made, and return whether it was
+func VerifyJournal(target configuration.Target, journalVerifyView v2.JournalVerifyView) (*v2.JournalVerifyResultView, error) {
+	bytes, err := json.Marshal(journalVerifyView)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := doRequest(target, "POST", v2ApiJournalVerify, string(bytes), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = handleResponseError(response, "Could not verify journal")
+	if err != nil {
+		return nil, err
+	}
+
+	var resultView v2.JournalVerifyResultView
+
+	err = UnmarshalToInterface(response, &resultView)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resultView, nil
+}
+
+// ExportJournalHar will get every entry in the journal of Hoverfly as an HTTP Archive
+func ExportJournalHar(target configuration.Target) ([]byte, error) {
+	response, err := doRequest(target, "GET", v2ApiJournal+"?format=har", "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	err = handleResponseError(response, "Could not retrieve journal")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		log.Debug(err.Error())
+		return nil, errors.New("Could not export from Hoverfly")
+	}
+
+	var harBytes bytes.Buffer
+	err = json.Indent(&harBytes, body, "", "\t")
+	if err != nil {
+		log.Debug(err.Error())
+		return nil, errors.New("Could not export from Hoverfly")
+	}
+
+	return harBytes.Bytes(), nil
+}
@@ -0,0 +1,178 @@
+package wrapper
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func Test_VerifyJournal_SendsCorrectHTTPRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "POST",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/journal/verify",
+							},
+						},
+						Body: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Json,
+								Value:   `{"request":{"path":[{"matcher":"exact","value":"/path"}]},"count":{"exactly":2}}`,
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 200,
+						Body:   `{"passed":false,"message":"Expected request to be made exactly 2 times, but it was made once","count":1,"matches":[{"mode":"simulate"}]}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	exactly := 2
+	result, err := VerifyJournal(target, v2.JournalVerifyView{
+		Request: &v2.RequestMatcherViewV5{
+			Path: []v2.MatcherViewV5{
+				v2.NewMatcherView(matchers.Exact, "/path"),
+			},
+		},
+		Count: &v2.CountConstraintView{
+			Exactly: &exactly,
+		},
+	})
+	Expect(err).To(BeNil())
+
+	Expect(result.Passed).To(BeFalse())
+	Expect(result.Message).To(Equal("Expected request to be made exactly 2 times, but it was made once"))
+	Expect(result.Count).To(Equal(1))
+	Expect(result.Matches).To(HaveLen(1))
+	Expect(result.Matches[0].Mode).To(Equal("simulate"))
+}
+
+func Test_VerifyJournal_ErrorsWhen_HoverflyNotAccessible(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := VerifyJournal(inaccessibleTarget, v2.JournalVerifyView{
+		Request: &v2.RequestMatcherViewV5{},
+	})
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not connect to Hoverfly at something:1234"))
+}
+
+func Test_VerifyJournal_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "POST",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/journal/verify",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 400,
+						Body:   `{"error": "test error"}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	_, err := VerifyJournal(target, v2.JournalVerifyView{
+		Request: &v2.RequestMatcherViewV5{},
+	})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not verify journal\n\ntest error"))
+}
+
+func Test_ExportJournalHar_SendsCorrectHTTPRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "GET",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/journal",
+							},
+						},
+						Query: &v2.QueryMatcherViewV5{
+							"format": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "har",
+								},
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 200,
+						Body:   `{"log":{"version":"1.2"}}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	har, err := ExportJournalHar(target)
+	Expect(err).To(BeNil())
+
+	Expect(string(har)).To(Equal("{\n\t\"log\": {\n\t\t\"version\": \"1.2\"\n\t}\n}"))
+}
+
+func Test_ExportJournalHar_ErrorsWhen_HoverflyNotAccessible(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := ExportJournalHar(inaccessibleTarget)
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not connect to Hoverfly at something:1234"))
+}
@@ -31,13 +31,7 @@ func GetMiddleware(target configuration.Target) (v2.MiddlewareView, error) {
 	return middlewareView, nil
 }
 
-func SetMiddleware(target configuration.Target, binary, script, remote string) (v2.MiddlewareView, error) {
-	middlewareRequest := &v2.MiddlewareView{
-		Binary: binary,
-		Script: script,
-		Remote: remote,
-	}
-
+func SetMiddleware(target configuration.Target, middlewareRequest v2.MiddlewareView) (v2.MiddlewareView, error) {
 	marshalledMiddleware, err := json.Marshal(middlewareRequest)
 	if err != nil {
 		return v2.MiddlewareView{}, err
@@ -64,3 +58,56 @@ func SetMiddleware(target configuration.Target, binary, script, remote string) (
 
 	return middlewareView, nil
 }
+
+// GetMiddlewareChain returns the stages of the middleware chain of Hoverfly, in the order they are run
+func GetMiddlewareChain(target configuration.Target) (v2.MiddlewareChainView, error) {
+	response, err := doRequest(target, "GET", v2ApiMiddlewareChain, "", nil)
+	if err != nil {
+		return v2.MiddlewareChainView{}, err
+	}
+
+	defer response.Body.Close()
+
+	err = handleResponseError(response, "Could not retrieve middleware chain")
+	if err != nil {
+		return v2.MiddlewareChainView{}, err
+	}
+
+	var chainView v2.MiddlewareChainView
+
+	err = UnmarshalToInterface(response, &chainView)
+	if err != nil {
+		return v2.MiddlewareChainView{}, err
+	}
+
+	return chainView, nil
+}
+
+// SetMiddlewareChain replaces the middleware chain of Hoverfly. Hoverfly tests the middleware of every stage first.
+func SetMiddlewareChain(target configuration.Target, chainRequest v2.MiddlewareChainView) (v2.MiddlewareChainView, error) {
+	marshalledChain, err := json.Marshal(chainRequest)
+	if err != nil {
+		return v2.MiddlewareChainView{}, err
+	}
+
+	response, err := doRequest(target, "PUT", v2ApiMiddlewareChain, string(marshalledChain), nil)
+	if err != nil {
+		return v2.MiddlewareChainView{}, err
+	}
+
+	defer response.Body.Close()
+
+	err = handleResponseError(response, "Could not set middleware chain, it may have failed the test")
+	if err != nil {
+		return v2.MiddlewareChainView{}, err
+	}
+
+	var chainView v2.MiddlewareChainView
+
+	err = UnmarshalToInterface(response, &chainView)
+	if err != nil {
+		return v2.MiddlewareChainView{}, err
+	}
+
+	return chainView, nil
+}
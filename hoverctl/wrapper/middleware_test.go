@@ -101,7 +101,7 @@ func Test_GetMiddleware_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
 func Test_SetMiddleware_ErrorsWhen_HoverflyNotAccessible(t *testing.T) {
 	RegisterTestingT(t)
 
-	_, err := SetMiddleware(inaccessibleTarget, "", "", "")
+	_, err := SetMiddleware(inaccessibleTarget, v2.MiddlewareView{})
 
 	Expect(err).ToNot(BeNil())
 	Expect(err.Error()).To(Equal("Could not connect to Hoverfly at something:1234"))
@@ -141,7 +141,89 @@ func Test_SetMiddleware_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
 		},
 	})
 
-	_, err := SetMiddleware(target, "", "", "")
+	_, err := SetMiddleware(target, v2.MiddlewareView{})
 	Expect(err).ToNot(BeNil())
 	Expect(err.Error()).To(Equal("Could not set middleware, it may have failed the test\n\ntest error"))
 }
+
+func Test_GetMiddlewareChain_GetsTheMiddlewareChainFromHoverfly(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "GET",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/hoverfly/middleware/chain",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 200,
+						Body:   `{"stages": [{"phase": "on-miss", "modes": ["spy"], "remote": "http://test.com"}]}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	response, err := GetMiddlewareChain(target)
+	Expect(err).To(BeNil())
+
+	Expect(response.Stages).To(HaveLen(1))
+	Expect(response.Stages[0].Phase).To(Equal("on-miss"))
+	Expect(response.Stages[0].Modes).To(Equal([]string{"spy"}))
+	Expect(response.Stages[0].Remote).To(Equal("http://test.com"))
+}
+
+func Test_SetMiddlewareChain_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "PUT",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/hoverfly/middleware/chain",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 422,
+						Body:   `{"error": "test error"}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	_, err := SetMiddlewareChain(target, v2.MiddlewareChainView{})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not set middleware chain, it may have failed the test\n\ntest error"))
+}
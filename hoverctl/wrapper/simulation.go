@@ -112,3 +112,28 @@ func DeleteSimulations(target configuration.Target) error {
 
 	return nil
 }
+
+// CompactSimulation will merge similar pairs in the simulation of Hoverfly. When it is a dry run,
+// the simulation is left as it is and the merges which would have been made are returned.
+func CompactSimulation(target configuration.Target, dryRun bool) (*v2.SimulationCompactView, error) {
+	response, err := doRequest(target, "POST", fmt.Sprintf("%s?dryRun=%t", v2ApiSimulationCompact, dryRun), "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	err = handleResponseError(response, "Could not compact simulation")
+	if err != nil {
+		return nil, err
+	}
+
+	var compactView v2.SimulationCompactView
+
+	err = UnmarshalToInterface(response, &compactView)
+	if err != nil {
+		return nil, err
+	}
+
+	return &compactView, nil
+}
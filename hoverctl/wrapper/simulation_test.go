@@ -366,3 +366,102 @@ func Test_DeleteSimulations_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
 	Expect(err).ToNot(BeNil())
 	Expect(err.Error()).To(Equal("Could not delete simulation\n\ntest error"))
 }
+
+func Test_CompactSimulation_SendsCorrectHTTPRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "POST",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation/compact",
+							},
+						},
+						Query: &v2.QueryMatcherViewV5{
+							"dryRun": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "true",
+								},
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 200,
+						Body:   `{"dryRun":true,"pairsBefore":2,"pairsAfter":1,"merges":[{"pair":{"request":{"path":[{"matcher":"glob","value":"/users/*"}]},"response":{"status":200}},"replaces":[]}]}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	compactView, err := CompactSimulation(target, true)
+	Expect(err).To(BeNil())
+
+	Expect(compactView.DryRun).To(BeTrue())
+	Expect(compactView.PairsBefore).To(Equal(2))
+	Expect(compactView.PairsAfter).To(Equal(1))
+	Expect(compactView.Merges[0].Pair.RequestMatcher.Path[0].Value).To(Equal("/users/*"))
+}
+
+func Test_CompactSimulation_ErrorsWhen_HoverflyNotAccessible(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := CompactSimulation(inaccessibleTarget, false)
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not connect to Hoverfly at something:1234"))
+}
+
+func Test_CompactSimulation_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "POST",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation/compact",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 400,
+						Body:   "{\"error\":\"test error\"}",
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	_, err := CompactSimulation(target, false)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not compact simulation\n\ntest error"))
+}